@@ -0,0 +1,83 @@
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+// 命令
+type command struct {
+	Name        string
+	Usage       string
+	Description string
+	Run         func(args []string) error
+}
+
+// 命令列表
+var commands = []*command{
+	{
+		Name:        "new",
+		Usage:       "quark new <name> [-module path]",
+		Description: "创建新项目",
+		Run:         runNew,
+	},
+	{
+		Name:        "make:resource",
+		Usage:       "quark make:resource <Name> -model <file> [-struct Name] [-dir path]",
+		Description: "根据GORM模型创建资源",
+		Run:         runMakeResource,
+	},
+	{
+		Name:        "make:action",
+		Usage:       "quark make:action <Name> [-dir path]",
+		Description: "创建行为",
+		Run:         runMakeAction,
+	},
+	{
+		Name:        "make:search",
+		Usage:       "quark make:search <Name> [-column name] [-dir path]",
+		Description: "创建搜索项",
+		Run:         runMakeSearch,
+	},
+	{
+		Name:        "make:dashboard",
+		Usage:       "quark make:dashboard <Name> [-dir path]",
+		Description: "创建仪表盘",
+		Run:         runMakeDashboard,
+	},
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(1)
+	}
+
+	name := os.Args[1]
+	for _, cmd := range commands {
+		if cmd.Name == name {
+			if err := cmd.Run(os.Args[2:]); err != nil {
+				fmt.Fprintln(os.Stderr, "error:", err)
+				os.Exit(1)
+			}
+			return
+		}
+	}
+
+	if name != "help" && name != "-h" && name != "--help" {
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n", name)
+	}
+
+	usage()
+}
+
+// 打印帮助信息
+func usage() {
+	fmt.Fprintln(os.Stderr, "Usage: quark <command> [arguments]")
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "Commands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(os.Stderr, "  %-16s %s\n", cmd.Name, cmd.Description)
+		fmt.Fprintf(os.Stderr, "  %-16s %s\n", "", cmd.Usage)
+	}
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/gobeam/stringy"
+)
+
+// 解析命令参数，名称可位于参数之前或之后
+func parseArgs(fs *flag.FlagSet, args []string) (string, error) {
+	name := ""
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		name = args[0]
+		args = args[1:]
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return "", err
+	}
+
+	if name == "" {
+		name = fs.Arg(0)
+	}
+
+	if name == "" {
+		return "", errors.New("name is required")
+	}
+
+	return name, nil
+}
+
+// 转换为结构体名称，user_profile => UserProfile
+func structName(name string) string {
+	return stringy.New(name).CamelCase()
+}
+
+// 转换为文件名称，UserProfile => user_profile.go
+func fileName(name string) string {
+	return stringy.New(name).SnakeCase().ToLower() + ".go"
+}
+
+// 渲染模板并写入文件
+func writeTemplate(path string, tpl string, data interface{}, force bool) error {
+	if _, err := os.Stat(path); err == nil && !force {
+		return fmt.Errorf("%s already exists, use -force to overwrite", path)
+	}
+
+	t, err := template.New(filepath.Base(path)).Parse(tpl)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, data); err != nil {
+		return err
+	}
+
+	content := buf.Bytes()
+	if strings.HasSuffix(path, ".go") {
+		content, err = format.Source(content)
+		if err != nil {
+			return fmt.Errorf("format %s: %w", path, err)
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(path, content, 0644); err != nil {
+		return err
+	}
+
+	fmt.Println("created", path)
+
+	return nil
+}
+
+// 创建行为
+func runMakeAction(args []string) error {
+	fs := flag.NewFlagSet("make:action", flag.ContinueOnError)
+	dir := fs.String("dir", "internal/service/actions", "output directory")
+	title := fs.String("title", "", "action title")
+	force := fs.Bool("force", false, "overwrite existing file")
+
+	name, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+
+	data := map[string]string{
+		"Package": filepath.Base(*dir),
+		"Name":    structName(name),
+		"Title":   *title,
+	}
+	if data["Title"] == "" {
+		data["Title"] = data["Name"]
+	}
+
+	return writeTemplate(filepath.Join(*dir, fileName(name)), actionStub, data, *force)
+}
+
+// 创建搜索项
+func runMakeSearch(args []string) error {
+	fs := flag.NewFlagSet("make:search", flag.ContinueOnError)
+	dir := fs.String("dir", "internal/service/searches", "output directory")
+	column := fs.String("column", "", "column to search")
+	title := fs.String("title", "", "search label")
+	force := fs.Bool("force", false, "overwrite existing file")
+
+	name, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+
+	data := map[string]string{
+		"Package": filepath.Base(*dir),
+		"Name":    structName(name),
+		"Column":  *column,
+		"Title":   *title,
+	}
+	if data["Column"] == "" {
+		data["Column"] = stringy.New(name).SnakeCase().ToLower()
+	}
+	if data["Title"] == "" {
+		data["Title"] = data["Name"]
+	}
+
+	return writeTemplate(filepath.Join(*dir, fileName(name)), searchStub, data, *force)
+}
+
+// 创建仪表盘
+func runMakeDashboard(args []string) error {
+	fs := flag.NewFlagSet("make:dashboard", flag.ContinueOnError)
+	dir := fs.String("dir", "internal/service/dashboards", "output directory")
+	title := fs.String("title", "", "dashboard title")
+	force := fs.Bool("force", false, "overwrite existing file")
+
+	name, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+
+	data := map[string]string{
+		"Package": filepath.Base(*dir),
+		"Name":    structName(name),
+		"Title":   *title,
+	}
+	if data["Title"] == "" {
+		data["Title"] = data["Name"]
+	}
+
+	return writeTemplate(filepath.Join(*dir, fileName(name)), dashboardStub, data, *force)
+}
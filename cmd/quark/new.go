@@ -0,0 +1,57 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"path/filepath"
+
+	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
+	"github.com/quarkcloudio/quark-go/v2/pkg/utils/rand"
+)
+
+// 创建新项目
+func runNew(args []string) error {
+	fs := flag.NewFlagSet("new", flag.ContinueOnError)
+	module := fs.String("module", "", "module path, defaults to the project name")
+	dsn := fs.String("dsn", "root:@tcp(127.0.0.1:3306)/quarkgo?charset=utf8&parseTime=True&loc=Local", "mysql dsn")
+	addr := fs.String("addr", ":3000", "listen address")
+	force := fs.Bool("force", false, "overwrite existing files")
+
+	name, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+
+	if *module == "" {
+		*module = filepath.Base(name)
+	}
+
+	data := map[string]string{
+		"Module":  *module,
+		"Dsn":     *dsn,
+		"Addr":    *addr,
+		"AppKey":  rand.MakeAlphanumeric(32),
+		"Version": builder.Version,
+	}
+
+	files := map[string]string{
+		"main.go":                       mainStub,
+		"go.mod":                        goModStub,
+		"internal/service/providers.go": providersStub,
+	}
+
+	for path, stub := range files {
+		err := writeTemplate(filepath.Join(name, path), stub, data, *force)
+		if err != nil {
+			return err
+		}
+	}
+
+	fmt.Println()
+	fmt.Println("Project created, next steps:")
+	fmt.Println("  cd " + name)
+	fmt.Println("  go mod tidy")
+	fmt.Println("  go run .")
+
+	return nil
+}
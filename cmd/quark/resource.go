@@ -0,0 +1,366 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/gobeam/stringy"
+)
+
+// 模型字段
+type modelField struct {
+	Name     string // 结构体字段名
+	Column   string // 数据库字段名
+	Label    string // 显示名称
+	Type     string // Go类型
+	Size     int    // gorm size
+	NotNull  bool   // 是否必填
+	Unique   bool   // 是否唯一
+	Primary  bool   // 是否主键
+	TextType bool   // 是否为text类型
+}
+
+// 资源字段
+type resourceField struct {
+	Builder  string   // 字段构建代码
+	Search   string   // 搜索项构建代码
+	Imports  []string // 依赖的包
+	UseRules bool     // 是否使用了校验规则
+}
+
+// 根据GORM模型创建资源
+func runMakeResource(args []string) error {
+	fs := flag.NewFlagSet("make:resource", flag.ContinueOnError)
+	modelFile := fs.String("model", "", "go file that declares the GORM model")
+	modelStruct := fs.String("struct", "", "model struct name, defaults to the resource name")
+	modelImport := fs.String("import", "", "import path of the model package, detected from go.mod by default")
+	dir := fs.String("dir", "internal/service/resources", "output directory")
+	title := fs.String("title", "", "resource title")
+	force := fs.Bool("force", false, "overwrite existing file")
+
+	name, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+
+	if *modelFile == "" {
+		return errors.New("-model is required")
+	}
+
+	if *modelStruct == "" {
+		*modelStruct = structName(name)
+	}
+
+	fields, pkgName, err := parseModel(*modelFile, *modelStruct)
+	if err != nil {
+		return err
+	}
+
+	if *modelImport == "" {
+		*modelImport, err = detectImportPath(filepath.Dir(*modelFile))
+		if err != nil {
+			return err
+		}
+	}
+
+	imports := map[string]bool{
+		"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/service/actions":   true,
+		"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/template/resource": true,
+		"github.com/quarkcloudio/quark-go/v2/pkg/builder":                     true,
+		*modelImport: true,
+	}
+
+	builders := []string{}
+	searches := []string{}
+	for _, field := range fields {
+		rf := buildResourceField(field)
+		if rf == nil {
+			continue
+		}
+		builders = append(builders, rf.Builder)
+		if rf.Search != "" {
+			searches = append(searches, rf.Search)
+			imports["github.com/quarkcloudio/quark-go/v2/pkg/app/admin/service/searches"] = true
+		}
+		for _, v := range rf.Imports {
+			imports[v] = true
+		}
+	}
+
+	importList := []string{}
+	for k := range imports {
+		if k == *modelImport && pkgName != filepath.Base(k) {
+			importList = append(importList, pkgName+" "+strconv.Quote(k))
+			continue
+		}
+		importList = append(importList, strconv.Quote(k))
+	}
+
+	data := map[string]interface{}{
+		"Package":  filepath.Base(*dir),
+		"Name":     structName(name),
+		"Title":    *title,
+		"Model":    pkgName + "." + *modelStruct,
+		"Imports":  importList,
+		"Fields":   builders,
+		"Searches": searches,
+	}
+	if *title == "" {
+		data["Title"] = structName(name)
+	}
+
+	return writeTemplate(filepath.Join(*dir, fileName(name)), resourceStub, data, *force)
+}
+
+// 解析模型文件中的结构体字段
+func parseModel(file string, name string) ([]*modelField, string, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, file, nil, parser.ParseComments)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var spec *ast.StructType
+	ast.Inspect(f, func(n ast.Node) bool {
+		ts, ok := n.(*ast.TypeSpec)
+		if !ok || ts.Name.Name != name {
+			return true
+		}
+		if st, ok := ts.Type.(*ast.StructType); ok {
+			spec = st
+		}
+		return false
+	})
+	if spec == nil {
+		return nil, "", fmt.Errorf("struct %s not found in %s", name, file)
+	}
+
+	fields := []*modelField{}
+	for _, v := range spec.Fields.List {
+		if len(v.Names) == 0 {
+			continue
+		}
+
+		tag := ""
+		if v.Tag != nil {
+			tag, _ = strconv.Unquote(v.Tag.Value)
+		}
+
+		for _, ident := range v.Names {
+			if !ident.IsExported() {
+				continue
+			}
+
+			field := &modelField{
+				Name:  ident.Name,
+				Type:  exprString(v.Type),
+				Label: ident.Name,
+			}
+
+			// 字段注释作为显示名称
+			if v.Comment != nil {
+				field.Label = strings.TrimSpace(v.Comment.Text())
+			} else if v.Doc != nil {
+				field.Label = strings.TrimSpace(v.Doc.Text())
+			}
+
+			parseTags(field, reflect.StructTag(tag))
+			fields = append(fields, field)
+		}
+	}
+
+	return fields, f.Name.Name, nil
+}
+
+// 解析json、gorm标签
+func parseTags(field *modelField, tag reflect.StructTag) {
+	field.Column = stringy.New(field.Name).SnakeCase().ToLower()
+
+	if jsonTag := tag.Get("json"); jsonTag != "" {
+		name := strings.Split(jsonTag, ",")[0]
+		if name != "" && name != "-" {
+			field.Column = name
+		}
+	}
+
+	for _, item := range strings.Split(tag.Get("gorm"), ";") {
+		kv := strings.SplitN(strings.TrimSpace(item), ":", 2)
+		key := strings.ToLower(kv[0])
+		value := ""
+		if len(kv) == 2 {
+			value = kv[1]
+		}
+
+		switch key {
+		case "column":
+			field.Column = value
+		case "size":
+			field.Size, _ = strconv.Atoi(value)
+		case "not null":
+			field.NotNull = true
+		case "unique", "uniqueindex":
+			field.Unique = true
+		case "primarykey", "autoincrement":
+			field.Primary = true
+		case "type":
+			field.TextType = strings.Contains(strings.ToLower(value), "text")
+		case "index":
+			field.Unique = field.Unique || strings.Contains(value, "unique")
+		}
+	}
+
+	if field.Name == "Id" || field.Name == "ID" {
+		field.Primary = true
+	}
+}
+
+// 根据字段类型推断资源字段
+func buildResourceField(field *modelField) *resourceField {
+	column := strconv.Quote(field.Column)
+	label := strconv.Quote(field.Label)
+	lowerName := strings.ToLower(field.Name)
+	rf := &resourceField{}
+
+	switch {
+	case field.Type == "gorm.DeletedAt":
+		return nil
+	case field.Primary:
+		rf.Builder = "field.ID(" + column + ", " + label + ")"
+		return rf
+	case field.Type == "time.Time" || field.Type == "*time.Time":
+		rf.Builder = "field.Datetime(" + column + ", " + label + ")"
+		if lowerName == "createdat" || lowerName == "updatedat" {
+			rf.Builder += ".OnlyOnIndex()"
+		} else {
+			rf.Search = "searches.DatetimeRange(" + column + ", " + label + ")"
+		}
+		return rf
+	case strings.Contains(lowerName, "password"):
+		rf.Builder = "field.Password(" + column + ", " + label + ").OnlyOnForms()"
+		return rf
+	case lowerName == "status" || field.Type == "bool":
+		rf.Builder = "field.Switch(" + column + ", " + label + ").\n" +
+			"SetTrueValue(\"正常\").\n" +
+			"SetFalseValue(\"禁用\").\n" +
+			"SetEditable(true).\n" +
+			"SetDefault(true)"
+		if lowerName == "status" {
+			rf.Search = "searches.Status()"
+		}
+		return rf
+	case hasColumnWord(field.Column, "avatar", "cover", "image", "images"):
+		rf.Builder = "field.Image(" + column + ", " + label + ").OnlyOnForms()"
+		return rf
+	case field.Type == "string" && (field.TextType || field.Size > 255):
+		rf.Builder = "field.TextArea(" + column + ", " + label + ").OnlyOnForms()"
+	case field.Type == "string":
+		rf.Builder = "field.Text(" + column + ", " + label + ")"
+		rf.Search = "searches.Input(" + column + ", " + label + ")"
+	case isNumberType(field.Type):
+		rf.Builder = "field.Number(" + column + ", " + label + ")"
+	default:
+		rf.Builder = "field.Text(" + column + ", " + label + ")"
+	}
+
+	rules := []string{}
+	if field.NotNull {
+		rules = append(rules, "rule.Required(true, "+strconv.Quote(field.Label+"必须填写")+")")
+	}
+	if field.Type == "string" && field.Size > 0 && !field.TextType {
+		rules = append(rules, "rule.Max("+strconv.Itoa(field.Size)+", "+strconv.Quote(field.Label+"不能超过"+strconv.Itoa(field.Size)+"个字符")+")")
+	}
+	if len(rules) > 0 {
+		rf.Builder += ".\nSetRules([]*rule.Rule{\n" + strings.Join(rules, ",\n") + ",\n})"
+		rf.Imports = append(rf.Imports, "github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/form/rule")
+	}
+
+	return rf
+}
+
+// 字段名按下划线分词后是否包含指定单词，避免recovery等字段匹配到cover
+func hasColumnWord(column string, words ...string) bool {
+	for _, v := range strings.Split(strings.ToLower(column), "_") {
+		for _, word := range words {
+			if v == word {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// 是否为数值类型
+func isNumberType(t string) bool {
+	switch t {
+	case "int", "int8", "int16", "int32", "int64",
+		"uint", "uint8", "uint16", "uint32", "uint64",
+		"float32", "float64":
+		return true
+	}
+
+	return false
+}
+
+// 类型表达式转换为字符串
+func exprString(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		return t.Name
+	case *ast.StarExpr:
+		return "*" + exprString(t.X)
+	case *ast.SelectorExpr:
+		return exprString(t.X) + "." + t.Sel.Name
+	case *ast.ArrayType:
+		return "[]" + exprString(t.Elt)
+	case *ast.MapType:
+		return "map[" + exprString(t.Key) + "]" + exprString(t.Value)
+	}
+
+	return ""
+}
+
+// 根据go.mod推断目录的导入路径
+func detectImportPath(dir string) (string, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+
+	root := abs
+	for {
+		content, err := os.ReadFile(filepath.Join(root, "go.mod"))
+		if err == nil {
+			for _, line := range strings.Split(string(content), "\n") {
+				line = strings.TrimSpace(line)
+				if strings.HasPrefix(line, "module ") {
+					module := strings.TrimSpace(strings.TrimPrefix(line, "module "))
+					rel, err := filepath.Rel(root, abs)
+					if err != nil {
+						return "", err
+					}
+					if rel == "." {
+						return module, nil
+					}
+					return module + "/" + filepath.ToSlash(rel), nil
+				}
+			}
+			return "", fmt.Errorf("module directive not found in %s", filepath.Join(root, "go.mod"))
+		}
+
+		parent := filepath.Dir(root)
+		if parent == root {
+			return "", errors.New("go.mod not found, use -import to set the model import path")
+		}
+		root = parent
+	}
+}
@@ -0,0 +1,243 @@
+package main
+
+// 项目入口模板
+const mainStub = `package main
+
+import (
+	"{{.Module}}/internal/service"
+	admininstall "github.com/quarkcloudio/quark-go/v2/pkg/app/admin/install"
+	adminmiddleware "github.com/quarkcloudio/quark-go/v2/pkg/app/admin/middleware"
+	adminservice "github.com/quarkcloudio/quark-go/v2/pkg/app/admin/service"
+	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func main() {
+
+	// 定义服务
+	var providers []interface{}
+
+	// 数据库配置信息
+	dsn := {{printf "%q" .Dsn}}
+
+	// 加载后台服务
+	providers = append(providers, adminservice.Providers...)
+
+	// 加载自定义服务
+	providers = append(providers, service.Providers...)
+
+	// 配置资源
+	config := &builder.Config{
+		AppKey:    {{printf "%q" .AppKey}},
+		Providers: providers,
+		DBConfig: &builder.DBConfig{
+			Dialector: mysql.Open(dsn),
+			Opts:      &gorm.Config{},
+		},
+	}
+
+	// 实例化对象
+	b := builder.New(config)
+
+	// WEB根目录
+	b.Static("/", "./web/app")
+
+	// 构建管理后台数据库
+	admininstall.Handle()
+
+	// 管理后台中间件
+	b.Use(adminmiddleware.Handle)
+
+	// 启动服务
+	b.Run({{printf "%q" .Addr}})
+}
+`
+
+// go.mod模板
+const goModStub = `module {{.Module}}
+
+go 1.18
+
+require github.com/quarkcloudio/quark-go/v2 v{{.Version}}
+`
+
+// 服务注册模板
+const providersStub = `package service
+
+// 注册服务
+var Providers = []interface{}{}
+`
+
+// 资源模板
+const resourceStub = `package {{.Package}}
+
+import (
+{{range .Imports}}	{{.}}
+{{end}})
+
+type {{.Name}} struct {
+	resource.Template
+}
+
+// 初始化
+func (p *{{.Name}}) Init(ctx *builder.Context) interface{} {
+
+	// 标题
+	p.Title = {{printf "%q" .Title}}
+
+	// 模型
+	p.Model = &{{.Model}}{}
+
+	// 分页
+	p.PerPage = 10
+
+	return p
+}
+
+// 字段
+func (p *{{.Name}}) Fields(ctx *builder.Context) []interface{} {
+	field := &resource.Field{}
+
+	return []interface{}{
+{{range .Fields}}		{{.}},
+{{end}}	}
+}
+
+// 搜索
+func (p *{{.Name}}) Searches(ctx *builder.Context) []interface{} {
+	return []interface{}{
+{{range .Searches}}		{{.}},
+{{end}}	}
+}
+
+// 行为
+func (p *{{.Name}}) Actions(ctx *builder.Context) []interface{} {
+	return []interface{}{
+		actions.CreateLink(),
+		actions.BatchDelete(),
+		actions.DetailLink(),
+		actions.More().
+			SetActions([]interface{}{
+				actions.EditLink(),
+				actions.Delete(),
+			}),
+		actions.FormSubmit(),
+		actions.FormReset(),
+		actions.FormBack(),
+		actions.FormExtraBack(),
+	}
+}
+`
+
+// 行为模板
+const actionStub = `package {{.Package}}
+
+import (
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/message"
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/template/resource/actions"
+	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
+	"gorm.io/gorm"
+)
+
+type {{.Name}}Action struct {
+	actions.Action
+}
+
+// {{.Title}}，{{.Name}}() | {{.Name}}({{printf "%q" .Title}})
+func {{.Name}}(options ...interface{}) *{{.Name}}Action {
+	action := &{{.Name}}Action{}
+
+	action.Name = {{printf "%q" .Title}}
+	if len(options) == 1 {
+		action.Name = options[0].(string)
+	}
+
+	return action
+}
+
+// 初始化
+func (p *{{.Name}}Action) Init(ctx *builder.Context) interface{} {
+
+	// 设置按钮类型,primary | ghost | dashed | link | text | default
+	p.Type = "link"
+
+	// 设置按钮大小,large | middle | small | default
+	p.Size = "small"
+
+	// 执行成功后刷新的组件
+	p.Reload = "table"
+
+	// 设置展示位置
+	p.SetOnlyOnIndexTableRow(true)
+
+	return p
+}
+
+// 行为接口接收的参数，当行为在表格行展示的时候，可以配置当前行的任意字段
+func (p *{{.Name}}Action) GetApiParams() []string {
+	return []string{
+		"id",
+	}
+}
+
+// 执行行为句柄
+func (p *{{.Name}}Action) Handle(ctx *builder.Context, query *gorm.DB) error {
+
+	return ctx.JSON(200, message.Success("操作成功"))
+}
+`
+
+// 搜索项模板
+const searchStub = `package {{.Package}}
+
+import (
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/template/resource/searches"
+	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
+	"gorm.io/gorm"
+)
+
+type {{.Name}}Field struct {
+	searches.Search
+}
+
+// {{.Title}}
+func {{.Name}}() *{{.Name}}Field {
+	field := &{{.Name}}Field{}
+
+	field.Column = {{printf "%q" .Column}}
+	field.Name = {{printf "%q" .Title}}
+
+	return field
+}
+
+// 执行查询
+func (p *{{.Name}}Field) Apply(ctx *builder.Context, query *gorm.DB, value interface{}) *gorm.DB {
+	return query.Where(p.Column+" = ?", value)
+}
+`
+
+// 仪表盘模板
+const dashboardStub = `package {{.Package}}
+
+import (
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/template/dashboard"
+	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
+)
+
+type {{.Name}} struct {
+	dashboard.Template
+}
+
+// 初始化
+func (p *{{.Name}}) Init(ctx *builder.Context) interface{} {
+	p.Title = {{printf "%q" .Title}}
+
+	return p
+}
+
+// 内容
+func (p *{{.Name}}) Cards(ctx *builder.Context) []interface{} {
+	return []interface{}{}
+}
+`
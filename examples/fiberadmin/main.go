@@ -1,10 +1,11 @@
 package main
 
 import (
+	"net/http"
 	"strings"
-	"time"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/filesystem"
 	"github.com/quarkcloudio/quark-go/v2/pkg/adapter/fiberadapter"
 	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/install"
 	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/middleware"
@@ -28,16 +29,6 @@ func main() {
 		return c.Next()
 	})
 
-	// WEB根目录
-	app.Static("/", "./web/app", fiber.Static{
-		Compress:      true,
-		ByteRange:     true,
-		Browse:        false,
-		Index:         "index.html",
-		CacheDuration: 1 * time.Second,
-		MaxAge:        3600,
-	})
-
 	// 数据库配置信息
 	dsn := "root:fK7xPGJi1gJfIief@tcp(127.0.0.1:3306)/quarkgo?charset=utf8&parseTime=True&loc=Local"
 
@@ -63,5 +54,12 @@ func main() {
 	// 适配fiber
 	fiberadapter.Adapter(b, app)
 
+	// WEB根目录，使用内嵌的前端文件，存在./web/app目录时优先使用目录中的文件
+	app.Use("/", filesystem.New(filesystem.Config{
+		Root:   http.FS(b.WebFS()),
+		Index:  "index.html",
+		MaxAge: 3600,
+	}))
+
 	app.Listen(":3000")
 }
@@ -3,8 +3,12 @@
 package main
 
 import (
+	"context"
+	"net/http"
+
 	"github.com/cloudwego/hertz/pkg/app"
 	"github.com/cloudwego/hertz/pkg/app/server"
+	"github.com/cloudwego/hertz/pkg/common/adaptor"
 	"github.com/quarkcloudio/quark-go/v2/pkg/adapter/hertzadapter"
 	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/install"
 	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/middleware"
@@ -20,13 +24,6 @@ func main() {
 	// 注册路由
 	register(h)
 
-	// 静态文件
-	h.StaticFile("/admin/", "./web/app/admin/index.html")
-
-	// WEB根目录
-	fs := &app.FS{Root: "./web/app", IndexNames: []string{"index.html"}}
-	h.StaticFS("/", fs)
-
 	// 数据库配置信息
 	dsn := "root:fK7xPGJi1gJfIief@tcp(127.0.0.1:3306)/quarkgo?charset=utf8&parseTime=True&loc=Local"
 
@@ -52,6 +49,17 @@ func main() {
 	// 适配hertz
 	hertzadapter.Adapter(b, h)
 
+	// WEB根目录，使用内嵌的前端文件，存在./web/app目录时优先使用目录中的文件
+	fileServer := http.FileServer(http.FS(b.WebFS()))
+	h.NoRoute(func(c context.Context, ctx *app.RequestContext) {
+		req, err := adaptor.GetCompatRequest(&ctx.Request)
+		if err != nil {
+			ctx.AbortWithError(http.StatusInternalServerError, err)
+			return
+		}
+		fileServer.ServeHTTP(adaptor.GetCompatResponseWriter(&ctx.Response), req)
+	})
+
 	// 启动服务
 	h.Spin()
 }
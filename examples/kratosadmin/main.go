@@ -45,7 +45,7 @@ func main() {
 	kratosadapter.Adapter(b, hs)
 
 	// WEB根目录，只能放在后面，否则与其他路由有冲突
-	hs.HandlePrefix("/", stdhttp.FileServer(stdhttp.Dir("./web/app")))
+	hs.HandlePrefix("/", stdhttp.FileServer(stdhttp.FS(b.WebFS())))
 
 	// 创建服务
 	app := kratos.New(
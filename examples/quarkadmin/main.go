@@ -50,7 +50,7 @@ func main() {
 	b := builder.New(config)
 
 	// WEB根目录
-	b.Static("/", "./web/app")
+	b.StaticFS("/", b.WebFS())
 
 	// 构建管理后台数据库
 	admininstall.Handle()
@@ -38,7 +38,7 @@ func main() {
 	b := builder.New(config)
 
 	// WEB根目录
-	b.Static("/", "./web/app")
+	b.StaticFS("/", b.WebFS())
 
 	// 自动构建数据库、拉取静态文件
 	install.Handle()
@@ -4,7 +4,6 @@ import (
 	"flag"
 	"fmt"
 	"net/http"
-	"os"
 
 	"github.com/quarkcloudio/quark-go/v2/examples/zeroadmin/internal/config"
 	"github.com/quarkcloudio/quark-go/v2/examples/zeroadmin/internal/handler"
@@ -29,15 +28,6 @@ func main() {
 	var c config.Config
 	conf.MustLoad(*configFile, &c)
 
-	server := rest.MustNewServer(c.RestConf)
-	defer server.Stop()
-
-	// WEB根目录
-	staticFile("/", "./web/app", server)
-
-	ctx := svc.NewServiceContext(c)
-	handler.RegisterHandlers(server, ctx)
-
 	// 数据库配置信息
 	dsn := "root:fK7xPGJi1gJfIief@tcp(127.0.0.1:3306)/quarkgo?charset=utf8&parseTime=True&loc=Local"
 
@@ -54,6 +44,13 @@ func main() {
 	// 创建对象
 	b := builder.New(config)
 
+	// WEB根目录，使用内嵌的前端文件，存在./web/app目录时优先使用目录中的文件
+	server := rest.MustNewServer(c.RestConf, rest.WithNotFoundHandler(http.FileServer(http.FS(b.WebFS()))))
+	defer server.Stop()
+
+	ctx := svc.NewServiceContext(c)
+	handler.RegisterHandlers(server, ctx)
+
 	// 初始化安装
 	install.Handle()
 
@@ -66,34 +63,3 @@ func main() {
 	fmt.Printf("Starting server at %s:%d...\n", c.Host, c.Port)
 	server.Start()
 }
-
-// 加载静态文件
-func staticFile(root string, dirPath string, server *rest.Server) {
-	rd, _ := os.ReadDir(dirPath)
-
-	for _, f := range rd {
-		fileName := f.Name()
-		subPath := root + fileName + "/"
-		subDirPath := dirPath + "/" + fileName
-		if isDir(subDirPath) {
-			staticFile(subPath, subDirPath, server)
-		}
-	}
-
-	server.AddRoute(
-		rest.Route{
-			Method:  http.MethodGet,
-			Path:    root + ":file",
-			Handler: http.StripPrefix(root, http.FileServer(http.Dir(dirPath))).ServeHTTP,
-		},
-	)
-}
-
-// 判断所给路径是否为文件夹
-func isDir(path string) bool {
-	s, err := os.Stat(path)
-	if err != nil {
-		return false
-	}
-	return s.IsDir()
-}
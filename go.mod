@@ -12,7 +12,7 @@ require (
 	github.com/dchest/captcha v1.0.0
 	github.com/derekstavis/go-qs v0.0.0-20180720192143-9eef69e6c4e7
 	github.com/gabriel-vasile/mimetype v1.4.2
-	github.com/gin-contrib/static v0.0.1
+	github.com/glebarez/sqlite v1.9.0
 	github.com/go-basic/uuid v1.0.0
 	github.com/gobeam/stringy v0.0.6
 	github.com/gofiber/fiber/v2 v2.47.0
@@ -20,8 +20,10 @@ require (
 	github.com/parnurzeal/gorequest v0.2.16
 	github.com/redis/go-redis/v9 v9.0.3
 	github.com/shirou/gopsutil v3.21.11+incompatible
+	github.com/skip2/go-qrcode v0.0.0-20200617195104-da1b6568686e
 	github.com/xuri/excelize/v2 v2.7.1
 	golang.org/x/crypto v0.14.0
+	golang.org/x/image v0.5.0
 	gorm.io/driver/mysql v1.5.1
 	gorm.io/gorm v1.25.2
 )
@@ -36,7 +38,6 @@ require (
 	github.com/fatih/color v1.15.0 // indirect
 	github.com/gin-contrib/sse v0.1.0 // indirect
 	github.com/glebarez/go-sqlite v1.21.2 // indirect
-	github.com/glebarez/sqlite v1.9.0 // indirect
 	github.com/go-kratos/aegis v0.2.0 // indirect
 	github.com/go-logr/logr v1.2.4 // indirect
 	github.com/go-logr/stdr v1.2.2 // indirect
@@ -31,44 +31,10 @@ func RouteAdapter(b *builder.Engine, ctx *fiber.Ctx) error {
 // 适配gofiber框架
 func Adapter(b *builder.Engine, app *fiber.App) {
 
-	// 获取注册的服务
-	routePaths := b.GetRoutePaths()
-
 	// 解析服务
-	for _, v := range routePaths {
-		switch v.Method {
-		case "GET":
-			app.Get(v.Path, func(ctx *fiber.Ctx) error {
-				return RouteAdapter(b, ctx)
-			})
-		case "HEAD":
-			app.Head(v.Path, func(ctx *fiber.Ctx) error {
-				return RouteAdapter(b, ctx)
-			})
-		case "OPTIONS":
-			app.Options(v.Path, func(ctx *fiber.Ctx) error {
-				return RouteAdapter(b, ctx)
-			})
-		case "POST":
-			app.Post(v.Path, func(ctx *fiber.Ctx) error {
-				return RouteAdapter(b, ctx)
-			})
-		case "PUT":
-			app.Put(v.Path, func(ctx *fiber.Ctx) error {
-				return RouteAdapter(b, ctx)
-			})
-		case "PATCH":
-			app.Patch(v.Path, func(ctx *fiber.Ctx) error {
-				return RouteAdapter(b, ctx)
-			})
-		case "DELETE":
-			app.Delete(v.Path, func(ctx *fiber.Ctx) error {
-				return RouteAdapter(b, ctx)
-			})
-		case "Any":
-			app.All(v.Path, func(ctx *fiber.Ctx) error {
-				return RouteAdapter(b, ctx)
-			})
-		}
-	}
+	b.WalkRoutes(func(method string, path string) {
+		app.Add(method, path, func(ctx *fiber.Ctx) error {
+			return RouteAdapter(b, ctx)
+		})
+	})
 }
@@ -38,44 +38,10 @@ func RouteAdapter(b *builder.Engine, ctx *app.RequestContext) {
 // 适配hertz框架
 func Adapter(b *builder.Engine, r *server.Hertz) {
 
-	// 获取注册的服务
-	routePaths := b.GetRoutePaths()
-
 	// 解析服务
-	for _, v := range routePaths {
-		switch v.Method {
-		case "GET":
-			r.GET(v.Path, func(c context.Context, ctx *app.RequestContext) {
-				RouteAdapter(b, ctx)
-			})
-		case "HEAD":
-			r.HEAD(v.Path, func(c context.Context, ctx *app.RequestContext) {
-				RouteAdapter(b, ctx)
-			})
-		case "OPTIONS":
-			r.OPTIONS(v.Path, func(c context.Context, ctx *app.RequestContext) {
-				RouteAdapter(b, ctx)
-			})
-		case "POST":
-			r.POST(v.Path, func(c context.Context, ctx *app.RequestContext) {
-				RouteAdapter(b, ctx)
-			})
-		case "PUT":
-			r.PUT(v.Path, func(c context.Context, ctx *app.RequestContext) {
-				RouteAdapter(b, ctx)
-			})
-		case "PATCH":
-			r.PATCH(v.Path, func(c context.Context, ctx *app.RequestContext) {
-				RouteAdapter(b, ctx)
-			})
-		case "DELETE":
-			r.DELETE(v.Path, func(c context.Context, ctx *app.RequestContext) {
-				RouteAdapter(b, ctx)
-			})
-		case "Any":
-			r.Any(v.Path, func(c context.Context, ctx *app.RequestContext) {
-				RouteAdapter(b, ctx)
-			})
-		}
-	}
+	b.WalkRoutes(func(method string, path string) {
+		r.Handle(method, path, func(c context.Context, ctx *app.RequestContext) {
+			RouteAdapter(b, ctx)
+		})
+	})
 }
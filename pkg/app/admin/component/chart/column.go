@@ -0,0 +1,154 @@
+package chart
+
+import "github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/component"
+
+type Column struct {
+	component.Element
+	Api              string      `json:"api"`
+	Width            int         `json:"width"`
+	Height           int         `json:"height"`
+	AutoFit          bool        `json:"autoFit"`
+	Padding          interface{} `json:"padding"`
+	AppendPadding    interface{} `json:"appendPadding"`
+	Renderer         string      `json:"renderer"`
+	LimitInPlot      bool        `json:"limitInPlot"`
+	Locale           string      `json:"locale"`
+	Data             interface{} `json:"data"`
+	XField           string      `json:"xField"`
+	YField           string      `json:"yField"`
+	SeriesField      string      `json:"seriesField"`
+	IsGroup          bool        `json:"isGroup"`
+	IsStack          bool        `json:"isStack"`
+	IsPercent        bool        `json:"isPercent"`
+	ColumnWidthRatio float64     `json:"columnWidthRatio,omitempty"`
+	Label            interface{} `json:"label"`
+	Meta             interface{} `json:"meta"`
+}
+
+// 柱状图
+func NewColumn(data interface{}) *Column {
+	return (&Column{}).Init().SetData(data)
+}
+
+// 初始化
+func (p *Column) Init() *Column {
+	p.Component = "column"
+	p.SetKey(component.DEFAULT_KEY, component.DEFAULT_CRYPT)
+
+	return p
+}
+
+// 数据接口
+func (p *Column) SetApi(api string) *Column {
+	p.Api = api
+	return p
+}
+
+// 设置图表宽度
+func (p *Column) SetWidth(width int) *Column {
+	p.Width = width
+	return p
+}
+
+// 设置图表高度
+func (p *Column) SetHeight(height int) *Column {
+	p.Height = height
+	return p
+}
+
+// 图表是否自适应容器宽高。当 autoFit 设置为 true 时，width 和 height 的设置将失效。
+func (p *Column) SetAutoFit(autoFit bool) *Column {
+	p.AutoFit = autoFit
+	return p
+}
+
+// 画布的 padding 值，代表图表在上右下左的间距，可以为单个数字 16，或者数组 [16, 8, 16, 8] 代表四个方向，或者开启 auto，由底层自动计算间距。
+func (p *Column) SetPadding(padding interface{}) *Column {
+	p.Padding = padding
+	return p
+}
+
+// 额外增加的 appendPadding 值，在 padding 的基础上，设置额外的 padding 数值，可以是单个数字 16，或者数组 [16, 8, 16, 8] 代表四个方向。
+func (p *Column) SetAppendPadding(appendPadding interface{}) *Column {
+	p.AppendPadding = appendPadding
+	return p
+}
+
+// 设置图表渲染方式为 canvas 或 svg。
+func (p *Column) SetRenderer(renderer string) *Column {
+	p.Renderer = renderer
+	return p
+}
+
+// 是否对超出坐标系范围的 Geometry 进行剪切。
+func (p *Column) SetLimitInPlot(limitInPlot bool) *Column {
+	p.LimitInPlot = limitInPlot
+	return p
+}
+
+// 指定具体语言，目前内置 'zh-CN' and 'en-US' 两个语言，你也可以使用 G2Plot.registerLocale 方法注册新的语言。语言包格式参考：src/locales/en_US.ts
+func (p *Column) SetLocale(locale string) *Column {
+	p.Locale = locale
+	return p
+}
+
+// 数据
+func (p *Column) SetData(data interface{}) *Column {
+	p.Data = data
+	return p
+}
+
+// X轴字段
+func (p *Column) SetXField(xField string) *Column {
+	p.XField = xField
+	return p
+}
+
+// y轴字段
+func (p *Column) SetYField(yField string) *Column {
+	p.YField = yField
+	return p
+}
+
+// 分组字段，用于多系列图表
+func (p *Column) SetSeriesField(seriesField string) *Column {
+	p.SeriesField = seriesField
+	return p
+}
+
+// 是否分组柱形图
+func (p *Column) SetIsGroup(isGroup bool) *Column {
+	p.IsGroup = isGroup
+	return p
+}
+
+// 是否堆积柱状图
+func (p *Column) SetIsStack(isStack bool) *Column {
+	p.IsStack = isStack
+	return p
+}
+
+// 是否百分比柱状图，isStack 为 true 时有效
+func (p *Column) SetIsPercent(isPercent bool) *Column {
+	p.IsPercent = isPercent
+	return p
+}
+
+// 柱状图宽度占比 [0-1]
+func (p *Column) SetColumnWidthRatio(columnWidthRatio float64) *Column {
+	p.ColumnWidthRatio = columnWidthRatio
+	return p
+}
+
+// 数据标签配置，设置为 false 时不显示
+func (p *Column) SetLabel(label interface{}) *Column {
+	p.Label = label
+	return p
+}
+
+// 通过 meta 可以全局化配置图表数据元信息，以字段为单位进行配置。在 meta 上的配置将同时影响所有组件的文本信息。传入以字段名为 key，MetaOption 为 value 的配置，同时设置多个字段的元信息。
+func (p *Column) SetMeta(meta interface{}) *Column {
+	p.Meta = meta
+
+	return p
+}
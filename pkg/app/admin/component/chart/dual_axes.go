@@ -0,0 +1,119 @@
+package chart
+
+import "github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/component"
+
+type DualAxes struct {
+	component.Element
+	Api             string      `json:"api"`
+	Width           int         `json:"width"`
+	Height          int         `json:"height"`
+	AutoFit         bool        `json:"autoFit"`
+	Padding         interface{} `json:"padding"`
+	AppendPadding   interface{} `json:"appendPadding"`
+	Renderer        string      `json:"renderer"`
+	LimitInPlot     bool        `json:"limitInPlot"`
+	Locale          string      `json:"locale"`
+	Data            interface{} `json:"data"`
+	XField          string      `json:"xField"`
+	YField          interface{} `json:"yField"`
+	GeometryOptions interface{} `json:"geometryOptions"`
+	Meta            interface{} `json:"meta"`
+}
+
+// 双轴图
+func NewDualAxes(data interface{}) *DualAxes {
+	return (&DualAxes{}).Init().SetData(data)
+}
+
+// 初始化
+func (p *DualAxes) Init() *DualAxes {
+	p.Component = "dualAxes"
+	p.SetKey(component.DEFAULT_KEY, component.DEFAULT_CRYPT)
+
+	return p
+}
+
+// 数据接口
+func (p *DualAxes) SetApi(api string) *DualAxes {
+	p.Api = api
+	return p
+}
+
+// 设置图表宽度
+func (p *DualAxes) SetWidth(width int) *DualAxes {
+	p.Width = width
+	return p
+}
+
+// 设置图表高度
+func (p *DualAxes) SetHeight(height int) *DualAxes {
+	p.Height = height
+	return p
+}
+
+// 图表是否自适应容器宽高。当 autoFit 设置为 true 时，width 和 height 的设置将失效。
+func (p *DualAxes) SetAutoFit(autoFit bool) *DualAxes {
+	p.AutoFit = autoFit
+	return p
+}
+
+// 画布的 padding 值，代表图表在上右下左的间距，可以为单个数字 16，或者数组 [16, 8, 16, 8] 代表四个方向，或者开启 auto，由底层自动计算间距。
+func (p *DualAxes) SetPadding(padding interface{}) *DualAxes {
+	p.Padding = padding
+	return p
+}
+
+// 额外增加的 appendPadding 值，在 padding 的基础上，设置额外的 padding 数值，可以是单个数字 16，或者数组 [16, 8, 16, 8] 代表四个方向。
+func (p *DualAxes) SetAppendPadding(appendPadding interface{}) *DualAxes {
+	p.AppendPadding = appendPadding
+	return p
+}
+
+// 设置图表渲染方式为 canvas 或 svg。
+func (p *DualAxes) SetRenderer(renderer string) *DualAxes {
+	p.Renderer = renderer
+	return p
+}
+
+// 是否对超出坐标系范围的 Geometry 进行剪切。
+func (p *DualAxes) SetLimitInPlot(limitInPlot bool) *DualAxes {
+	p.LimitInPlot = limitInPlot
+	return p
+}
+
+// 指定具体语言，目前内置 'zh-CN' and 'en-US' 两个语言，你也可以使用 G2Plot.registerLocale 方法注册新的语言。语言包格式参考：src/locales/en_US.ts
+func (p *DualAxes) SetLocale(locale string) *DualAxes {
+	p.Locale = locale
+	return p
+}
+
+// 数据
+func (p *DualAxes) SetData(data interface{}) *DualAxes {
+	p.Data = data
+	return p
+}
+
+// X轴字段
+func (p *DualAxes) SetXField(xField string) *DualAxes {
+	p.XField = xField
+	return p
+}
+
+// y轴字段，数组格式，例如：["value", "count"]
+func (p *DualAxes) SetYField(yField interface{}) *DualAxes {
+	p.YField = yField
+	return p
+}
+
+// 两个轴分别对应的图形配置，例如：[{geometry: 'column'}, {geometry: 'line'}]
+func (p *DualAxes) SetGeometryOptions(geometryOptions interface{}) *DualAxes {
+	p.GeometryOptions = geometryOptions
+	return p
+}
+
+// 通过 meta 可以全局化配置图表数据元信息，以字段为单位进行配置。在 meta 上的配置将同时影响所有组件的文本信息。传入以字段名为 key，MetaOption 为 value 的配置，同时设置多个字段的元信息。
+func (p *DualAxes) SetMeta(meta interface{}) *DualAxes {
+	p.Meta = meta
+
+	return p
+}
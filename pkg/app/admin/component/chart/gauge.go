@@ -0,0 +1,154 @@
+package chart
+
+import "github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/component"
+
+type Gauge struct {
+	component.Element
+	Api           string      `json:"api"`
+	Width         int         `json:"width"`
+	Height        int         `json:"height"`
+	AutoFit       bool        `json:"autoFit"`
+	Padding       interface{} `json:"padding"`
+	AppendPadding interface{} `json:"appendPadding"`
+	Renderer      string      `json:"renderer"`
+	LimitInPlot   bool        `json:"limitInPlot"`
+	Locale        string      `json:"locale"`
+	Data          interface{} `json:"data"`
+	Percent       float64     `json:"percent"`
+	Radius        float64     `json:"radius,omitempty"`
+	InnerRadius   float64     `json:"innerRadius,omitempty"`
+	StartAngle    float64     `json:"startAngle,omitempty"`
+	EndAngle      float64     `json:"endAngle,omitempty"`
+	Range         interface{} `json:"range,omitempty"`
+	Indicator     interface{} `json:"indicator,omitempty"`
+	Statistic     interface{} `json:"statistic,omitempty"`
+	Meta          interface{} `json:"meta"`
+}
+
+// 仪表盘
+func NewGauge(data interface{}) *Gauge {
+	return (&Gauge{}).Init().SetData(data)
+}
+
+// 初始化
+func (p *Gauge) Init() *Gauge {
+	p.Component = "gauge"
+	p.SetKey(component.DEFAULT_KEY, component.DEFAULT_CRYPT)
+
+	return p
+}
+
+// 数据接口
+func (p *Gauge) SetApi(api string) *Gauge {
+	p.Api = api
+	return p
+}
+
+// 设置图表宽度
+func (p *Gauge) SetWidth(width int) *Gauge {
+	p.Width = width
+	return p
+}
+
+// 设置图表高度
+func (p *Gauge) SetHeight(height int) *Gauge {
+	p.Height = height
+	return p
+}
+
+// 图表是否自适应容器宽高。当 autoFit 设置为 true 时，width 和 height 的设置将失效。
+func (p *Gauge) SetAutoFit(autoFit bool) *Gauge {
+	p.AutoFit = autoFit
+	return p
+}
+
+// 画布的 padding 值，代表图表在上右下左的间距，可以为单个数字 16，或者数组 [16, 8, 16, 8] 代表四个方向，或者开启 auto，由底层自动计算间距。
+func (p *Gauge) SetPadding(padding interface{}) *Gauge {
+	p.Padding = padding
+	return p
+}
+
+// 额外增加的 appendPadding 值，在 padding 的基础上，设置额外的 padding 数值，可以是单个数字 16，或者数组 [16, 8, 16, 8] 代表四个方向。
+func (p *Gauge) SetAppendPadding(appendPadding interface{}) *Gauge {
+	p.AppendPadding = appendPadding
+	return p
+}
+
+// 设置图表渲染方式为 canvas 或 svg。
+func (p *Gauge) SetRenderer(renderer string) *Gauge {
+	p.Renderer = renderer
+	return p
+}
+
+// 是否对超出坐标系范围的 Geometry 进行剪切。
+func (p *Gauge) SetLimitInPlot(limitInPlot bool) *Gauge {
+	p.LimitInPlot = limitInPlot
+	return p
+}
+
+// 指定具体语言，目前内置 'zh-CN' and 'en-US' 两个语言，你也可以使用 G2Plot.registerLocale 方法注册新的语言。语言包格式参考：src/locales/en_US.ts
+func (p *Gauge) SetLocale(locale string) *Gauge {
+	p.Locale = locale
+	return p
+}
+
+// 数据
+func (p *Gauge) SetData(data interface{}) *Gauge {
+	p.Data = data
+	return p
+}
+
+// 指标比例数据 [0-1]
+func (p *Gauge) SetPercent(percent float64) *Gauge {
+	p.Percent = percent
+	return p
+}
+
+// 外环的半径 [0-1]，相对于画布宽高的最小值
+func (p *Gauge) SetRadius(radius float64) *Gauge {
+	p.Radius = radius
+	return p
+}
+
+// 内环的半径 [0-1]，相对于内半径 radius
+func (p *Gauge) SetInnerRadius(innerRadius float64) *Gauge {
+	p.InnerRadius = innerRadius
+	return p
+}
+
+// 圆弧的起始角度
+func (p *Gauge) SetStartAngle(startAngle float64) *Gauge {
+	p.StartAngle = startAngle
+	return p
+}
+
+// 圆弧的结束角度
+func (p *Gauge) SetEndAngle(endAngle float64) *Gauge {
+	p.EndAngle = endAngle
+	return p
+}
+
+// 辅助圆弧的样式，例如：{ticks: [0, 1/3, 2/3, 1], color: ['#F4664A', '#FAAD14', '#30BF78']}
+func (p *Gauge) SetRange(gaugeRange interface{}) *Gauge {
+	p.Range = gaugeRange
+	return p
+}
+
+// 指示器样式配置
+func (p *Gauge) SetIndicator(indicator interface{}) *Gauge {
+	p.Indicator = indicator
+	return p
+}
+
+// 中心文本组件配置
+func (p *Gauge) SetStatistic(statistic interface{}) *Gauge {
+	p.Statistic = statistic
+	return p
+}
+
+// 通过 meta 可以全局化配置图表数据元信息，以字段为单位进行配置。在 meta 上的配置将同时影响所有组件的文本信息。传入以字段名为 key，MetaOption 为 value 的配置，同时设置多个字段的元信息。
+func (p *Gauge) SetMeta(meta interface{}) *Gauge {
+	p.Meta = meta
+
+	return p
+}
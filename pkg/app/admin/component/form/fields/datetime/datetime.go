@@ -47,21 +47,23 @@ type Component struct {
 	Span        int           `json:"-"` // 包含列的数量，只在详情页中有效
 	ColumnWidth int           `json:"-"` // 设置列宽，只在列表页中有效
 
-	Api            string          `json:"api,omitempty"` // 获取数据接口
-	Ignore         bool            `json:"ignore"`        // 是否忽略保存到数据库，默认为 false
-	Rules          []*rule.Rule    `json:"-"`             // 全局校验规则
-	CreationRules  []*rule.Rule    `json:"-"`             // 创建页校验规则
-	UpdateRules    []*rule.Rule    `json:"-"`             // 编辑页校验规则
-	FrontendRules  []*rule.Rule    `json:"frontendRules"` // 前端校验规则，设置字段的校验逻辑
-	When           *when.Component `json:"when"`          //
-	WhenItem       []*when.Item    `json:"-"`             //
-	ShowOnIndex    bool            `json:"-"`             // 在列表页展示
-	ShowOnDetail   bool            `json:"-"`             // 在详情页展示
-	ShowOnCreation bool            `json:"-"`             // 在创建页面展示
-	ShowOnUpdate   bool            `json:"-"`             // 在编辑页面展示
-	ShowOnExport   bool            `json:"-"`             // 在导出的Excel上展示
-	ShowOnImport   bool            `json:"-"`             // 在导入Excel上展示
-	Callback       interface{}     `json:"-"`             // 回调函数
+	Api             string          `json:"api,omitempty"` // 获取数据接口
+	Ignore          bool            `json:"ignore"`        // 是否忽略保存到数据库，默认为 false
+	Rules           []*rule.Rule    `json:"-"`             // 全局校验规则
+	CreationRules   []*rule.Rule    `json:"-"`             // 创建页校验规则
+	UpdateRules     []*rule.Rule    `json:"-"`             // 编辑页校验规则
+	FrontendRules   []*rule.Rule    `json:"frontendRules"` // 前端校验规则，设置字段的校验逻辑
+	When            *when.Component `json:"when"`          //
+	WhenItem        []*when.Item    `json:"-"`             //
+	ShowOnIndex     bool            `json:"-"`             // 在列表页展示
+	ShowOnDetail    bool            `json:"-"`             // 在详情页展示
+	ShowOnCreation  bool            `json:"-"`             // 在创建页面展示
+	ShowOnUpdate    bool            `json:"-"`             // 在编辑页面展示
+	ShowOnExport    bool            `json:"-"`             // 在导出的Excel上展示
+	ShowOnImport    bool            `json:"-"`             // 在导入Excel上展示
+	ReadPermission  bool            `json:"-"`             // 查看该字段需要授权
+	WritePermission bool            `json:"-"`             // 编辑该字段需要授权
+	Callback        interface{}     `json:"-"`             // 回调函数
 
 	AllowClear     bool                   `json:"allowClear,omitempty"`     // 是否支持清除，默认true
 	AutoFocus      bool                   `json:"autoFocus,omitempty"`      // 自动获取焦点，默认false
@@ -724,6 +726,30 @@ func (p *Component) IsShownOnImport() bool {
 	return p.ShowOnImport
 }
 
+// 设置查看该字段需要授权，同步权限后可在角色中分配
+func (p *Component) SetReadPermission(readPermission bool) *Component {
+	p.ReadPermission = readPermission
+
+	return p
+}
+
+// 设置编辑该字段需要授权，同步权限后可在角色中分配
+func (p *Component) SetWritePermission(writePermission bool) *Component {
+	p.WritePermission = writePermission
+
+	return p
+}
+
+// 查看该字段是否需要授权
+func (p *Component) GetReadPermission() bool {
+	return p.ReadPermission
+}
+
+// 编辑该字段是否需要授权
+func (p *Component) GetWritePermission() bool {
+	return p.WritePermission
+}
+
 // 当前列值的枚举 valueEnum
 func (p *Component) GetValueEnum() map[interface{}]interface{} {
 	data := map[interface{}]interface{}{}
@@ -1,5 +1,18 @@
 package rule
 
+import (
+	"strconv"
+	"strings"
+)
+
+// 密码字符类别
+const (
+	CharLower  = "lower"  // 小写字母
+	CharUpper  = "upper"  // 大写字母
+	CharDigit  = "digit"  // 数字
+	CharSymbol = "symbol" // 特殊字符
+)
+
 type Rule struct {
 	Name              string        `json:"-"`                      // 需要验证的字段名称
 	RuleType          string        `json:"-"`                      // 规则类型，max | min | unique | required
@@ -15,6 +28,11 @@ type Rule struct {
 	UniqueTable       string        `json:"-"`                      // type：unique时，指定验证的表名
 	UniqueTableField  string        `json:"-"`                      // type：unique时，指定需验证表中的字段
 	UniqueIgnoreValue string        `json:"-"`                      // type：unique时，忽略符合条件验证的列，例如：{id}
+	CharClasses       []string      `json:"-"`                      // type：password时，密码必须包含的字符类别，lower | upper | digit | symbol
+	HistoryTable      string        `json:"-"`                      // type：passwordHistory时，指定历史密码的表名
+	HistoryField      string        `json:"-"`                      // type：passwordHistory时，指定历史密码表中关联记录的字段
+	HistoryValue      string        `json:"-"`                      // type：passwordHistory时，关联记录的值，例如：{id}
+	HistoryLimit      int           `json:"-"`                      // type：passwordHistory时，检查最近使用过的密码数量
 	Type              string        `json:"type,omitempty"`         // 字段类型，string | number | boolean | method | regexp | integer | float | array | object | enum | date | url | hex | email | any
 }
 
@@ -25,12 +43,12 @@ func New() *Rule {
 	return p
 }
 
-// 转换前端验证规则，剔除前端不支持的unique
+// 转换前端验证规则，剔除前端不支持的unique、passwordHistory
 func ConvertToFrontendRules(rules []*Rule) []*Rule {
 	var newRules []*Rule
 
 	for _, rule := range rules {
-		if rule.RuleType != "unique" {
+		if rule.RuleType != "unique" && rule.RuleType != "passwordHistory" {
 			newRules = append(newRules, rule)
 		}
 	}
@@ -154,6 +172,20 @@ func Unique(unique ...string) *Rule {
 	return p
 }
 
+// 密码强度，Password(8, []string{"lower", "upper", "digit"}, "密码至少8位，且包含大小写字母及数字")
+func Password(minLength int, charClasses []string, message string) *Rule {
+	p := &Rule{}
+
+	return p.SetPassword(minLength, charClasses).SetMessage(message)
+}
+
+// 密码不能与最近使用过的密码相同，PasswordHistory("password_histories", "admin_id", "{id}", 5, "不能使用最近5次使用过的密码")
+func PasswordHistory(table string, field string, value string, limit int, message string) *Rule {
+	p := &Rule{}
+
+	return p.SetPasswordHistory(table, field, value, limit).SetMessage(message)
+}
+
 // 需要验证的字段名称
 func (p *Rule) SetName(name string) *Rule {
 	p.Name = name
@@ -269,6 +301,67 @@ func (p *Rule) SetUnique(unique ...string) *Rule {
 	return p.SetRuleType("unique")
 }
 
+// 设置密码强度，前端使用正则表达式验证
+func (p *Rule) SetPassword(minLength int, charClasses []string) *Rule {
+	p.Min = minLength
+	p.CharClasses = charClasses
+
+	pattern := "/^"
+	for _, v := range charClasses {
+		switch v {
+		case CharLower:
+			pattern += "(?=.*[a-z])"
+		case CharUpper:
+			pattern += "(?=.*[A-Z])"
+		case CharDigit:
+			pattern += `(?=.*\d)`
+		case CharSymbol:
+			pattern += `(?=.*[^a-zA-Z\d])`
+		}
+	}
+	p.Pattern = pattern + ".{" + strconv.Itoa(minLength) + ",}$/"
+
+	return p.SetRuleType("password")
+}
+
+// 设置密码历史验证，value为关联记录的值，例如：{id}
+func (p *Rule) SetPasswordHistory(table string, field string, value string, limit int) *Rule {
+	p.HistoryTable = table
+	p.HistoryField = field
+	p.HistoryValue = value
+	p.HistoryLimit = limit
+
+	return p.SetRuleType("passwordHistory")
+}
+
+// 判断密码是否满足强度要求
+func (p *Rule) CheckPassword(password string) bool {
+	if len([]rune(password)) < p.Min {
+		return false
+	}
+
+	for _, v := range p.CharClasses {
+		matched := strings.IndexFunc(password, func(r rune) bool {
+			switch v {
+			case CharLower:
+				return r >= 'a' && r <= 'z'
+			case CharUpper:
+				return r >= 'A' && r <= 'Z'
+			case CharDigit:
+				return r >= '0' && r <= '9'
+			case CharSymbol:
+				return !(r >= 'a' && r <= 'z') && !(r >= 'A' && r <= 'Z') && !(r >= '0' && r <= '9')
+			}
+			return true
+		}) >= 0
+		if !matched {
+			return false
+		}
+	}
+
+	return true
+}
+
 // type：unique时，指定验证的表名
 func (p *Rule) SetUniqueTable(uniqueTable string) *Rule {
 	p.UniqueTable = uniqueTable
@@ -297,7 +390,7 @@ func (p *Rule) SetType(ruleType string) *Rule {
 	return p
 }
 
-// 规则类型，max | min | unique | required
+// 规则类型，max | min | unique | required | password | passwordHistory
 func (p *Rule) SetRuleType(ruleType string) *Rule {
 	p.RuleType = ruleType
 
@@ -0,0 +1,114 @@
+package progress
+
+import "github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/component"
+
+type Component struct {
+	component.Element
+	Api         string      `json:"api"`
+	Type        string      `json:"type"`
+	Percent     float64     `json:"percent"`
+	Status      string      `json:"status,omitempty"`
+	ShowInfo    bool        `json:"showInfo"`
+	StrokeColor interface{} `json:"strokeColor,omitempty"`
+	StrokeWidth int         `json:"strokeWidth,omitempty"`
+	Size        interface{} `json:"size,omitempty"`
+	Steps       int         `json:"steps,omitempty"`
+	Title       string      `json:"title"`
+	Value       float64     `json:"value"`
+	Target      float64     `json:"target"`
+}
+
+// 初始化组件
+func New() *Component {
+	return (&Component{}).Init()
+}
+
+// 初始化
+func (p *Component) Init() *Component {
+	p.Component = "progress"
+	p.Type = "line"
+	p.ShowInfo = true
+
+	p.SetKey(component.DEFAULT_KEY, component.DEFAULT_CRYPT)
+
+	return p
+}
+
+// Set style.
+func (p *Component) SetStyle(style map[string]interface{}) *Component {
+	p.Style = style
+
+	return p
+}
+
+// 数据接口，返回的数据会覆盖percent、value、target
+func (p *Component) SetApi(api string) *Component {
+	p.Api = api
+	return p
+}
+
+// 类型，可选 line | circle | dashboard
+func (p *Component) SetType(progressType string) *Component {
+	p.Type = progressType
+	return p
+}
+
+// 百分比
+func (p *Component) SetPercent(percent float64) *Component {
+	p.Percent = percent
+	return p
+}
+
+// 状态，可选：success | exception | normal | active(仅限 line)
+func (p *Component) SetStatus(status string) *Component {
+	p.Status = status
+	return p
+}
+
+// 是否显示进度数值或状态图标
+func (p *Component) SetShowInfo(showInfo bool) *Component {
+	p.ShowInfo = showInfo
+	return p
+}
+
+// 进度条的色彩，可以为单个颜色或渐变色配置
+func (p *Component) SetStrokeColor(strokeColor interface{}) *Component {
+	p.StrokeColor = strokeColor
+	return p
+}
+
+// 进度条线的宽度，单位 px
+func (p *Component) SetStrokeWidth(strokeWidth int) *Component {
+	p.StrokeWidth = strokeWidth
+	return p
+}
+
+// 进度条的尺寸
+func (p *Component) SetSize(size interface{}) *Component {
+	p.Size = size
+	return p
+}
+
+// 进度条总共步数
+func (p *Component) SetSteps(steps int) *Component {
+	p.Steps = steps
+	return p
+}
+
+// 设置数值的标题
+func (p *Component) SetTitle(title string) *Component {
+	p.Title = title
+	return p
+}
+
+// 当前值
+func (p *Component) SetValue(value float64) *Component {
+	p.Value = value
+	return p
+}
+
+// 目标值
+func (p *Component) SetTarget(target float64) *Component {
+	p.Target = target
+	return p
+}
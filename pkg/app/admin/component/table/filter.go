@@ -0,0 +1,95 @@
+package table
+
+import "github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/component"
+
+type Filter struct {
+	component.Element
+	Title      string        `json:"title,omitempty"`
+	SubmitText string        `json:"submitText,omitempty"`
+	ResetText  string        `json:"resetText,omitempty"`
+	Collapsed  bool          `json:"collapsed,omitempty"`
+	Width      int           `json:"width,omitempty"`
+	Items      []interface{} `json:"items"`
+}
+
+// 初始化
+func (p *Filter) Init() *Filter {
+	p.Component = "filter"
+	p.Title = "筛选"
+	p.SubmitText = "确定"
+	p.ResetText = "重置"
+	p.Width = 240
+
+	p.SetKey(component.DEFAULT_KEY, component.DEFAULT_CRYPT)
+
+	return p
+}
+
+// Set style.
+func (p *Filter) SetStyle(style map[string]interface{}) *Filter {
+	p.Style = style
+
+	return p
+}
+
+// 筛选面板标题
+func (p *Filter) SetTitle(title string) *Filter {
+	p.Title = title
+
+	return p
+}
+
+// 提交按钮的文本
+func (p *Filter) SetSubmitText(submitText string) *Filter {
+	p.SubmitText = submitText
+
+	return p
+}
+
+// 重置按钮的文本
+func (p *Filter) SetResetText(resetText string) *Filter {
+	p.ResetText = resetText
+
+	return p
+}
+
+// 默认状态下是否收起筛选面板
+func (p *Filter) SetCollapsed(collapsed bool) *Filter {
+	p.Collapsed = collapsed
+
+	return p
+}
+
+// 筛选面板宽度
+func (p *Filter) SetWidth(width int) *Filter {
+	p.Width = width
+
+	return p
+}
+
+// 设置筛选表单项
+func (p *Filter) SetItems(items []interface{}) *Filter {
+	p.Items = []interface{}{}
+	for _, item := range items {
+		p.AddItem(item)
+	}
+
+	return p
+}
+
+// 添加筛选表单项，nil会被忽略
+func (p *Filter) AddItem(item interface{}) *Filter {
+	if item == nil {
+		return p
+	}
+	p.Items = append(p.Items, item)
+
+	return p
+}
+
+// 组件json序列化
+func (p *Filter) JsonSerialize() *Filter {
+	p.Component = "filter"
+
+	return p
+}
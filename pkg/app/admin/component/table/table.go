@@ -13,6 +13,7 @@ type Component struct {
 	RowSelection     interface{}     `json:"rowSelection"`
 	Options          map[string]bool `json:"options"`
 	Search           interface{}     `json:"search"`
+	Filters          interface{}     `json:"filters,omitempty"`
 	BatchActions     interface{}     `json:"batchActions"`
 	DateFormatter    string          `json:"dateFormatter"`
 	ColumnEmptyText  string          `json:"columnEmptyText"`
@@ -42,6 +43,12 @@ func NewSearch() *Search {
 	return (&Search{}).Init()
 }
 
+// 获取Filter
+func NewFilter() *Filter {
+
+	return (&Filter{}).Init()
+}
+
 // 获取SearchItem
 func NewToolBar() *ToolBar {
 
@@ -162,6 +169,13 @@ func (p *Component) SetColumnEmptyText(columnEmptyText string) *Component {
 	return p
 }
 
+// 侧边栏筛选面板
+func (p *Component) SetFilters(filters interface{}) *Component {
+	p.Filters = filters
+
+	return p
+}
+
 // 透传 ProUtils 中的 ListToolBar 配置项
 func (p *Component) SetToolBar(toolBar interface{}) *Component {
 	p.ToolBar = toolBar
@@ -216,6 +230,36 @@ func (p *Component) SetPagination(current int, pageSize int, total int, defaultC
 	return p
 }
 
+// 表格简单分页，不统计精确总数，total为估算值或根据是否有下一页推算的值
+func (p *Component) SetSimplePagination(current int, pageSize int, total int, hasMore bool) *Component {
+	p.Pagination = map[string]interface{}{
+		"simple":         true,
+		"current":        current,
+		"pageSize":       pageSize,
+		"total":          total,
+		"hasMore":        hasMore,
+		"defaultCurrent": 1,
+	}
+
+	return p
+}
+
+// 表格游标分页，没有上一页或下一页时对应的游标为空，total小于0时不显示总数
+func (p *Component) SetCursorPagination(pageSize int, total int, prevCursor string, nextCursor string) *Component {
+	pagination := map[string]interface{}{
+		"type":       "cursor",
+		"pageSize":   pageSize,
+		"prevCursor": prevCursor,
+		"nextCursor": nextCursor,
+	}
+	if total >= 0 {
+		pagination["total"] = total
+	}
+	p.Pagination = pagination
+
+	return p
+}
+
 // 是否轮询
 func (p *Component) SetPolling(polling int) *Component {
 	p.Polling = polling
@@ -1,50 +1,32 @@
 package install
 
 import (
-	"os"
-
 	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/model"
 	"github.com/quarkcloudio/quark-go/v2/pkg/dal/db"
-	"github.com/quarkcloudio/quark-go/v2/pkg/utils/file"
-	"gorm.io/gorm"
+	"github.com/quarkcloudio/quark-go/v2/pkg/dal/migration"
 )
 
 // 执行安装操作
 func Handle() {
 
-	// 如果锁定文件存在则不执行安装步骤
-	if file.IsExist("install.lock") {
-		return
-	}
-
-	// 迁移数据
-	db.Client.AutoMigrate(
-		&model.ActionLog{},
-		&model.Admin{},
-		&model.Config{},
-		&model.Menu{},
-		&model.File{},
-		&model.FileCategory{},
-		&model.Picture{},
-		&model.PictureCategory{},
-		&model.Permission{},
-		&model.Role{},
-		&model.CasbinRule{},
-	)
+	// 注册内置迁移
+	migration.Register(Migrations...)
 
-	// 如果超级管理员不存在，初始化数据库数据
-	adminInfo, err := (&model.Admin{}).GetInfoById(1)
-	if err != nil && err != gorm.ErrRecordNotFound {
+	// 迁移功能之前安装的数据库已存在管理员表，将初始迁移标记为已执行，避免重新创建默认账号、恢复已删除的菜单
+	ran, err := migration.Ran(db.Client, baselineVersions[0])
+	if err != nil {
 		panic(err)
 	}
-	if adminInfo.Id == 0 {
-		// 数据填充
-		(&model.Admin{}).Seeder()
-		(&model.Config{}).Seeder()
-		(&model.Menu{}).Seeder()
+	if !ran && db.Client.Migrator().HasTable(&model.Admin{}) {
+		err = migration.MarkRan(db.Client, baselineVersions...)
+		if err != nil {
+			panic(err)
+		}
 	}
 
-	// 创建锁定文件
-	file, _ := os.Create("install.lock")
-	file.Close()
+	// 执行未完成的迁移，包括应用注册的迁移
+	err = migration.Up(db.Client)
+	if err != nil {
+		panic(err)
+	}
 }
@@ -0,0 +1,681 @@
+package install
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/model"
+	"github.com/quarkcloudio/quark-go/v2/pkg/dal/db"
+	"github.com/quarkcloudio/quark-go/v2/pkg/dal/migration"
+	"github.com/quarkcloudio/quark-go/v2/pkg/utils/hash"
+	"gorm.io/gorm"
+)
+
+// 迁移功能之前的安装方式已创建的表结构及填充的数据，已有安装时标记为已执行
+var baselineVersions = []string{
+	"2023_01_01_000000_create_admin_tables",
+	"2023_01_01_000001_seed_admin_data",
+}
+
+// 管理后台内置迁移，新增表结构变更时在末尾追加新的版本
+//
+// 每个迁移使用在迁移内定义的结构体，不引用model包中的模型，模型字段变更后已有的迁移保持不变
+var Migrations = []*migration.Migration{
+	{
+		Version: "2023_01_01_000000_create_admin_tables",
+		Up: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(adminTables()...)
+		},
+		Down: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(adminTables()...)
+		},
+	},
+	{
+		Version: "2023_01_01_000001_seed_admin_data",
+		Up:      seedAdminData,
+	},
+	{
+		Version: "2023_07_01_000000_seed_super_admin_role",
+		Up:      seedSuperAdminRole,
+	},
+	{
+		Version: "2023_07_02_000000_create_revoked_tokens_table",
+		Up: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(revokedTokenTable())
+		},
+		Down: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(revokedTokenTable())
+		},
+	},
+	{
+		Version: "2023_07_03_000000_create_picture_variants_table",
+		Up: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(pictureVariantTable())
+		},
+		Down: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(pictureVariantTable())
+		},
+	},
+	{
+		Version: "2023_07_05_000000_add_data_scope_columns",
+		Up: func(tx *gorm.DB) error {
+			role, admin := dataScopeColumns()
+
+			return tx.AutoMigrate(role, admin)
+		},
+		Down: func(tx *gorm.DB) error {
+			role, admin := dataScopeColumns()
+			err := tx.Migrator().DropColumn(role, "data_scope")
+			if err != nil {
+				return err
+			}
+
+			return tx.Migrator().DropColumn(admin, "department_id")
+		},
+	},
+	{
+		Version: "2023_07_06_000000_create_departments_table",
+		Up: func(tx *gorm.DB) error {
+			err := tx.AutoMigrate(departmentTable())
+			if err != nil {
+				return err
+			}
+
+			// 部门菜单
+			type Menu struct {
+				Id        int
+				Name      string
+				GuardName string
+				Icon      string
+				Type      int
+				Pid       int
+				Sort      int
+				Path      string
+				Show      int
+				IsEngine  int
+				IsLink    int
+				Status    int
+				CreatedAt time.Time
+				UpdatedAt time.Time
+			}
+			menu := Menu{Name: "部门列表", GuardName: "admin", Icon: "", Type: 2, Pid: 3, Sort: 0, Path: "/api/admin/department/index", Show: 1, IsEngine: 1, IsLink: 0, Status: 1}
+			err = tx.Where(Menu{Path: menu.Path}).FirstOrCreate(&menu).Error
+			if err != nil {
+				return err
+			}
+			db.AfterCommit(tx, func() {
+				(&model.Menu{}).ClearCache()
+			})
+
+			return nil
+		},
+		Down: func(tx *gorm.DB) error {
+			type Menu struct {
+				Id   int
+				Path string
+			}
+			err := tx.Where("path = ?", "/api/admin/department/index").Delete(&Menu{}).Error
+			if err != nil {
+				return err
+			}
+
+			return tx.Migrator().DropTable(departmentTable())
+		},
+	},
+	{
+		Version: "2023_07_07_000000_add_audit_columns_to_action_logs",
+		Up: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(auditColumns())
+		},
+		Down: func(tx *gorm.DB) error {
+			for _, column := range []string{"method", "resource", "uri_key", "record_id", "diff"} {
+				err := tx.Migrator().DropColumn(auditColumns(), column)
+				if err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+	},
+	{
+		Version: "2023_07_08_000000_add_totp_columns",
+		Up: func(tx *gorm.DB) error {
+			admin, role := totpColumns()
+			err := tx.AutoMigrate(admin, role)
+			if err != nil {
+				return err
+			}
+
+			// 全局强制两步验证配置
+			return seedConfigs(tx, []configSeed{
+				{Title: "强制两步验证", Type: "switch", Name: "ADMIN_TOTP_FORCE", GroupName: "安全", Value: "0", Remark: "开启后所有管理员均须开启两步验证"},
+			})
+		},
+		Down: func(tx *gorm.DB) error {
+			err := deleteConfigs(tx, "ADMIN_TOTP_FORCE")
+			if err != nil {
+				return err
+			}
+			admin, role := totpColumns()
+			for _, column := range []string{"totp_secret", "totp_enabled", "totp_recovery"} {
+				err = tx.Migrator().DropColumn(admin, column)
+				if err != nil {
+					return err
+				}
+			}
+
+			return tx.Migrator().DropColumn(role, "require_totp")
+		},
+	},
+	{
+		Version: "2023_07_09_000000_add_password_policy",
+		Up: func(tx *gorm.DB) error {
+			type Admin struct {
+				Id            int
+				Password      string
+				MustChangePwd int `gorm:"size:1;not null;default:0"`
+				PwdChangedAt  time.Time
+				DeletedAt     gorm.DeletedAt
+			}
+			type PasswordHistory struct {
+				Id        int    `gorm:"autoIncrement"`
+				AdminId   int    `gorm:"index;not null"`
+				Password  string `gorm:"size:255;not null"`
+				CreatedAt time.Time
+			}
+			err := tx.AutoMigrate(&Admin{}, &PasswordHistory{})
+			if err != nil {
+				return err
+			}
+
+			// 已有管理员以迁移时间作为最后修改密码的时间，并记录当前密码
+			err = tx.Model(&Admin{}).Where("pwd_changed_at IS NULL").Update("pwd_changed_at", time.Now()).Error
+			if err != nil {
+				return err
+			}
+			admins := []Admin{}
+			err = tx.Select("id", "password").Find(&admins).Error
+			if err != nil {
+				return err
+			}
+			for _, admin := range admins {
+				err = tx.Create(&PasswordHistory{AdminId: admin.Id, Password: admin.Password}).Error
+				if err != nil {
+					return err
+				}
+			}
+
+			// 密码策略配置
+			return seedConfigs(tx, []configSeed{
+				{Title: "密码最小长度", Type: "text", Name: "PASSWORD_MIN_LENGTH", GroupName: "密码策略", Value: "6", Remark: ""},
+				{Title: "密码字符类别", Type: "text", Name: "PASSWORD_CHAR_CLASSES", GroupName: "密码策略", Value: "", Remark: "必须包含的字符类别，多个用英文逗号分隔：lower,upper,digit,symbol"},
+				{Title: "禁止重复使用次数", Type: "text", Name: "PASSWORD_HISTORY", GroupName: "密码策略", Value: "0", Remark: "不能与最近几次使用过的密码相同，0为不限制"},
+				{Title: "密码有效期", Type: "text", Name: "PASSWORD_MAX_AGE", GroupName: "密码策略", Value: "0", Remark: "单位天，过期后登录须修改密码，0为永不过期"},
+			})
+		},
+		Down: func(tx *gorm.DB) error {
+			type Admin struct {
+				Id int
+			}
+			type PasswordHistory struct {
+				Id int
+			}
+			err := deleteConfigs(tx, "PASSWORD_MIN_LENGTH", "PASSWORD_CHAR_CLASSES", "PASSWORD_HISTORY", "PASSWORD_MAX_AGE")
+			if err != nil {
+				return err
+			}
+			for _, column := range []string{"must_change_pwd", "pwd_changed_at"} {
+				err = tx.Migrator().DropColumn(&Admin{}, column)
+				if err != nil {
+					return err
+				}
+			}
+
+			return tx.Migrator().DropTable(&PasswordHistory{})
+		},
+	},
+}
+
+// 初始表结构
+func adminTables() []interface{} {
+	type ActionLog struct {
+		Id        int    `gorm:"autoIncrement"`
+		ObjectId  int    `gorm:"size:11;not null"`
+		Url       string `gorm:"size:500;not null"`
+		Remark    string `gorm:"size:255;not null"`
+		Ip        string `gorm:"size:100;not null"`
+		Type      string `gorm:"size:100;not null"`
+		Status    int    `gorm:"size:1;not null;default:1"`
+		CreatedAt time.Time
+		UpdatedAt time.Time
+	}
+	type Admin struct {
+		Id            int    `gorm:"autoIncrement"`
+		Username      string `gorm:"size:20;index:admins_username_unique,unique;not null"`
+		Nickname      string `gorm:"size:200;not null"`
+		Sex           int    `gorm:"size:4;not null;default:1"`
+		Email         string `gorm:"size:50;index:admins_email_unique,unique;not null"`
+		Phone         string `gorm:"size:11;index:admins_phone_unique,unique;not null"`
+		Password      string `gorm:"size:255;not null"`
+		Avatar        string `gorm:"size:1000"`
+		LastLoginIp   string `gorm:"size:255"`
+		LastLoginTime time.Time
+		Status        int `gorm:"size:1;not null;default:1"`
+		CreatedAt     time.Time
+		UpdatedAt     time.Time
+		DeletedAt     gorm.DeletedAt
+	}
+	type Config struct {
+		Id        int    `gorm:"autoIncrement"`
+		Title     string `gorm:"size:255;not null"`
+		Type      string `gorm:"size:20;not null"`
+		Name      string `gorm:"size:255;not null"`
+		Sort      int    `gorm:"size:11;default:0"`
+		GroupName string `gorm:"size:255;not null"`
+		Value     string `gorm:"size:2000"`
+		Remark    string `gorm:"size:100;not null"`
+		Status    int    `gorm:"size:1;not null;default:1"`
+		CreatedAt time.Time
+		UpdatedAt time.Time
+	}
+	type Menu struct {
+		Id        int    `gorm:"autoIncrement"`
+		Name      string `gorm:"size:100;not null"`
+		GuardName string `gorm:"size:100;not null"`
+		Icon      string `gorm:"size:100;"`
+		Type      int    `gorm:"size:100;not null"`
+		Pid       int    `gorm:"size:11;default:0"`
+		Sort      int    `gorm:"size:11;default:0"`
+		Path      string `gorm:"size:255"`
+		Show      int    `gorm:"size:1;not null;default:1"`
+		IsEngine  int    `gorm:"size:1;not null;default:0"`
+		IsLink    int    `gorm:"size:1;not null;default:0"`
+		Status    int    `gorm:"size:1;not null;default:1"`
+		CreatedAt time.Time
+		UpdatedAt time.Time
+	}
+	type File struct {
+		Id             int    `gorm:"autoIncrement"`
+		ObjType        string `gorm:"size:255"`
+		ObjId          int    `gorm:"size:11;default:0"`
+		FileCategoryId int    `gorm:"size:11;default:0"`
+		Sort           int    `gorm:"size:11;default:0"`
+		Name           string `gorm:"size:255;not null"`
+		Size           int64  `gorm:"size:20;default:0"`
+		Ext            string `gorm:"size:255"`
+		Path           string `gorm:"size:255;not null"`
+		Url            string `gorm:"size:255;not null"`
+		Hash           string `gorm:"size:255;not null"`
+		Status         int    `gorm:"size:1;not null;default:1"`
+		CreatedAt      time.Time
+		UpdatedAt      time.Time
+	}
+	type FileCategory struct {
+		Id          int    `gorm:"autoIncrement"`
+		ObjType     string `gorm:"size:100"`
+		ObjId       int    `gorm:"size:11;default:0"`
+		Title       string `gorm:"size:255;not null"`
+		Sort        int    `gorm:"size:11;default:0"`
+		Description string `gorm:"size:255"`
+	}
+	type Picture struct {
+		Id                int    `gorm:"autoIncrement"`
+		ObjType           string `gorm:"size:255"`
+		ObjId             int    `gorm:"size:11;default:0"`
+		PictureCategoryId int    `gorm:"size:11;default:0"`
+		Sort              int    `gorm:"size:11;default:0"`
+		Name              string `gorm:"size:255;not null"`
+		Size              int64  `gorm:"size:20;default:0"`
+		Width             int    `gorm:"size:11;default:0"`
+		Height            int    `gorm:"size:11;default:0"`
+		Ext               string `gorm:"size:255"`
+		Path              string `gorm:"size:255;not null"`
+		Url               string `gorm:"size:255;not null"`
+		Hash              string `gorm:"size:255;not null"`
+		Status            int    `gorm:"size:1;not null;default:1"`
+		CreatedAt         time.Time
+		UpdatedAt         time.Time
+	}
+	type PictureCategory struct {
+		Id          int    `gorm:"autoIncrement"`
+		ObjType     string `gorm:"size:100"`
+		ObjId       int    `gorm:"size:11;default:0"`
+		Title       string `gorm:"size:255;not null"`
+		Sort        int    `gorm:"size:11;default:0"`
+		Description string `gorm:"size:255"`
+	}
+	type Permission struct {
+		Id        int    `gorm:"autoIncrement"`
+		Name      string `gorm:"size:500;not null"`
+		GuardName string `gorm:"size:100;not null"`
+		Path      string `gorm:"size:500;not null"`
+		Method    string `gorm:"size:500;not null"`
+		Remark    string `gorm:"size:100"`
+		CreatedAt time.Time
+		UpdatedAt time.Time
+	}
+	type Role struct {
+		Id        int    `gorm:"autoIncrement"`
+		Name      string `gorm:"size:255;not null"`
+		GuardName string `gorm:"size:100;not null"`
+		CreatedAt time.Time
+		UpdatedAt time.Time
+	}
+	type CasbinRule struct {
+		ID    uint   `gorm:"primaryKey;autoIncrement"`
+		Ptype string `gorm:"size:100;uniqueIndex:unique_index"`
+		V0    string `gorm:"size:100;uniqueIndex:unique_index"`
+		V1    string `gorm:"size:100;uniqueIndex:unique_index"`
+		V2    string `gorm:"size:100;uniqueIndex:unique_index"`
+		V3    string `gorm:"size:100;uniqueIndex:unique_index"`
+		V4    string `gorm:"size:100;uniqueIndex:unique_index"`
+		V5    string `gorm:"size:100;uniqueIndex:unique_index"`
+	}
+
+	return []interface{}{
+		&ActionLog{},
+		&Admin{},
+		&Config{},
+		&Menu{},
+		&File{},
+		&FileCategory{},
+		&Picture{},
+		&PictureCategory{},
+		&Permission{},
+		&Role{},
+		&CasbinRule{},
+	}
+}
+
+// 吊销令牌表
+func revokedTokenTable() interface{} {
+	type RevokedToken struct {
+		Id        int    `gorm:"autoIncrement"`
+		Jti       string `gorm:"size:64;index"`
+		Subject   string `gorm:"size:100;index"`
+		RevokedAt int64
+		ExpiresAt time.Time
+		CreatedAt time.Time
+	}
+
+	return &RevokedToken{}
+}
+
+// 图片版本表
+func pictureVariantTable() interface{} {
+	type PictureVariant struct {
+		Id        int    `gorm:"autoIncrement"`
+		PictureId int    `gorm:"size:11;index;not null"`
+		Name      string `gorm:"size:50;not null"`
+		Width     int    `gorm:"size:11;default:0"`
+		Height    int    `gorm:"size:11;default:0"`
+		Size      int64  `gorm:"size:20;default:0"`
+		Ext       string `gorm:"size:255"`
+		Path      string `gorm:"size:255;not null"`
+		Url       string `gorm:"size:255;not null"`
+		CreatedAt time.Time
+		UpdatedAt time.Time
+	}
+
+	return &PictureVariant{}
+}
+
+// 数据权限字段
+func dataScopeColumns() (role interface{}, admin interface{}) {
+	type Role struct {
+		Id        int
+		DataScope int `gorm:"size:1;not null;default:1"`
+	}
+	type Admin struct {
+		Id           int
+		DepartmentId int `gorm:"not null;default:0"`
+	}
+
+	return &Role{}, &Admin{}
+}
+
+// 部门表
+func departmentTable() interface{} {
+	type Department struct {
+		Id        int    `gorm:"autoIncrement"`
+		Pid       int    `gorm:"size:11;default:0"`
+		Name      string `gorm:"size:100;not null"`
+		Sort      int    `gorm:"size:11;default:0"`
+		Status    int    `gorm:"size:1;not null;default:1"`
+		CreatedAt time.Time
+		UpdatedAt time.Time
+	}
+
+	return &Department{}
+}
+
+// 操作日志审计字段
+func auditColumns() interface{} {
+	type ActionLog struct {
+		Id       int
+		Method   string `gorm:"size:10"`
+		Resource string `gorm:"size:100"`
+		UriKey   string `gorm:"size:100"`
+		RecordId string `gorm:"size:500"`
+		Diff     string `gorm:"type:text"`
+	}
+
+	return &ActionLog{}
+}
+
+// 两步验证字段
+func totpColumns() (admin interface{}, role interface{}) {
+	type Admin struct {
+		Id           int
+		TotpSecret   string `gorm:"size:64"`
+		TotpEnabled  int    `gorm:"size:1;not null;default:0"`
+		TotpRecovery string `gorm:"size:2000"`
+	}
+	type Role struct {
+		Id          int
+		RequireTotp int `gorm:"size:1;not null;default:0"`
+	}
+
+	return &Admin{}, &Role{}
+}
+
+// 填充默认管理员、配置及菜单
+func seedAdminData(tx *gorm.DB) error {
+	type Admin struct {
+		Id            int
+		Username      string
+		Nickname      string
+		Sex           int
+		Email         string
+		Phone         string
+		Password      string
+		Status        int
+		LastLoginTime time.Time
+		CreatedAt     time.Time
+		UpdatedAt     time.Time
+		DeletedAt     gorm.DeletedAt
+	}
+	admins := []Admin{
+		{Username: "administrator", Nickname: "超级管理员", Email: "admin@yourweb.com", Phone: "10086", Password: hash.Make("123456"), Sex: 1, Status: 1, LastLoginTime: time.Now()},
+	}
+	for _, admin := range admins {
+		err := tx.Unscoped().Where(Admin{Username: admin.Username}).FirstOrCreate(&admin).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	err := seedConfigs(tx, []configSeed{
+		{Title: "网站名称", Type: "text", Name: "WEB_SITE_NAME", GroupName: "基本", Value: "QuarkCloud", Remark: ""},
+		{Title: "关键字", Type: "text", Name: "WEB_SITE_KEYWORDS", GroupName: "基本", Value: "QuarkCloud", Remark: ""},
+		{Title: "描述", Type: "textarea", Name: "WEB_SITE_DESCRIPTION", GroupName: "基本", Value: "QuarkCloud", Remark: ""},
+		{Title: "Logo", Type: "picture", Name: "WEB_SITE_LOGO", GroupName: "基本", Value: "", Remark: ""},
+		{Title: "统计代码", Type: "textarea", Name: "WEB_SITE_SCRIPT", GroupName: "基本", Value: "", Remark: ""},
+		{Title: "网站域名", Type: "text", Name: "WEB_SITE_DOMAIN", GroupName: "基本", Value: "", Remark: ""},
+		{Title: "网站版权", Type: "text", Name: "WEB_SITE_COPYRIGHT", GroupName: "基本", Value: "© Company 2018", Remark: ""},
+		{Title: "开启SSL", Type: "switch", Name: "SSL_OPEN", GroupName: "基本", Value: "0", Remark: ""},
+		{Title: "开启网站", Type: "switch", Name: "WEB_SITE_OPEN", GroupName: "基本", Value: "1", Remark: ""},
+		{Title: "KeyID", Type: "text", Name: "OSS_ACCESS_KEY_ID", GroupName: "阿里云存储", Value: "", Remark: "你的AccessKeyID"},
+		{Title: "KeySecret", Type: "text", Name: "OSS_ACCESS_KEY_SECRET", GroupName: "阿里云存储", Value: "", Remark: "你的AccessKeySecret"},
+		{Title: "EndPoint", Type: "text", Name: "OSS_ENDPOINT", GroupName: "阿里云存储", Value: "", Remark: "地域节点"},
+		{Title: "Bucket域名", Type: "text", Name: "OSS_BUCKET", GroupName: "阿里云存储", Value: "", Remark: ""},
+		{Title: "自定义域名", Type: "text", Name: "OSS_MYDOMAIN", GroupName: "阿里云存储", Value: "", Remark: "例如：oss.web.com"},
+		{Title: "开启云存储", Type: "switch", Name: "OSS_OPEN", GroupName: "阿里云存储", Value: "0", Remark: ""},
+	})
+	if err != nil {
+		return err
+	}
+
+	type Menu struct {
+		Id        int
+		Name      string
+		GuardName string
+		Icon      string
+		Type      int
+		Pid       int
+		Sort      int
+		Path      string
+		Show      int
+		IsEngine  int
+		IsLink    int
+		Status    int
+		CreatedAt time.Time
+		UpdatedAt time.Time
+	}
+	menus := []Menu{
+		{Id: 1, Name: "控制台", GuardName: "admin", Icon: "icon-home", Type: 1, Pid: 0, Sort: 0, Path: "/dashboard", Show: 1, IsEngine: 0, IsLink: 0, Status: 1},
+		{Id: 2, Name: "主页", GuardName: "admin", Icon: "", Type: 2, Pid: 1, Sort: 0, Path: "/api/admin/dashboard/index/index", Show: 1, IsEngine: 1, IsLink: 0, Status: 1},
+		{Id: 3, Name: "管理员", GuardName: "admin", Icon: "icon-admin", Type: 1, Pid: 0, Sort: 100, Path: "/admin", Show: 1, IsEngine: 0, IsLink: 0, Status: 1},
+		{Id: 4, Name: "管理员列表", GuardName: "admin", Icon: "", Type: 2, Pid: 3, Sort: 0, Path: "/api/admin/admin/index", Show: 1, IsEngine: 1, IsLink: 0, Status: 1},
+		{Id: 5, Name: "权限列表", GuardName: "admin", Icon: "", Type: 2, Pid: 3, Sort: 0, Path: "/api/admin/permission/index", Show: 1, IsEngine: 1, IsLink: 0, Status: 1},
+		{Id: 6, Name: "角色列表", GuardName: "admin", Icon: "", Type: 2, Pid: 3, Sort: 0, Path: "/api/admin/role/index", Show: 1, IsEngine: 1, IsLink: 0, Status: 1},
+		{Id: 7, Name: "系统配置", GuardName: "admin", Icon: "icon-setting", Type: 1, Pid: 0, Sort: 100, Path: "/system", Show: 1, IsEngine: 0, IsLink: 0, Status: 1},
+		{Id: 8, Name: "设置管理", GuardName: "admin", Icon: "", Type: 1, Pid: 7, Sort: 0, Path: "/system/config", Show: 1, IsEngine: 0, IsLink: 0, Status: 1},
+		{Id: 9, Name: "网站设置", GuardName: "admin", Icon: "", Type: 2, Pid: 8, Sort: 0, Path: "/api/admin/webConfig/setting/form", Show: 1, IsEngine: 1, IsLink: 0, Status: 1},
+		{Id: 10, Name: "配置管理", GuardName: "admin", Icon: "", Type: 2, Pid: 8, Sort: 0, Path: "/api/admin/config/index", Show: 1, IsEngine: 1, IsLink: 0, Status: 1},
+		{Id: 11, Name: "菜单管理", GuardName: "admin", Icon: "", Type: 2, Pid: 7, Sort: 0, Path: "/api/admin/menu/index", Show: 1, IsEngine: 1, IsLink: 0, Status: 1},
+		{Id: 12, Name: "操作日志", GuardName: "admin", Icon: "", Type: 2, Pid: 7, Sort: 100, Path: "/api/admin/actionLog/index", Show: 1, IsEngine: 1, IsLink: 0, Status: 1},
+		{Id: 13, Name: "附件空间", GuardName: "admin", Icon: "icon-attachment", Type: 1, Pid: 0, Sort: 100, Path: "/attachment", Show: 1, IsEngine: 0, IsLink: 0, Status: 1},
+		{Id: 14, Name: "文件管理", GuardName: "admin", Icon: "", Type: 2, Pid: 13, Sort: 0, Path: "/api/admin/file/index", Show: 1, IsEngine: 1, IsLink: 0, Status: 1},
+		{Id: 15, Name: "图片管理", GuardName: "admin", Icon: "", Type: 2, Pid: 13, Sort: 0, Path: "/api/admin/picture/index", Show: 1, IsEngine: 1, IsLink: 0, Status: 1},
+		{Id: 16, Name: "我的账号", GuardName: "admin", Icon: "icon-user", Type: 1, Pid: 0, Sort: 100, Path: "/account", Show: 1, IsEngine: 0, IsLink: 0, Status: 1},
+		{Id: 17, Name: "个人设置", GuardName: "admin", Icon: "", Type: 2, Pid: 16, Sort: 0, Path: "/api/admin/account/setting/form", Show: 1, IsEngine: 1, IsLink: 0, Status: 1},
+	}
+	for _, menu := range menus {
+		err := tx.Where(Menu{Id: menu.Id}).FirstOrCreate(&menu).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// 创建超级管理员角色，并分配给默认管理员；默认管理员不存在时分配给最早创建的管理员
+func seedSuperAdminRole(tx *gorm.DB) error {
+	type Role struct {
+		Id        int
+		Name      string
+		GuardName string
+		CreatedAt time.Time
+		UpdatedAt time.Time
+	}
+	type Admin struct {
+		Id        int
+		Username  string
+		DeletedAt gorm.DeletedAt
+	}
+	type CasbinRule struct {
+		ID    uint
+		Ptype string
+		V0    string
+		V1    string
+	}
+
+	role := Role{Name: model.SuperAdminRoleName, GuardName: "admin"}
+	err := tx.Where(Role{Name: role.Name, GuardName: role.GuardName}).FirstOrCreate(&role).Error
+	if err != nil {
+		return err
+	}
+
+	admin := Admin{}
+	err = tx.Where("username = ?", "administrator").Limit(1).Find(&admin).Error
+	if err != nil {
+		return err
+	}
+	if admin.Id == 0 {
+		err = tx.Order("id asc").Limit(1).Find(&admin).Error
+		if err != nil || admin.Id == 0 {
+			return err
+		}
+	}
+
+	rule := CasbinRule{Ptype: "g", V0: "admin|" + strconv.Itoa(admin.Id), V1: "role|" + strconv.Itoa(role.Id)}
+	err = tx.Where(rule).FirstOrCreate(&rule).Error
+	if err != nil {
+		return err
+	}
+	(&model.CasbinRule{}).ReloadAfterCommit(tx)
+	db.AfterCommit(tx, func() {
+		(&model.Role{}).ClearCache()
+	})
+
+	return nil
+}
+
+// 配置填充数据
+type configSeed struct {
+	Title     string
+	Type      string
+	Name      string
+	GroupName string
+	Value     string
+	Remark    string
+}
+
+// 填充配置，已存在的配置保持不变
+func seedConfigs(tx *gorm.DB, seeds []configSeed) error {
+	type Config struct {
+		Id        int
+		Title     string
+		Type      string
+		Name      string
+		Sort      int
+		GroupName string
+		Value     string
+		Remark    string
+		Status    int
+		CreatedAt time.Time
+		UpdatedAt time.Time
+	}
+	for _, seed := range seeds {
+		config := Config{Title: seed.Title, Type: seed.Type, Name: seed.Name, Sort: 0, GroupName: seed.GroupName, Value: seed.Value, Remark: seed.Remark, Status: 1}
+		err := tx.Where(Config{Name: config.Name}).FirstOrCreate(&config).Error
+		if err != nil {
+			return err
+		}
+	}
+	db.AfterCommit(tx, func() {
+		(&model.Config{}).ClearCache()
+	})
+
+	return nil
+}
+
+// 删除配置
+func deleteConfigs(tx *gorm.DB, names ...string) error {
+	type Config struct {
+		Id   int
+		Name string
+	}
+	err := tx.Where("name IN ?", names).Delete(&Config{}).Error
+	if err != nil {
+		return err
+	}
+	db.AfterCommit(tx, func() {
+		(&model.Config{}).ClearCache()
+	})
+
+	return nil
+}
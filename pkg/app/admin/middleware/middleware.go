@@ -1,7 +1,6 @@
 package middleware
 
 import (
-	"strconv"
 	"strings"
 
 	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/model"
@@ -9,6 +8,12 @@ import (
 	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
 )
 
+// 被要求修改密码或开启两步验证时，允许访问的路由前缀
+var AccountSettingPaths = []string{
+	"/api/admin/account/",
+	"/api/admin/layout/",
+}
+
 // 中间件
 func Handle(ctx *builder.Context) error {
 
@@ -41,54 +46,80 @@ func Handle(ctx *builder.Context) error {
 		return ctx.Next()
 	}
 
-	// 定义管理员结构体
-	adminInfo := &model.AdminClaims{}
-
 	// 获取登录管理员信息
-	err := ctx.JwtAuthUser(adminInfo)
+	adminInfo, err := (&model.Admin{}).GetAuthUser(ctx.Engine.GetConfig().AppKey, ctx.Token())
 	if err != nil {
 		return ctx.JSON(401, builder.Error(err.Error()))
 	}
 
-	guardName := adminInfo.GuardName
-	if guardName != "admin" {
+	// 刷新令牌只能用于获取新的访问令牌
+	if adminInfo.TokenType == model.RefreshTokenType {
 		return ctx.JSON(401, builder.Error("401 Unauthozied"))
 	}
 
-	// 管理员id
-	if adminInfo.Id != 1 {
-		result1, err := (&model.CasbinRule{}).Enforce("admin|"+strconv.Itoa(adminInfo.Id), ctx.FullPath(), "Any")
-		if err != nil {
-			return ctx.JSON(500, builder.Error(err.Error()))
-		}
+	// 令牌已被吊销，例如已退出登录、账号被禁用或密码已修改
+	revoked, err := (&model.Admin{}).IsTokenRevoked(adminInfo)
+	if err != nil {
+		return ctx.JSON(500, builder.Error(err.Error()))
+	}
+	if revoked {
+		return ctx.JSON(401, builder.Error("token已失效"))
+	}
 
-		result2, err := (&model.CasbinRule{}).Enforce("admin|"+strconv.Itoa(adminInfo.Id), ctx.FullPath(), ctx.Method())
-		if err != nil {
-			return ctx.JSON(500, builder.Error(err.Error()))
-		}
+	guardName := adminInfo.GuardName
+	if guardName != "admin" {
+		return ctx.JSON(401, builder.Error("401 Unauthozied"))
+	}
 
-		result3, err := (&model.CasbinRule{}).Enforce("admin|"+strconv.Itoa(adminInfo.Id), ctx.Path(), "Any")
-		if err != nil {
-			return ctx.JSON(500, builder.Error(err.Error()))
-		}
+	// 被要求修改密码或密码已过期时，仅允许访问个人设置
+	needChange, err := (&model.Admin{}).NeedChangePassword(adminInfo.Id)
+	if err != nil {
+		return ctx.JSON(500, builder.Error(err.Error()))
+	}
+	if needChange && !inAccountSettingPaths(ctx.Path()) {
+		return ctx.JSON(403, builder.Error("请先在个人设置中修改密码"))
+	}
 
-		result4, err := (&model.CasbinRule{}).Enforce("admin|"+strconv.Itoa(adminInfo.Id), ctx.Path(), ctx.Method())
-		if err != nil {
-			return ctx.JSON(500, builder.Error(err.Error()))
-		}
+	// 被要求开启两步验证但尚未开启时，仅允许访问个人设置
+	needEnroll, err := (&model.Admin{}).NeedEnrollTotp(adminInfo.Id)
+	if err != nil {
+		return ctx.JSON(500, builder.Error(err.Error()))
+	}
+	if needEnroll && !inAccountSettingPaths(ctx.Path()) {
+		return ctx.JSON(403, builder.Error("请先在个人设置中开启两步验证"))
+	}
 
-		if !(result1 || result2 || result3 || result4) {
-			return ctx.JSON(403, builder.Error("403 Forbidden"))
-		}
+	// 权限验证，超级管理员直接放行
+	result, err := (&model.CasbinRule{}).CanAccess(ctx, adminInfo.Id, ctx.Path(), ctx.Method())
+	if err != nil {
+		return ctx.JSON(500, builder.Error(err.Error()))
+	}
+	if !result {
+		return ctx.JSON(403, builder.Error("403 Forbidden"))
 	}
 
-	// 记录操作日志
-	(&model.ActionLog{}).InsertGetId(&model.ActionLog{
+	// 记录操作日志，资源的增删改操作会在执行后补充审计信息
+	actionLogId, err := (&model.ActionLog{}).InsertGetId(&model.ActionLog{
 		ObjectId: adminInfo.Id,
 		Url:      ctx.Path(),
 		Ip:       ctx.ClientIP(),
 		Type:     "admin",
+		Method:   ctx.Method(),
 	})
+	if err == nil {
+		ctx.Set("action_log_id", actionLogId)
+	}
 
 	return ctx.Next()
 }
+
+// 判断是否为被限制访问时仍允许访问的个人设置路由
+func inAccountSettingPaths(path string) bool {
+	for _, v := range AccountSettingPaths {
+		if strings.Contains(path, v) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -1,6 +1,9 @@
 package model
 
 import (
+	"encoding/json"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/quarkcloudio/quark-go/v2/pkg/dal/db"
@@ -15,14 +18,141 @@ type ActionLog struct {
 	Remark    string    `json:"remark" gorm:"size:255;not null"`
 	Ip        string    `json:"ip" gorm:"size:100;not null"`
 	Type      string    `json:"type" gorm:"size:100;not null"`
+	Method    string    `json:"method" gorm:"size:10"`
+	Resource  string    `json:"resource" gorm:"size:100"`
+	UriKey    string    `json:"uri_key" gorm:"size:100"`
+	RecordId  string    `json:"record_id" gorm:"size:500"`
+	Diff      string    `json:"diff" gorm:"type:text"`
 	Status    int       `json:"status" gorm:"size:1;not null;default:1"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
 
+// 字段变更
+type FieldChange struct {
+	Old interface{} `json:"old"`
+	New interface{} `json:"new"`
+}
+
+// 需要脱敏的字段，字段名相同或以"_字段名"结尾时脱敏，可在应用启动前修改
+var ActionLogMaskFields = []string{"password", "token", "secret", "recovery", "totp_secret", "totp_recovery"}
+
+// 对比时忽略的字段
+var actionLogIgnoreFields = []string{"updated_at"}
+
 // 插入数据
 func (model *ActionLog) InsertGetId(data *ActionLog) (id int, Error error) {
 	err := db.Client.Create(data).Error
 
 	return data.Id, err
 }
+
+// 对比记录修改前后的值，返回以记录id为键的变更字段，记录不存在时值为nil
+func (model *ActionLog) Compare(before map[string]map[string]interface{}, after map[string]map[string]interface{}) map[string]map[string]*FieldChange {
+	result := map[string]map[string]*FieldChange{}
+
+	ids := []string{}
+	for id := range before {
+		ids = append(ids, id)
+	}
+	for id := range after {
+		if _, ok := before[id]; !ok {
+			ids = append(ids, id)
+		}
+	}
+
+	for _, id := range ids {
+		changes := map[string]*FieldChange{}
+		oldRecord, newRecord := before[id], after[id]
+
+		fields := map[string]bool{}
+		for k := range oldRecord {
+			fields[k] = true
+		}
+		for k := range newRecord {
+			fields[k] = true
+		}
+
+		for k := range fields {
+			if inLogFields(k, actionLogIgnoreFields) {
+				continue
+			}
+
+			var oldValue, newValue interface{}
+			if oldRecord != nil {
+				oldValue = normalizeLogValue(oldRecord[k])
+			}
+			if newRecord != nil {
+				newValue = normalizeLogValue(newRecord[k])
+			}
+			if oldRecord != nil && newRecord != nil && fmt.Sprint(oldValue) == fmt.Sprint(newValue) {
+				continue
+			}
+
+			// 脱敏
+			if model.IsMaskField(k) {
+				if oldValue != nil {
+					oldValue = "******"
+				}
+				if newValue != nil {
+					newValue = "******"
+				}
+			}
+
+			changes[k] = &FieldChange{Old: oldValue, New: newValue}
+		}
+
+		if len(changes) > 0 {
+			result[id] = changes
+		}
+	}
+
+	return result
+}
+
+// 判断字段是否需要脱敏
+func (model *ActionLog) IsMaskField(name string) bool {
+	name = strings.ToLower(name)
+	for _, v := range ActionLogMaskFields {
+		if name == v || strings.HasSuffix(name, "_"+v) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// 解析变更记录
+func (model *ActionLog) GetDiff() (diff map[string]map[string]*FieldChange, Error error) {
+	diff = map[string]map[string]*FieldChange{}
+	if model.Diff == "" {
+		return diff, nil
+	}
+
+	err := json.Unmarshal([]byte(model.Diff), &diff)
+
+	return diff, err
+}
+
+// 判断字段是否在列表中
+func inLogFields(name string, fields []string) bool {
+	for _, v := range fields {
+		if v == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// 格式化字段值，便于对比及序列化
+func normalizeLogValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case []byte:
+		return string(v)
+	case time.Time:
+		return v.Format("2006-01-02 15:04:05")
+	}
+
+	return value
+}
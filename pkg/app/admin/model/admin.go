@@ -1,12 +1,20 @@
 package model
 
 import (
+	crand "crypto/rand"
+	"encoding/json"
 	"errors"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/go-basic/uuid"
 	"github.com/golang-jwt/jwt/v4"
+	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
+	"github.com/quarkcloudio/quark-go/v2/pkg/cache"
 	"github.com/quarkcloudio/quark-go/v2/pkg/dal/db"
 	"github.com/quarkcloudio/quark-go/v2/pkg/utils/hash"
+	"github.com/quarkcloudio/quark-go/v2/pkg/utils/totp"
 	"gorm.io/gorm"
 )
 
@@ -22,6 +30,12 @@ type Admin struct {
 	Avatar        string         `json:"avatar" gorm:"size:1000"`
 	LastLoginIp   string         `json:"last_login_ip" gorm:"size:255"`
 	LastLoginTime time.Time      `json:"last_login_time"`
+	DepartmentId  int            `json:"department_id" gorm:"not null;default:0"`
+	TotpSecret    string         `json:"totp_secret" gorm:"size:64"`                       // 两步验证密钥
+	TotpEnabled   int            `json:"totp_enabled" gorm:"size:1;not null;default:0"`    // 是否已开启两步验证
+	TotpRecovery  string         `json:"totp_recovery" gorm:"size:2000"`                   // 两步验证恢复码，哈希后的JSON数组
+	MustChangePwd int            `json:"must_change_pwd" gorm:"size:1;not null;default:0"` // 下次登录时必须修改密码
+	PwdChangedAt  time.Time      `json:"pwd_changed_at"`                                   // 最后一次修改密码的时间
 	Status        int            `json:"status" gorm:"size:1;not null;default:1"`
 	CreatedAt     time.Time      `json:"created_at"`
 	UpdatedAt     time.Time      `json:"updated_at"`
@@ -30,28 +44,38 @@ type Admin struct {
 
 // 管理员JWT结构体
 type AdminClaims struct {
-	Id        int    `json:"id"`
-	Username  string `json:"username"`
-	Nickname  string `json:"nickname"`
-	Sex       int    `json:"sex"`
-	Email     string `json:"email"`
-	Phone     string `json:"phone"`
-	Avatar    string `json:"avatar"`
-	GuardName string `json:"guard_name"`
+	Id           int    `json:"id"`
+	Username     string `json:"username"`
+	Nickname     string `json:"nickname"`
+	Sex          int    `json:"sex"`
+	Email        string `json:"email"`
+	Phone        string `json:"phone"`
+	Avatar       string `json:"avatar"`
+	GuardName    string `json:"guard_name"`
+	TokenType    string `json:"token_type,omitempty"` // 令牌类型，access或refresh
+	IssuedAtNano int64  `json:"iat_nano,omitempty"`   // 纳秒级颁发时间，iat只精确到秒，用于判断令牌是否在吊销前签发
 	jwt.RegisteredClaims
 }
 
-// 管理员Seeder
-func (model *Admin) Seeder() {
-	seeders := []Admin{
-		{Username: "administrator", Nickname: "超级管理员", Email: "admin@yourweb.com", Phone: "10086", Password: hash.Make("123456"), Sex: 1, Status: 1, LastLoginTime: time.Now()},
-	}
-
-	db.Client.Create(&seeders)
-}
+// 令牌类型
+const (
+	AccessTokenType  = "access"  // 访问令牌
+	RefreshTokenType = "refresh" // 刷新令牌
+)
 
 // 获取管理员JWT信息
 func (model *Admin) GetClaims(adminInfo *Admin) (adminClaims *AdminClaims) {
+	return model.newClaims(adminInfo, AccessTokenType, builder.GetConfig().TokenExpire)
+}
+
+// 获取管理员刷新令牌的JWT信息
+func (model *Admin) GetRefreshClaims(adminInfo *Admin) (adminClaims *AdminClaims) {
+	return model.newClaims(adminInfo, RefreshTokenType, builder.GetConfig().RefreshTokenExpire)
+}
+
+// 创建JWT信息
+func (model *Admin) newClaims(adminInfo *Admin, tokenType string, expire time.Duration) (adminClaims *AdminClaims) {
+	now := time.Now()
 	adminClaims = &AdminClaims{
 		adminInfo.Id,
 		adminInfo.Username,
@@ -61,18 +85,42 @@ func (model *Admin) GetClaims(adminInfo *Admin) (adminClaims *AdminClaims) {
 		adminInfo.Phone,
 		adminInfo.Avatar,
 		"admin",
+		tokenType,
+		now.UnixNano(),
 		jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(24 * time.Hour)), // 过期时间，默认24小时
-			IssuedAt:  jwt.NewNumericDate(time.Now()),                     // 颁发时间
-			NotBefore: jwt.NewNumericDate(time.Now()),                     // 不早于时间
-			Issuer:    "QuarkGo",                                          // 颁发人
-			Subject:   "Admin Token",                                      // 主题信息
+			ID:        uuid.New(),                          // 令牌ID，用于吊销
+			ExpiresAt: jwt.NewNumericDate(now.Add(expire)), // 过期时间
+			IssuedAt:  jwt.NewNumericDate(now),             // 颁发时间
+			NotBefore: jwt.NewNumericDate(now),             // 不早于时间
+			Issuer:    "QuarkGo",                           // 颁发人
+			Subject:   "Admin Token",                       // 主题信息
 		},
 	}
 
 	return adminClaims
 }
 
+// 吊销管理员已签发的全部令牌，禁用账号、修改密码后调用，tx为当前请求的事务
+func (model *Admin) RevokeTokens(tx *gorm.DB, id int) error {
+	return (&RevokedToken{}).RevokeSubject(
+		tx,
+		"admin|"+strconv.Itoa(id),
+		time.Now().Add(builder.GetConfig().RefreshTokenExpire),
+	)
+}
+
+// 判断令牌是否已被吊销
+func (model *Admin) IsTokenRevoked(adminClaims *AdminClaims) (bool, error) {
+	issuedAt := time.Time{}
+	if adminClaims.IssuedAtNano > 0 {
+		issuedAt = time.Unix(0, adminClaims.IssuedAtNano)
+	} else if adminClaims.IssuedAt != nil {
+		issuedAt = adminClaims.IssuedAt.Time
+	}
+
+	return (&RevokedToken{}).IsRevoked(adminClaims.ID, "admin|"+strconv.Itoa(adminClaims.Id), issuedAt)
+}
+
 // 获取当前认证的用户信息，默认参数为tokenString
 func (model *Admin) GetAuthUser(appKey string, tokenString string) (adminClaims *AdminClaims, Error error) {
 	token, err := jwt.ParseWithClaims(tokenString, &AdminClaims{}, func(token *jwt.Token) (interface{}, error) {
@@ -133,3 +181,217 @@ func (model *Admin) UpdateLastLogin(uid int, lastLoginIp string, lastLoginTime t
 		Where("id = ?", uid).
 		Updates(&data).Error
 }
+
+// 两步验证签发方名称，显示在验证器App中，可在应用启动前修改
+var TotpIssuer = "QuarkGo"
+
+// 两步验证恢复码数量
+const TotpRecoveryCount = 10
+
+// 两步验证缓存键
+const (
+	totpEnrollCacheKey   = "admin_totp_enroll:"   // 绑定中的密钥
+	totpUsedCacheKey     = "admin_totp_used:"     // 已使用的时间步，防止口令重放
+	totpRequiredCacheKey = "admin_totp_required:" // 是否需要绑定两步验证
+	pwdExpiredCacheKey   = "admin_pwd_expired:"   // 是否需要修改密码
+)
+
+// 判断管理员是否被要求开启两步验证，全局配置开启或拥有的任一角色要求时返回true
+func (model *Admin) IsTotpRequired(adminId int) (bool, error) {
+	if (&Config{}).GetValue("ADMIN_TOTP_FORCE") == "1" {
+		return true, nil
+	}
+
+	roles, err := (&CasbinRule{}).GetUserRoles(adminId)
+	if err != nil {
+		return false, err
+	}
+	for _, v := range roles {
+		if v.RequireTotp == 1 {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// 判断管理员是否被要求开启两步验证但尚未开启，结果缓存1分钟
+func (model *Admin) NeedEnrollTotp(adminId int) (bool, error) {
+	value, err := cache.Remember(totpRequiredCacheKey+strconv.Itoa(adminId), time.Minute, func() (string, error) {
+		admin := Admin{}
+		err := db.Client.Select("id", "totp_enabled").Where("id = ?", adminId).First(&admin).Error
+		if err != nil || admin.TotpEnabled == 1 {
+			return "0", err
+		}
+
+		required, err := model.IsTotpRequired(adminId)
+		if err != nil || !required {
+			return "0", err
+		}
+
+		return "1", nil
+	})
+
+	return value == "1", err
+}
+
+// 获取绑定中的两步验证密钥，不存在时生成新的密钥，10分钟内有效
+func (model *Admin) GetTotpEnrollSecret(adminId int) (string, error) {
+	key := totpEnrollCacheKey + strconv.Itoa(adminId)
+	secret, err := cache.Get(key)
+	if err == nil && secret != "" {
+		return secret, nil
+	}
+
+	secret, err = totp.GenerateSecret()
+	if err != nil {
+		return "", err
+	}
+
+	return secret, cache.Set(key, secret, time.Minute*10)
+}
+
+// 获取两步验证的otpauth链接，用于生成二维码
+func (model *Admin) GetTotpURL(username string, secret string) string {
+	return totp.URL(TotpIssuer, username, secret)
+}
+
+// 校验绑定中的密钥及口令，校验通过时返回密钥
+func (model *Admin) CheckTotpEnroll(adminId int, code string) (secret string, Error error) {
+	secret, err := cache.Get(totpEnrollCacheKey + strconv.Itoa(adminId))
+	if err != nil {
+		return "", errors.New("绑定已过期，请刷新页面后重新扫码")
+	}
+
+	counter, ok := totp.Validate(secret, code, time.Now())
+	if !ok {
+		return "", errors.New("验证码错误")
+	}
+
+	used, err := model.useTotpCounter(adminId, counter)
+	if err != nil {
+		return "", err
+	}
+	if used {
+		return "", errors.New("验证码已使用，请等待下一个验证码")
+	}
+
+	return secret, nil
+}
+
+// 清除两步验证缓存，开启、关闭两步验证后调用
+func (model *Admin) ClearTotpCache(adminId int) error {
+	return cache.Delete(totpEnrollCacheKey+strconv.Itoa(adminId), totpRequiredCacheKey+strconv.Itoa(adminId))
+}
+
+// 校验两步验证口令，口令在有效期内只能使用一次
+func (model *Admin) CheckTotpCode(adminInfo *Admin, code string) bool {
+	if adminInfo.TotpEnabled != 1 || adminInfo.TotpSecret == "" {
+		return false
+	}
+
+	counter, ok := totp.Validate(adminInfo.TotpSecret, code, time.Now())
+	if !ok {
+		return false
+	}
+
+	used, err := model.useTotpCounter(adminInfo.Id, counter)
+
+	return err == nil && !used
+}
+
+// 校验两步验证口令或恢复码，恢复码使用后失效
+func (model *Admin) VerifyTotp(adminInfo *Admin, code string) (bool, error) {
+	if adminInfo.TotpEnabled != 1 || adminInfo.TotpSecret == "" {
+		return false, errors.New("未开启两步验证")
+	}
+
+	code = strings.ToLower(strings.TrimSpace(code))
+	if model.CheckTotpCode(adminInfo, code) {
+		return true, nil
+	}
+
+	hashedCodes := []string{}
+	if adminInfo.TotpRecovery != "" {
+		err := json.Unmarshal([]byte(adminInfo.TotpRecovery), &hashedCodes)
+		if err != nil {
+			return false, err
+		}
+	}
+	for k, v := range hashedCodes {
+		if !hash.Check(v, code) {
+			continue
+		}
+
+		value, err := json.Marshal(append(hashedCodes[:k:k], hashedCodes[k+1:]...))
+		if err != nil {
+			return false, err
+		}
+
+		// 条件更新，避免同一恢复码被并发使用
+		result := db.Client.Model(&Admin{}).
+			Where("id = ?", adminInfo.Id).
+			Where("totp_recovery = ?", adminInfo.TotpRecovery).
+			Update("totp_recovery", string(value))
+
+		return result.RowsAffected == 1, result.Error
+	}
+
+	return false, nil
+}
+
+// 记录已使用的时间步，返回该时间步此前是否已被使用
+func (model *Admin) useTotpCounter(adminId int, counter int64) (used bool, Error error) {
+	key := totpUsedCacheKey + strconv.Itoa(adminId) + ":" + strconv.FormatInt(counter, 10)
+
+	// 原子计数，并发提交同一口令时只有首次计数为1
+	count, err := cache.Incr(key, time.Second*totp.Period*(totp.Skew*2+1))
+
+	return count > 1, err
+}
+
+// 生成恢复码，返回明文及哈希后的JSON数组，明文仅展示一次
+func (model *Admin) MakeTotpRecovery() (recoveryCodes []string, hashedCodes string, Error error) {
+	seed := "abcdefghjkmnpqrstuvwxyz23456789"
+	hashed := []string{}
+	for i := 0; i < TotpRecoveryCount; i++ {
+		buf := make([]byte, 10)
+		if _, err := crand.Read(buf); err != nil {
+			return nil, "", err
+		}
+		for k, v := range buf {
+			buf[k] = seed[int(v)%len(seed)]
+		}
+
+		code := string(buf[:5]) + "-" + string(buf[5:])
+		recoveryCodes = append(recoveryCodes, code)
+		hashed = append(hashed, hash.Make(code))
+	}
+
+	value, err := json.Marshal(hashed)
+
+	return recoveryCodes, string(value), err
+}
+
+// 判断管理员是否需要修改密码，被要求修改或密码已过期时返回true，结果缓存1分钟
+func (model *Admin) NeedChangePassword(adminId int) (bool, error) {
+	value, err := cache.Remember(pwdExpiredCacheKey+strconv.Itoa(adminId), time.Minute, func() (string, error) {
+		admin := Admin{}
+		err := db.Client.Select("id", "must_change_pwd", "pwd_changed_at").Where("id = ?", adminId).First(&admin).Error
+		if err != nil {
+			return "0", err
+		}
+		if admin.MustChangePwd == 1 || (&PasswordHistory{}).GetPolicy().IsExpired(admin.PwdChangedAt) {
+			return "1", nil
+		}
+
+		return "0", nil
+	})
+
+	return value == "1", err
+}
+
+// 清除修改密码状态缓存，修改密码后调用
+func (model *Admin) ClearPasswordCache(adminId int) error {
+	return cache.Delete(pwdExpiredCacheKey + strconv.Itoa(adminId))
+}
@@ -1,16 +1,23 @@
 package model
 
 import (
+	"errors"
+	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/casbin/casbin/v2"
 	casbinmodel "github.com/casbin/casbin/v2/model"
+	"github.com/casbin/casbin/v2/persist"
+	"github.com/casbin/casbin/v2/util"
 	gormadapter "github.com/casbin/gorm-adapter/v3"
 	rediswatcher "github.com/casbin/redis-watcher/v2"
 	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
 	"github.com/quarkcloudio/quark-go/v2/pkg/dal/db"
+	redisclient "github.com/quarkcloudio/quark-go/v2/pkg/dal/redis"
 	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
 )
 
 // 字段
@@ -27,6 +34,9 @@ type CasbinRule struct {
 
 var Enforcer *casbin.Enforcer
 
+// 策略变更通知，多实例部署时通知其他实例重新加载策略
+var policyWatcher persist.Watcher
+
 // 获取Enforcer
 func (p *CasbinRule) Enforcer() (enforcer *casbin.Enforcer, err error) {
 	if Enforcer != nil {
@@ -51,7 +61,7 @@ func (p *CasbinRule) Enforcer() (enforcer *casbin.Enforcer, err error) {
 		e = some(where (p.eft == allow))
 		
 		[matchers]
-		m = g(r.sub, p.sub) && r.obj == p.obj && r.act == p.act
+		m = g(r.sub, p.sub) && pathMatch(r.obj, p.obj) && methodMatch(r.act, p.act)
 	`)
 	if err != nil {
 		return nil, err
@@ -62,8 +72,12 @@ func (p *CasbinRule) Enforcer() (enforcer *casbin.Enforcer, err error) {
 		return nil, err
 	}
 
+	// 路径及请求方法匹配函数
+	Enforcer.AddFunction("pathMatch", pathMatchFunc)
+	Enforcer.AddFunction("methodMatch", methodMatchFunc)
+
 	redisConfig := builder.GetConfig().RedisConfig
-	if redisConfig != nil {
+	if redisConfig != nil && redisclient.Client != nil {
 
 		// 不同的Channel
 		appKey := builder.GetConfig().AppKey
@@ -80,6 +94,7 @@ func (p *CasbinRule) Enforcer() (enforcer *casbin.Enforcer, err error) {
 		if err != nil {
 			return nil, err
 		}
+		policyWatcher = w
 
 		// Or use the default callback
 		err = w.SetUpdateCallback(func(s string) {
@@ -93,65 +108,168 @@ func (p *CasbinRule) Enforcer() (enforcer *casbin.Enforcer, err error) {
 	return Enforcer, err
 }
 
-// 查看是否放行
-func (p *CasbinRule) Enforce(sub string, obj string, act string) (result bool, err error) {
-	enforcer, err := p.Enforcer()
+// 已编译的路径正则，无效的正则对应nil
+var pathPatterns sync.Map
+
+// 路径匹配：以^开头的规则按正则匹配，否则按keyMatch2匹配，支持/api/admin/:resource/index、/api/admin/article/*形式
+func pathMatch(path string, pattern string) bool {
+	if strings.HasPrefix(pattern, "^") {
+		re := compilePathPattern(pattern)
+		if re == nil {
+			return false
+		}
+
+		return re.MatchString(path)
+	}
+
+	return util.KeyMatch2(path, pattern)
+}
+
+// 编译路径正则并缓存，无效的正则记录日志后按不匹配处理
+func compilePathPattern(pattern string) *regexp.Regexp {
+	if value, ok := pathPatterns.Load(pattern); ok {
+		return value.(*regexp.Regexp)
+	}
+
+	re, err := regexp.Compile(pattern)
 	if err != nil {
-		return
+		builder.GetLogger().Warnf("invalid permission path pattern %q: %v", pattern, err)
+		re = nil
 	}
+	pathPatterns.Store(pattern, re)
 
-	result, err = enforcer.Enforce(sub, obj, act)
+	return re
+}
 
-	return
+// 验证权限路径，以^开头时需为有效的正则表达式
+func (p *CasbinRule) ValidatePath(path string) error {
+	if !strings.HasPrefix(path, "^") {
+		return nil
+	}
+
+	_, err := regexp.Compile(path)
+	if err != nil {
+		return errors.New("路径不是有效的正则表达式：" + err.Error())
+	}
+
+	return nil
 }
 
-// 添加菜单拥有的权限
-func (p *CasbinRule) AddMenuPermission(menuId int, permissionIds interface{}) (err error) {
+// 请求方法匹配：Any或*匹配所有方法，多个方法使用|分隔，例如GET|POST
+func methodMatch(method string, pattern string) bool {
+	if pattern == "Any" || pattern == "*" {
+		return true
+	}
+
+	for _, v := range strings.Split(pattern, "|") {
+		if strings.EqualFold(v, method) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// pathMatch的Casbin函数包装
+func pathMatchFunc(args ...interface{}) (interface{}, error) {
+	if len(args) != 2 {
+		return false, errors.New("pathMatch: expected 2 arguments")
+	}
+	path, _ := args[0].(string)
+	pattern, _ := args[1].(string)
+
+	return pathMatch(path, pattern), nil
+}
+
+// methodMatch的Casbin函数包装
+func methodMatchFunc(args ...interface{}) (interface{}, error) {
+	if len(args) != 2 {
+		return false, errors.New("methodMatch: expected 2 arguments")
+	}
+	method, _ := args[0].(string)
+	pattern, _ := args[1].(string)
+
+	return methodMatch(method, pattern), nil
+}
+
+// 判断管理员是否拥有超级管理员角色
+func (p *CasbinRule) IsSuperAdmin(adminId int) (result bool, err error) {
+	roleId, err := (&Role{}).GetSuperAdminRoleId()
+	if err != nil || roleId == 0 {
+		return false, err
+	}
+
 	enforcer, err := p.Enforcer()
 	if err != nil {
-		return err
+		return false, err
 	}
 
-	permissions, err := (&Permission{}).GetListByIds(permissionIds)
-	if err != nil {
-		return err
+	return enforcer.HasRoleForUser("admin|"+strconv.Itoa(adminId), "role|"+strconv.Itoa(roleId))
+}
+
+// 判断管理员能否访问指定路径，超级管理员直接放行，判断结果在当前请求内缓存
+func (p *CasbinRule) CanAccess(ctx *builder.Context, adminId int, path string, method string) (result bool, err error) {
+	decisions, _ := ctx.Get("casbin_decisions").(map[string]bool)
+	if decisions == nil {
+		decisions = map[string]bool{}
+		ctx.Set("casbin_decisions", decisions)
 	}
 
-	rules := [][]string{}
-	for _, v := range permissions {
-		rules = append(rules, []string{"menu|" + strconv.Itoa(menuId), v.Name, "MenuHasPermission"})
+	key := strconv.Itoa(adminId) + " " + method + " " + path
+	if result, ok := decisions[key]; ok {
+		return result, nil
 	}
 
-	p.RemoveMenuPermissions(menuId)
-	if len(rules) == 0 {
-		return
+	result, err = p.IsSuperAdmin(adminId)
+	if err != nil {
+		return false, err
 	}
+	if !result {
+		result, err = p.Enforce("admin|"+strconv.Itoa(adminId), path, method)
+		if err != nil {
+			return false, err
+		}
+	}
+	decisions[key] = result
 
-	_, err = enforcer.AddPolicies(rules)
+	return result, nil
+}
+
+// 查看是否放行
+func (p *CasbinRule) Enforce(sub string, obj string, act string) (result bool, err error) {
+	enforcer, err := p.Enforcer()
 	if err != nil {
-		return err
+		return
 	}
 
-	enforcer.SavePolicy()
+	result, err = enforcer.Enforce(sub, obj, act)
 
 	return
 }
 
-// 删除菜单拥有的权限
-func (p *CasbinRule) RemoveMenuPermissions(menuId int) (err error) {
-	enforcer, err := p.Enforcer()
+// 添加菜单拥有的权限，tx为当前请求的事务，提交后重新加载策略
+func (p *CasbinRule) AddMenuPermission(tx *gorm.DB, menuId int, permissionIds interface{}) (err error) {
+	permissions, err := (&Permission{}).GetListByIds(permissionIds)
 	if err != nil {
 		return err
 	}
 
-	_, err = enforcer.DeleteUser("menu|" + strconv.Itoa(menuId))
+	rules := []CasbinRule{}
+	for _, v := range permissions {
+		rules = append(rules, CasbinRule{Ptype: "p", V0: "menu|" + strconv.Itoa(menuId), V1: v.Name, V2: "MenuHasPermission"})
+	}
+
+	err = p.RemoveMenuPermissions(tx, menuId)
 	if err != nil {
 		return err
 	}
 
-	enforcer.SavePolicy()
+	return p.addRules(tx, rules)
+}
 
-	return
+// 删除菜单拥有的权限，tx为当前请求的事务，提交后重新加载策略
+func (p *CasbinRule) RemoveMenuPermissions(tx *gorm.DB, menuId int) (err error) {
+	return p.removeSubject(tx, "menu|"+strconv.Itoa(menuId))
 }
 
 // 获取菜单拥有的权限
@@ -172,19 +290,14 @@ func (p *CasbinRule) GetMenuPermissions(menuId int) (permissions []*Permission,
 	return
 }
 
-// 给角色添加菜单及权限
-func (p *CasbinRule) AddMenuAndPermissionToRole(roleId int, menuIds []int) (err error) {
-	enforcer, err := p.Enforcer()
-	if err != nil {
-		return err
-	}
-
-	rules := [][]string{}
+// 给角色添加菜单及权限，tx为当前请求的事务，提交后重新加载策略
+func (p *CasbinRule) AddMenuAndPermissionToRole(tx *gorm.DB, roleId int, menuIds []int) (err error) {
+	rules := []CasbinRule{}
 	addedRules := make(map[string]bool)
 
 	// 角色拥有的菜单
 	for _, v := range menuIds {
-		rules = append(rules, []string{"role|" + strconv.Itoa(roleId), "menu|" + strconv.Itoa(v), "RoleHasMenu"})
+		rules = append(rules, CasbinRule{Ptype: "p", V0: "role|" + strconv.Itoa(roleId), V1: "menu|" + strconv.Itoa(v), V2: "RoleHasMenu"})
 	}
 
 	// 角色拥有的权限
@@ -194,7 +307,7 @@ func (p *CasbinRule) AddMenuAndPermissionToRole(roleId int, menuIds []int) (err
 			for _, sv := range menuHasPermissions {
 				rule := "role|" + strconv.Itoa(roleId) + sv.Path + sv.Method
 				if !addedRules[rule] {
-					rules = append(rules, []string{"role|" + strconv.Itoa(roleId), sv.Path, sv.Method})
+					rules = append(rules, CasbinRule{Ptype: "p", V0: "role|" + strconv.Itoa(roleId), V1: sv.Path, V2: sv.Method})
 					addedRules[rule] = true
 				}
 			}
@@ -202,32 +315,17 @@ func (p *CasbinRule) AddMenuAndPermissionToRole(roleId int, menuIds []int) (err
 	}
 
 	// 先清理数据
-	p.RemoveRoleMenuAndPermissions(roleId)
-
-	if len(rules) > 0 {
-		// 添加策略
-		_, err = enforcer.AddPolicies(rules)
-		if err != nil {
-			return err
-		}
-	}
-
-	return
-}
-
-// 删除角色拥有的菜单及权限
-func (p *CasbinRule) RemoveRoleMenuAndPermissions(roleId int) (err error) {
-	enforcer, err := p.Enforcer()
+	err = p.RemoveRoleMenuAndPermissions(tx, roleId)
 	if err != nil {
 		return err
 	}
 
-	_, err = enforcer.DeleteUser("role|" + strconv.Itoa(roleId))
-	if err != nil {
-		return err
-	}
+	return p.addRules(tx, rules)
+}
 
-	return
+// 删除角色拥有的菜单及权限，tx为当前请求的事务，提交后重新加载策略
+func (p *CasbinRule) RemoveRoleMenuAndPermissions(tx *gorm.DB, roleId int) (err error) {
+	return p.removeSubject(tx, "role|"+strconv.Itoa(roleId))
 }
 
 // 获取角色拥有的菜单
@@ -271,43 +369,77 @@ func (p *CasbinRule) GetRolePermissions(roleId int) (permissions []*Permission,
 	return
 }
 
-// 添加用户拥有的角色
-func (p *CasbinRule) AddUserRole(modelId int, roleIds []int) (err error) {
-	enforcer, err := p.Enforcer()
-	if err != nil {
-		return err
+// 添加用户拥有的角色，tx为当前请求的事务，提交后重新加载策略
+func (p *CasbinRule) AddUserRole(tx *gorm.DB, modelId int, roleIds []int) (err error) {
+	rules := []CasbinRule{}
+	for _, v := range roleIds {
+		rules = append(rules, CasbinRule{Ptype: "g", V0: "admin|" + strconv.Itoa(modelId), V1: "role|" + strconv.Itoa(v)})
 	}
 
-	roles := []string{}
-	for _, v := range roleIds {
-		roles = append(roles, "role|"+strconv.Itoa(v))
+	err = p.RemoveUserRoles(tx, modelId)
+	if err != nil {
+		return err
 	}
 
-	p.RemoveUserRoles(modelId)
+	return p.addRules(tx, rules)
+}
 
-	if len(roles) > 0 {
-		_, err = enforcer.AddRolesForUser("admin|"+strconv.Itoa(modelId), roles)
-		if err != nil {
-			return err
-		}
+// 删除用户拥有的角色，tx为当前请求的事务，提交后重新加载策略
+func (p *CasbinRule) RemoveUserRoles(tx *gorm.DB, modelId int) (err error) {
+	err = tx.Session(&gorm.Session{NewDB: true}).
+		Where("ptype = ? AND v0 = ?", "g", "admin|"+strconv.Itoa(modelId)).
+		Delete(&CasbinRule{}).Error
+	if err != nil {
+		return err
 	}
+	p.ReloadAfterCommit(tx)
 
 	return
 }
 
-// 删除用户拥有的角色
-func (p *CasbinRule) RemoveUserRoles(modelId int) (err error) {
-	enforcer, err := p.Enforcer()
+// 在事务中写入策略，提交后重新加载策略
+func (p *CasbinRule) addRules(tx *gorm.DB, rules []CasbinRule) error {
+	if len(rules) == 0 {
+		return nil
+	}
+
+	err := tx.Session(&gorm.Session{NewDB: true}).Create(&rules).Error
 	if err != nil {
 		return err
 	}
+	p.ReloadAfterCommit(tx)
 
-	_, err = enforcer.DeleteRolesForUser("admin|" + strconv.Itoa(modelId))
+	return nil
+}
+
+// 在事务中删除主体的全部策略及其拥有的角色，提交后重新加载策略
+func (p *CasbinRule) removeSubject(tx *gorm.DB, subject string) error {
+	err := tx.Session(&gorm.Session{NewDB: true}).
+		Where("ptype IN ? AND v0 = ?", []string{"p", "g"}, subject).
+		Delete(&CasbinRule{}).Error
 	if err != nil {
 		return err
 	}
+	p.ReloadAfterCommit(tx)
 
-	return
+	return nil
+}
+
+// 事务提交后从数据库重新加载策略，清除菜单缓存并通知其他实例，事务回滚时内存中的策略不受影响
+func (p *CasbinRule) ReloadAfterCommit(tx *gorm.DB) {
+	db.AfterCommit(tx, func() {
+		defer (&Menu{}).ClearCache()
+
+		enforcer, err := p.Enforcer()
+		if err != nil {
+			return
+		}
+		enforcer.LoadPolicy()
+
+		if policyWatcher != nil {
+			policyWatcher.Update()
+		}
+	})
 }
 
 // 获取用户拥有的角色
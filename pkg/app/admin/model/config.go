@@ -1,8 +1,10 @@
 package model
 
 import (
+	"sync"
 	"time"
 
+	"github.com/quarkcloudio/quark-go/v2/pkg/cache"
 	"github.com/quarkcloudio/quark-go/v2/pkg/dal/db"
 )
 
@@ -21,46 +23,84 @@ type Config struct {
 	UpdatedAt time.Time `json:"updated_at"`
 }
 
-// 存储配置
-var webConfig = make(map[string]string)
-
-// 配置表
-func (model *Config) Seeder() {
-	seeders := []Config{
-		{Title: "网站名称", Type: "text", Name: "WEB_SITE_NAME", Sort: 0, GroupName: "基本", Value: "QuarkCloud", Remark: "", Status: 1},
-		{Title: "关键字", Type: "text", Name: "WEB_SITE_KEYWORDS", Sort: 0, GroupName: "基本", Value: "QuarkCloud", Remark: "", Status: 1},
-		{Title: "描述", Type: "textarea", Name: "WEB_SITE_DESCRIPTION", Sort: 0, GroupName: "基本", Value: "QuarkCloud", Remark: "", Status: 1},
-		{Title: "Logo", Type: "picture", Name: "WEB_SITE_LOGO", Sort: 0, GroupName: "基本", Value: "", Remark: "", Status: 1},
-		{Title: "统计代码", Type: "textarea", Name: "WEB_SITE_SCRIPT", Sort: 0, GroupName: "基本", Value: "", Remark: "", Status: 1},
-		{Title: "网站域名", Type: "text", Name: "WEB_SITE_DOMAIN", Sort: 0, GroupName: "基本", Value: "", Remark: "", Status: 1},
-		{Title: "网站版权", Type: "text", Name: "WEB_SITE_COPYRIGHT", Sort: 0, GroupName: "基本", Value: "© Company 2018", Remark: "", Status: 1},
-		{Title: "开启SSL", Type: "switch", Name: "SSL_OPEN", Sort: 0, GroupName: "基本", Value: "0", Remark: "", Status: 1},
-		{Title: "开启网站", Type: "switch", Name: "WEB_SITE_OPEN", Sort: 0, GroupName: "基本", Value: "1", Remark: "", Status: 1},
-		{Title: "KeyID", Type: "text", Name: "OSS_ACCESS_KEY_ID", Sort: 0, GroupName: "阿里云存储", Value: "", Remark: "你的AccessKeyID", Status: 1},
-		{Title: "KeySecret", Type: "text", Name: "OSS_ACCESS_KEY_SECRET", Sort: 0, GroupName: "阿里云存储", Value: "", Remark: "你的AccessKeySecret", Status: 1},
-		{Title: "EndPoint", Type: "text", Name: "OSS_ENDPOINT", Sort: 0, GroupName: "阿里云存储", Value: "", Remark: "地域节点", Status: 1},
-		{Title: "Bucket域名", Type: "text", Name: "OSS_BUCKET", Sort: 0, GroupName: "阿里云存储", Value: "", Remark: "", Status: 1},
-		{Title: "自定义域名", Type: "text", Name: "OSS_MYDOMAIN", Sort: 0, GroupName: "阿里云存储", Value: "", Remark: "例如：oss.web.com", Status: 1},
-		{Title: "开启云存储", Type: "switch", Name: "OSS_OPEN", Sort: 0, GroupName: "阿里云存储", Value: "0", Remark: "", Status: 1},
+// 网站配置缓存键
+const webConfigCacheKey = "web_config"
+
+// 进程内配置的有效期，多实例部署时其他实例修改配置后最迟在此时间后生效
+const webConfigLocalTTL = 10 * time.Second
+
+// 进程内解码后的配置，避免每次读取时访问缓存并解码
+var webConfigLocal struct {
+	sync.RWMutex
+	values   map[string]string
+	loadedAt time.Time
+}
+
+// 获取全部配置，优先使用进程内已解码的配置
+func (model *Config) all() map[string]string {
+	webConfigLocal.RLock()
+	values, loadedAt := webConfigLocal.values, webConfigLocal.loadedAt
+	webConfigLocal.RUnlock()
+	if values != nil && time.Since(loadedAt) < webConfigLocalTTL {
+		return values
+	}
+
+	webConfigLocal.Lock()
+	defer webConfigLocal.Unlock()
+	if webConfigLocal.values != nil && time.Since(webConfigLocal.loadedAt) < webConfigLocalTTL {
+		return webConfigLocal.values
+	}
+
+	values, err := model.load()
+	if err != nil {
+		return map[string]string{}
 	}
+	webConfigLocal.values = values
+	webConfigLocal.loadedAt = time.Now()
 
-	db.Client.Create(&seeders)
+	return values
 }
 
-// 刷新配置
-func (model *Config) Refresh() {
-	configs := []Config{}
-	db.Client.Where("status", 1).Find(&configs)
-	for _, config := range configs {
-		webConfig[config.Name] = config.Value
+// 从缓存读取全部配置，缓存不存在时从数据库加载
+func (model *Config) load() (map[string]string, error) {
+	webConfig := map[string]string{}
+	err := cache.RememberJSON(webConfigCacheKey, 0, &webConfig, func() (interface{}, error) {
+		configs := []Config{}
+		err := db.Client.Where("status", 1).Find(&configs).Error
+		if err != nil {
+			return nil, err
+		}
+
+		values := map[string]string{}
+		for _, config := range configs {
+			values[config.Name] = config.Value
+		}
+
+		return values, nil
+	})
+	if err != nil {
+		return nil, err
 	}
+
+	return webConfig, nil
+}
+
+// 清除配置缓存，下次读取时重新加载
+func (model *Config) ClearCache() error {
+	webConfigLocal.Lock()
+	webConfigLocal.values = nil
+	webConfigLocal.Unlock()
+
+	return cache.Delete(webConfigCacheKey)
+}
+
+// 刷新配置，清除缓存后重新加载
+func (model *Config) Refresh() {
+	model.ClearCache()
+	model.all()
 }
 
 // 获取配置信息
 func (model *Config) GetValue(key string) string {
-	if len(webConfig) == 0 {
-		model.Refresh()
-	}
-
-	return webConfig[key]
+	return model.all()[key]
 }
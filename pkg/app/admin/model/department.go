@@ -0,0 +1,95 @@
+package model
+
+import (
+	"time"
+
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/form/fields/treeselect"
+	"github.com/quarkcloudio/quark-go/v2/pkg/dal/db"
+)
+
+// 部门
+type Department struct {
+	Id        int       `json:"id" gorm:"autoIncrement"`
+	Pid       int       `json:"pid" gorm:"size:11;default:0"`
+	Name      string    `json:"name" gorm:"size:100;not null"`
+	Sort      int       `json:"sort" gorm:"size:11;default:0"`
+	Status    int       `json:"status" gorm:"size:1;not null;default:1"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// 获取TreeSelect组件数据
+func (model *Department) TreeSelect(root bool) (list []*treeselect.TreeData, Error error) {
+
+	// 是否有根节点
+	if root {
+		list = append(list, &treeselect.TreeData{
+			Title: "根节点",
+			Value: 0,
+		})
+	}
+
+	list = append(list, model.FindTreeSelectNode(0)...)
+
+	return list, nil
+}
+
+// 递归获取TreeSelect组件数据
+func (model *Department) FindTreeSelectNode(pid int) (list []*treeselect.TreeData) {
+	departments := []Department{}
+	db.Client.
+		Where("pid = ?", pid).
+		Where("status = ?", 1).
+		Order("sort asc,id asc").
+		Select("name", "id", "pid").
+		Find(&departments)
+
+	if len(departments) == 0 {
+		return list
+	}
+
+	for _, v := range departments {
+		item := &treeselect.TreeData{
+			Value: v.Id,
+			Title: v.Name,
+		}
+
+		children := model.FindTreeSelectNode(v.Id)
+		if len(children) > 0 {
+			item.Children = children
+		}
+
+		list = append(list, item)
+	}
+
+	return list
+}
+
+// 获取部门及其所有子部门的id
+func (model *Department) GetChildrenIds(id int) (ids []int, Error error) {
+	ids = []int{id}
+	pids := []int{id}
+	found := map[int]bool{id: true}
+	for len(pids) > 0 {
+		childrenIds := []int{}
+		err := db.Client.
+			Model(&Department{}).
+			Where("pid IN ?", pids).
+			Pluck("id", &childrenIds).Error
+		if err != nil {
+			return ids, err
+		}
+
+		// 跳过已查找过的部门，避免数据异常时死循环
+		pids = []int{}
+		for _, v := range childrenIds {
+			if !found[v] {
+				found[v] = true
+				ids = append(ids, v)
+				pids = append(pids, v)
+			}
+		}
+	}
+
+	return ids, nil
+}
@@ -7,6 +7,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
 	"github.com/quarkcloudio/quark-go/v2/pkg/dal/db"
 	"github.com/xuri/excelize/v2"
 )
@@ -43,6 +44,26 @@ func (model *File) GetInfoByHash(hash string) (file *File, Error error) {
 	return file, err
 }
 
+// 根据id查询文件信息
+func (model *File) GetInfoById(id interface{}) (file *File, Error error) {
+	err := db.Client.Where("status = ?", 1).Where("id = ?", id).First(&file).Error
+
+	return file, err
+}
+
+// 根据id删除文件
+func (model *File) DeleteById(id interface{}) error {
+
+	return db.Client.Model(File{}).Where("id =?", id).Delete("").Error
+}
+
+// 统计引用同一存储路径的文件数量
+func (model *File) CountByPath(path string) (count int64, Error error) {
+	err := db.Client.Model(&File{}).Where("path = ?", path).Count(&count).Error
+
+	return count, err
+}
+
 // 获取文件路径
 func (model *File) GetPath(id interface{}) string {
 	http, path := "", ""
@@ -61,10 +82,10 @@ func (model *File) GetPath(id interface{}) string {
 			return getId
 		}
 		if strings.Contains(getId, "./") && !strings.Contains(getId, "{") {
-			return http + webSiteDomain + strings.Replace(getId, "./web/app/", "/", -1)
+			return http + webSiteDomain + routePath(strings.Replace(getId, "./web/app/", "/", -1))
 		}
 		if strings.Contains(getId, "/") && !strings.Contains(getId, "{") {
-			return http + webSiteDomain + getId
+			return http + webSiteDomain + routePath(getId)
 		}
 
 		// json字符串
@@ -88,7 +109,7 @@ func (model *File) GetPath(id interface{}) string {
 			path = strings.Replace(path, "./web/app/", "/", -1)
 		}
 		if path != "" {
-			return http + webSiteDomain + path
+			return http + webSiteDomain + routePath(path)
 		}
 	}
 
@@ -103,13 +124,22 @@ func (model *File) GetPath(id interface{}) string {
 			path = strings.Replace(path, "./web/app/", "/", -1)
 		}
 		if path != "" {
-			return http + webSiteDomain + path
+			return http + webSiteDomain + routePath(path)
 		}
 	}
 
 	return ""
 }
 
+// 本地文件地址添加路由前缀
+func routePath(path string) string {
+	if config := builder.GetConfig(); config != nil {
+		return config.RoutePrefix + path
+	}
+
+	return path
+}
+
 // 获取多文件路径
 func (model *File) GetPaths(id interface{}) []string {
 	var paths []string
@@ -139,7 +169,7 @@ func (model *File) GetPaths(id interface{}) []string {
 							path = strings.Replace(path, "./web/app/", "/", -1)
 						}
 						if path != "" {
-							path = http + webSiteDomain + path
+							path = http + webSiteDomain + routePath(path)
 						}
 						paths = append(paths, path)
 					}
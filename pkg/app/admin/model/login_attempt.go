@@ -0,0 +1,101 @@
+package model
+
+import (
+	"strings"
+	"time"
+
+	"github.com/quarkcloudio/quark-go/v2/pkg/cache"
+)
+
+// 登录失败限制，失败次数使用缓存统计，配置Redis后支持多实例部署
+type LoginAttempt struct{}
+
+// 登录失败限制参数，可在应用启动前修改
+var (
+	LoginMaxAttempts   = 5                      // 同一用户名连续失败次数达到后锁定
+	LoginIpMaxAttempts = 20                     // 同一IP连续失败次数达到后锁定
+	LoginLockDuration  = 15 * time.Minute       // 锁定时长，同时也是失败次数的统计周期
+	LoginDelayStep     = 500 * time.Millisecond // 每次失败后增加的响应延迟
+	LoginMaxDelay      = 3 * time.Second        // 最大响应延迟
+)
+
+// 登录失败限制缓存键
+const (
+	loginFailCacheKey = "admin_login_fail:" // 失败次数
+	loginLockCacheKey = "admin_login_lock:" // 锁定标识
+)
+
+// 获取剩余锁定时长，用户名及IP均未锁定时返回0
+func (model *LoginAttempt) LockedFor(username string, ip string) time.Duration {
+	var result time.Duration
+	for _, key := range model.keys(username, ip) {
+		ttl, err := cache.TTL(loginLockCacheKey + key)
+		if err == nil && ttl > result {
+			result = ttl
+		}
+	}
+
+	return result
+}
+
+// 判断用户名是否已被锁定
+func (model *LoginAttempt) IsLocked(username string) bool {
+	_, err := cache.Get(loginLockCacheKey + model.keys(username, "")[0])
+
+	return err == nil
+}
+
+// 记录一次登录失败，返回本次失败后的响应延迟，达到最大失败次数时锁定
+func (model *LoginAttempt) Fail(username string, ip string) (delay time.Duration, locked bool, Error error) {
+	limits := []int{LoginMaxAttempts, LoginIpMaxAttempts}
+	for k, key := range model.keys(username, ip) {
+
+		// 原子计数，避免并发请求读取到相同的失败次数，统计周期从首次失败开始
+		failures, err := cache.Incr(loginFailCacheKey+key, LoginLockDuration)
+		if err != nil {
+			return 0, false, err
+		}
+
+		if failures >= int64(limits[k]) {
+			err = cache.Set(loginLockCacheKey+key, "1", LoginLockDuration)
+			if err != nil {
+				return 0, false, err
+			}
+			cache.Delete(loginFailCacheKey + key)
+			locked = true
+			continue
+		}
+
+		// 按用户名的失败次数递增延迟
+		if k == 0 {
+			delay = time.Duration(failures) * LoginDelayStep
+			if delay > LoginMaxDelay {
+				delay = LoginMaxDelay
+			}
+		}
+	}
+
+	return delay, locked, nil
+}
+
+// 登录成功后清除用户名的失败次数
+func (model *LoginAttempt) Clear(username string) error {
+	return cache.Delete(loginFailCacheKey + model.keys(username, "")[0])
+}
+
+// 解除用户名的锁定
+func (model *LoginAttempt) Unlock(username string) error {
+	key := model.keys(username, "")[0]
+
+	return cache.Delete(loginFailCacheKey+key, loginLockCacheKey+key)
+}
+
+// 获取用户名及IP的缓存键，IP为空时只返回用户名的缓存键
+func (model *LoginAttempt) keys(username string, ip string) []string {
+	keys := []string{"username:" + strings.ToLower(strings.TrimSpace(username))}
+	if ip != "" {
+		keys = append(keys, "ip:"+ip)
+	}
+
+	return keys
+}
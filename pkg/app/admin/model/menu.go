@@ -1,12 +1,14 @@
 package model
 
 import (
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/go-basic/uuid"
 	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/form/fields/tree"
 	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/form/fields/treeselect"
+	"github.com/quarkcloudio/quark-go/v2/pkg/cache"
 	"github.com/quarkcloudio/quark-go/v2/pkg/dal/db"
 	"github.com/quarkcloudio/quark-go/v2/pkg/utils/lister"
 	"gorm.io/gorm"
@@ -33,31 +35,6 @@ type Menu struct {
 	UpdatedAt  time.Time `json:"updated_at"`
 }
 
-// 菜单表
-func (p *Menu) Seeder() {
-	seeders := []Menu{
-		{Id: 1, Name: "控制台", GuardName: "admin", Icon: "icon-home", Type: 1, Pid: 0, Sort: 0, Path: "/dashboard", Show: 1, IsEngine: 0, IsLink: 0, Status: 1},
-		{Id: 2, Name: "主页", GuardName: "admin", Icon: "", Type: 2, Pid: 1, Sort: 0, Path: "/api/admin/dashboard/index/index", Show: 1, IsEngine: 1, IsLink: 0, Status: 1},
-		{Id: 3, Name: "管理员", GuardName: "admin", Icon: "icon-admin", Type: 1, Pid: 0, Sort: 100, Path: "/admin", Show: 1, IsEngine: 0, IsLink: 0, Status: 1},
-		{Id: 4, Name: "管理员列表", GuardName: "admin", Icon: "", Type: 2, Pid: 3, Sort: 0, Path: "/api/admin/admin/index", Show: 1, IsEngine: 1, IsLink: 0, Status: 1},
-		{Id: 5, Name: "权限列表", GuardName: "admin", Icon: "", Type: 2, Pid: 3, Sort: 0, Path: "/api/admin/permission/index", Show: 1, IsEngine: 1, IsLink: 0, Status: 1},
-		{Id: 6, Name: "角色列表", GuardName: "admin", Icon: "", Type: 2, Pid: 3, Sort: 0, Path: "/api/admin/role/index", Show: 1, IsEngine: 1, IsLink: 0, Status: 1},
-		{Id: 7, Name: "系统配置", GuardName: "admin", Icon: "icon-setting", Type: 1, Pid: 0, Sort: 100, Path: "/system", Show: 1, IsEngine: 0, IsLink: 0, Status: 1},
-		{Id: 8, Name: "设置管理", GuardName: "admin", Icon: "", Type: 1, Pid: 7, Sort: 0, Path: "/system/config", Show: 1, IsEngine: 0, IsLink: 0, Status: 1},
-		{Id: 9, Name: "网站设置", GuardName: "admin", Icon: "", Type: 2, Pid: 8, Sort: 0, Path: "/api/admin/webConfig/setting/form", Show: 1, IsEngine: 1, IsLink: 0, Status: 1},
-		{Id: 10, Name: "配置管理", GuardName: "admin", Icon: "", Type: 2, Pid: 8, Sort: 0, Path: "/api/admin/config/index", Show: 1, IsEngine: 1, IsLink: 0, Status: 1},
-		{Id: 11, Name: "菜单管理", GuardName: "admin", Icon: "", Type: 2, Pid: 7, Sort: 0, Path: "/api/admin/menu/index", Show: 1, IsEngine: 1, IsLink: 0, Status: 1},
-		{Id: 12, Name: "操作日志", GuardName: "admin", Icon: "", Type: 2, Pid: 7, Sort: 100, Path: "/api/admin/actionLog/index", Show: 1, IsEngine: 1, IsLink: 0, Status: 1},
-		{Id: 13, Name: "附件空间", GuardName: "admin", Icon: "icon-attachment", Type: 1, Pid: 0, Sort: 100, Path: "/attachment", Show: 1, IsEngine: 0, IsLink: 0, Status: 1},
-		{Id: 14, Name: "文件管理", GuardName: "admin", Icon: "", Type: 2, Pid: 13, Sort: 0, Path: "/api/admin/file/index", Show: 1, IsEngine: 1, IsLink: 0, Status: 1},
-		{Id: 15, Name: "图片管理", GuardName: "admin", Icon: "", Type: 2, Pid: 13, Sort: 0, Path: "/api/admin/picture/index", Show: 1, IsEngine: 1, IsLink: 0, Status: 1},
-		{Id: 16, Name: "我的账号", GuardName: "admin", Icon: "icon-user", Type: 1, Pid: 0, Sort: 100, Path: "/account", Show: 1, IsEngine: 0, IsLink: 0, Status: 1},
-		{Id: 17, Name: "个人设置", GuardName: "admin", Icon: "", Type: 2, Pid: 16, Sort: 0, Path: "/api/admin/account/setting/form", Show: 1, IsEngine: 1, IsLink: 0, Status: 1},
-	}
-
-	db.Client.Create(&seeders)
-}
-
 // 获取TreeSelect组件数据
 func (model *Menu) TreeSelect(root bool) (list []*treeselect.TreeData, Error error) {
 
@@ -171,11 +148,33 @@ func (model *Menu) FindParentTreeNode(chrildPid int) (list []*Menu) {
 	return menus
 }
 
+// 管理员菜单缓存键前缀
+const adminMenusCacheKey = "admin_menus:"
+
+// 清除管理员菜单缓存，菜单或权限变更后调用
+func (model *Menu) ClearCache() error {
+	return cache.DeletePrefix(adminMenusCacheKey)
+}
+
 // 通过管理员ID权限菜单
 func (model *Menu) GetListByAdminId(adminId int) (menuList interface{}, err error) {
+	err = cache.RememberJSON(adminMenusCacheKey+strconv.Itoa(adminId), time.Minute*10, &menuList, func() (interface{}, error) {
+		return model.findListByAdminId(adminId)
+	})
+
+	return menuList, err
+}
+
+// 从数据库查询管理员权限菜单
+func (model *Menu) findListByAdminId(adminId int) (menuList interface{}, err error) {
 	menus := []*Menu{}
 
-	if adminId == 1 {
+	isSuperAdmin, err := (&CasbinRule{}).IsSuperAdmin(adminId)
+	if err != nil {
+		return menuList, err
+	}
+
+	if isSuperAdmin {
 		db.Client.
 			Where("guard_name", "admin").
 			Where("status = ?", 1).
@@ -0,0 +1,168 @@
+package model
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/form/rule"
+	"github.com/quarkcloudio/quark-go/v2/pkg/dal/db"
+	"github.com/quarkcloudio/quark-go/v2/pkg/utils/hash"
+	"gorm.io/gorm"
+)
+
+// 历史密码
+type PasswordHistory struct {
+	Id        int       `json:"id" gorm:"autoIncrement"`
+	AdminId   int       `json:"admin_id" gorm:"index;not null"`
+	Password  string    `json:"password" gorm:"size:255;not null"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// 密码策略
+type PasswordPolicy struct {
+	MinLength   int      // 最小长度
+	CharClasses []string // 必须包含的字符类别，lower | upper | digit | symbol
+	History     int      // 不能与最近几次使用过的密码相同，0为不限制
+	MaxAge      int      // 密码有效期，单位天，0为永不过期
+}
+
+// 历史密码最少保留的数量
+const passwordHistoryKeep = 1
+
+// 未配置时的密码最小长度
+const passwordDefaultMinLength = 6
+
+// 获取密码策略，读取网站配置中的密码策略分组
+func (model *PasswordHistory) GetPolicy() *PasswordPolicy {
+	config := &Config{}
+	policy := &PasswordPolicy{}
+	policy.MinLength, _ = strconv.Atoi(config.GetValue("PASSWORD_MIN_LENGTH"))
+	if policy.MinLength <= 0 {
+		policy.MinLength = passwordDefaultMinLength
+	}
+	policy.History, _ = strconv.Atoi(config.GetValue("PASSWORD_HISTORY"))
+	policy.MaxAge, _ = strconv.Atoi(config.GetValue("PASSWORD_MAX_AGE"))
+	for _, v := range strings.Split(config.GetValue("PASSWORD_CHAR_CLASSES"), ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			policy.CharClasses = append(policy.CharClasses, v)
+		}
+	}
+
+	return policy
+}
+
+// 密码策略的文字描述
+func (p *PasswordPolicy) Description() string {
+	names := map[string]string{
+		rule.CharLower:  "小写字母",
+		rule.CharUpper:  "大写字母",
+		rule.CharDigit:  "数字",
+		rule.CharSymbol: "特殊字符",
+	}
+
+	result := "密码至少" + strconv.Itoa(p.MinLength) + "位"
+	classes := []string{}
+	for _, v := range p.CharClasses {
+		if name, ok := names[v]; ok {
+			classes = append(classes, name)
+		}
+	}
+	if len(classes) > 0 {
+		result += "，且包含" + strings.Join(classes, "、")
+	}
+
+	return result
+}
+
+// 获取密码字段的验证规则，value为关联管理员id的值，例如：{id}
+func (p *PasswordPolicy) Rules(value string) []*rule.Rule {
+	rules := []*rule.Rule{
+		rule.Password(p.MinLength, p.CharClasses, p.Description()),
+	}
+	if p.History > 0 && value != "" {
+		rules = append(rules, rule.PasswordHistory("password_histories", "admin_id", value, p.History, "不能使用最近"+strconv.Itoa(p.History)+"次使用过的密码"))
+	}
+
+	return rules
+}
+
+// 校验明文密码是否满足策略，adminId为0时不校验历史密码
+func (p *PasswordPolicy) Validate(adminId int, password string) error {
+	for _, v := range p.Rules("") {
+		if !v.CheckPassword(password) {
+			return errors.New(v.Message)
+		}
+	}
+
+	if p.History > 0 && adminId > 0 {
+		reused, err := (&PasswordHistory{}).IsReused(db.Client, adminId, password, p.History)
+		if err != nil {
+			return err
+		}
+		if reused {
+			return errors.New("不能使用最近" + strconv.Itoa(p.History) + "次使用过的密码")
+		}
+	}
+
+	return nil
+}
+
+// 判断密码是否已过期
+func (p *PasswordPolicy) IsExpired(changedAt time.Time) bool {
+	if p.MaxAge <= 0 || changedAt.IsZero() {
+		return false
+	}
+
+	return time.Now().After(changedAt.AddDate(0, 0, p.MaxAge))
+}
+
+// 判断明文密码是否与最近使用过的密码相同
+func (model *PasswordHistory) IsReused(tx *gorm.DB, adminId int, password string, limit int) (bool, error) {
+	hashes := []string{}
+	err := tx.
+		Model(&PasswordHistory{}).
+		Where("admin_id = ?", adminId).
+		Order("id desc").
+		Limit(limit).
+		Pluck("password", &hashes).Error
+	if err != nil {
+		return false, err
+	}
+
+	for _, v := range hashes {
+		if hash.Check(v, password) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// 记录历史密码，并清理超出策略数量的记录，password为哈希后的密码
+func (model *PasswordHistory) Add(tx *gorm.DB, adminId int, password string) error {
+	err := tx.Create(&PasswordHistory{AdminId: adminId, Password: password}).Error
+	if err != nil {
+		return err
+	}
+
+	keep := model.GetPolicy().History
+	if keep < passwordHistoryKeep {
+		keep = passwordHistoryKeep
+	}
+
+	ids := []int{}
+	err = tx.
+		Model(&PasswordHistory{}).
+		Where("admin_id = ?", adminId).
+		Order("id desc").
+		Offset(keep).
+		Limit(1000).
+		Pluck("id", &ids).Error
+	if err != nil || len(ids) == 0 {
+		return err
+	}
+
+	return tx.Where("id IN ?", ids).Delete(&PasswordHistory{}).Error
+}
@@ -84,6 +84,13 @@ func (model *Picture) DeleteById(id interface{}) error {
 	return db.Client.Model(Picture{}).Where("id =?", id).Delete("").Error
 }
 
+// 统计引用同一存储路径的图片数量
+func (model *Picture) CountByPath(path string) (count int64, Error error) {
+	err := db.Client.Model(&Picture{}).Where("path = ?", path).Count(&count).Error
+
+	return count, err
+}
+
 // 根据id查询文件信息
 func (model *Picture) GetInfoById(id interface{}) (picture *Picture, Error error) {
 	err := db.Client.Where("status = ?", 1).Where("id = ?", id).First(&picture).Error
@@ -105,8 +112,14 @@ func (model *Picture) GetInfoByHash(hash string) (picture *Picture, Error error)
 	return picture, err
 }
 
-// 获取图片路径
-func (model *Picture) GetPath(id interface{}) string {
+// 获取图片路径，传入variant时返回对应的衍生版本，例如thumbnail、medium、webp，不存在时返回原图
+func (model *Picture) GetPath(id interface{}, variant ...string) string {
+	if len(variant) > 0 && variant[0] != "" {
+		if path := model.getVariantPath(id, variant[0]); path != "" {
+			return path
+		}
+	}
+
 	http, path := "", ""
 	webSiteDomain := (&Config{}).GetValue("WEB_SITE_DOMAIN")
 	WebConfig := (&Config{}).GetValue("SSL_OPEN")
@@ -123,10 +136,10 @@ func (model *Picture) GetPath(id interface{}) string {
 			return getId
 		}
 		if strings.Contains(getId, "./") && !strings.Contains(getId, "{") {
-			return http + webSiteDomain + strings.Replace(getId, "./web/app/", "/", -1)
+			return http + webSiteDomain + routePath(strings.Replace(getId, "./web/app/", "/", -1))
 		}
 		if strings.Contains(getId, "/") && !strings.Contains(getId, "{") {
-			return http + webSiteDomain + getId
+			return http + webSiteDomain + routePath(getId)
 		}
 
 		// json字符串
@@ -150,7 +163,7 @@ func (model *Picture) GetPath(id interface{}) string {
 		}
 		if path != "" {
 			// 如果设置域名，则加上域名前缀
-			return http + webSiteDomain + path
+			return http + webSiteDomain + routePath(path)
 		}
 	}
 
@@ -167,10 +180,63 @@ func (model *Picture) GetPath(id interface{}) string {
 	}
 	if path != "" {
 		// 如果设置域名，则加上域名前缀
-		return http + webSiteDomain + path
+		return http + webSiteDomain + routePath(path)
+	}
+
+	return http + webSiteDomain + routePath("/admin/default.png")
+}
+
+// 获取衍生版本路径
+func (model *Picture) getVariantPath(id interface{}, name string) string {
+	pictureId := 0
+	switch value := id.(type) {
+	case int:
+		pictureId = value
+	case float64:
+		pictureId = int(value)
+	case string:
+		if getId, err := strconv.Atoi(value); err == nil {
+			pictureId = getId
+			break
+		}
+
+		// json字符串
+		if strings.Contains(value, "{") {
+			var jsonData interface{}
+			json.Unmarshal([]byte(value), &jsonData)
+			if arrayData, ok := jsonData.([]interface{}); ok && len(arrayData) > 0 {
+				jsonData = arrayData[0]
+			}
+			if mapData, ok := jsonData.(map[string]interface{}); ok {
+				if getId, ok := mapData["id"].(float64); ok {
+					pictureId = int(getId)
+				}
+			}
+		}
+	}
+	if pictureId == 0 {
+		return ""
+	}
+
+	variant, err := (&PictureVariant{}).GetInfo(pictureId, name)
+	if err != nil || variant.Id == 0 {
+		return ""
+	}
+	if strings.Contains(variant.Url, "//") {
+		return variant.Url
+	}
+
+	http := ""
+	webSiteDomain := (&Config{}).GetValue("WEB_SITE_DOMAIN")
+	if webSiteDomain != "" {
+		if (&Config{}).GetValue("SSL_OPEN") == "1" {
+			http = "https://"
+		} else {
+			http = "http://"
+		}
 	}
 
-	return http + webSiteDomain + "/admin/default.png"
+	return http + webSiteDomain + routePath(strings.Replace(variant.Url, "./web/app/", "/", -1))
 }
 
 // 获取多图片路径
@@ -202,7 +268,7 @@ func (model *Picture) GetPaths(id interface{}) []string {
 							path = strings.Replace(path, "./web/app/", "/", -1)
 						}
 						if path != "" {
-							path = http + webSiteDomain + path
+							path = http + webSiteDomain + routePath(path)
 						}
 						paths = append(paths, path)
 					}
@@ -0,0 +1,65 @@
+package model
+
+import (
+	"time"
+
+	"github.com/quarkcloudio/quark-go/v2/pkg/dal/db"
+)
+
+// 图片衍生版本
+type PictureVariant struct {
+	Id        int       `json:"id" gorm:"autoIncrement"`
+	PictureId int       `json:"picture_id" gorm:"size:11;index;not null"`
+	Name      string    `json:"name" gorm:"size:50;not null"` // 版本名称，例如thumbnail、medium、webp
+	Width     int       `json:"width" gorm:"size:11;default:0"`
+	Height    int       `json:"height" gorm:"size:11;default:0"`
+	Size      int64     `json:"size" gorm:"size:20;default:0"`
+	Ext       string    `json:"ext" gorm:"size:255"`
+	Path      string    `json:"path" gorm:"size:255;not null"`
+	Url       string    `json:"url" gorm:"size:255;not null"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// 保存衍生版本，同名版本存在时更新
+func (model *PictureVariant) Save(variant *PictureVariant) error {
+	exist := &PictureVariant{}
+	db.Client.
+		Where("picture_id = ?", variant.PictureId).
+		Where("name = ?", variant.Name).
+		First(exist)
+	if exist.Id != 0 {
+		variant.Id = exist.Id
+	}
+
+	return db.Client.Save(variant).Error
+}
+
+// 获取图片的全部衍生版本
+func (model *PictureVariant) GetListByPictureId(pictureId interface{}) (variants []*PictureVariant, Error error) {
+	err := db.Client.Where("picture_id = ?", pictureId).Find(&variants).Error
+
+	return variants, err
+}
+
+// 获取图片指定的衍生版本
+func (model *PictureVariant) GetInfo(pictureId interface{}, name string) (variant *PictureVariant, Error error) {
+	err := db.Client.
+		Where("picture_id = ?", pictureId).
+		Where("name = ?", name).
+		First(&variant).Error
+
+	return variant, err
+}
+
+// 删除图片的全部衍生版本
+func (model *PictureVariant) DeleteByPictureId(pictureId interface{}) error {
+	return db.Client.Where("picture_id = ?", pictureId).Delete(&PictureVariant{}).Error
+}
+
+// 统计引用同一存储路径的衍生版本数量
+func (model *PictureVariant) CountByPath(path string) (count int64, Error error) {
+	err := db.Client.Model(&PictureVariant{}).Where("path = ?", path).Count(&count).Error
+
+	return count, err
+}
@@ -0,0 +1,131 @@
+package model
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/quarkcloudio/quark-go/v2/pkg/cache"
+	"github.com/quarkcloudio/quark-go/v2/pkg/dal/db"
+	"gorm.io/gorm"
+)
+
+// 已吊销的令牌
+type RevokedToken struct {
+	Id        int       `json:"id" gorm:"autoIncrement"`
+	Jti       string    `json:"jti" gorm:"size:64;index"`      // 令牌ID，为空时表示吊销Subject在此之前签发的全部令牌
+	Subject   string    `json:"subject" gorm:"size:100;index"` // 令牌所属用户，例如admin|1
+	RevokedAt int64     `json:"revoked_at"`                    // 吊销时间，纳秒级时间戳，早于此时间签发的令牌均已吊销
+	ExpiresAt time.Time `json:"expires_at"`                    // 吊销记录过期时间，过期后可清理
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// 吊销状态缓存时间
+const revokedTokenCacheTTL = time.Minute
+
+// 吊销指定令牌
+func (model *RevokedToken) Revoke(jti string, subject string, expiresAt time.Time) error {
+	if jti == "" {
+		return nil
+	}
+
+	err := db.Client.Create(&RevokedToken{
+		Jti:       jti,
+		Subject:   subject,
+		ExpiresAt: expiresAt,
+	}).Error
+	if err != nil {
+		return err
+	}
+
+	ttl := time.Until(expiresAt)
+	if ttl <= 0 {
+		return nil
+	}
+
+	return cache.Set("revoked_token:"+jti, "1", ttl)
+}
+
+// 使用一次性令牌并吊销，并发请求同一令牌时仅有一个返回true
+func (model *RevokedToken) Consume(jti string, subject string, expiresAt time.Time) (bool, error) {
+	ttl := time.Until(expiresAt)
+	if jti == "" || ttl <= 0 {
+		return false, nil
+	}
+
+	// 原子计数占用令牌，计数不为1时已被其他请求使用
+	count, err := cache.Incr("consumed_token:"+jti, ttl)
+	if err != nil {
+		return false, err
+	}
+	if count != 1 {
+		return false, nil
+	}
+
+	return true, model.Revoke(jti, subject, expiresAt)
+}
+
+// 吊销用户当前已签发的全部令牌，用于禁用账号、修改密码后强制下线，tx为当前请求的事务，提交后清除缓存
+func (model *RevokedToken) RevokeSubject(tx *gorm.DB, subject string, expiresAt time.Time) error {
+	err := tx.Session(&gorm.Session{NewDB: true}).Create(&RevokedToken{
+		Subject:   subject,
+		RevokedAt: time.Now().UnixNano(),
+		ExpiresAt: expiresAt,
+	}).Error
+	if err != nil {
+		return err
+	}
+
+	db.AfterCommit(tx, func() {
+		cache.Delete("revoked_subject:" + subject)
+	})
+
+	return nil
+}
+
+// 判断令牌是否已被吊销
+func (model *RevokedToken) IsRevoked(jti string, subject string, issuedAt time.Time) (bool, error) {
+	if jti != "" {
+		value, err := cache.Remember("revoked_token:"+jti, revokedTokenCacheTTL, func() (string, error) {
+			var count int64
+			err := db.Client.Model(&RevokedToken{}).Where("jti = ?", jti).Count(&count).Error
+			if count > 0 {
+				return "1", err
+			}
+
+			return "0", err
+		})
+		if err != nil {
+			return false, err
+		}
+		if value == "1" {
+			return true, nil
+		}
+	}
+
+	value, err := cache.Remember("revoked_subject:"+subject, revokedTokenCacheTTL, func() (string, error) {
+		revokedToken := RevokedToken{}
+		err := db.Client.
+			Where("subject = ?", subject).
+			Where("jti = ?", "").
+			Order("id desc").
+			Limit(1).
+			Find(&revokedToken).Error
+		if revokedToken.Id == 0 {
+			return "0", err
+		}
+
+		return strconv.FormatInt(revokedToken.RevokedAt, 10), err
+	})
+	if err != nil {
+		return false, err
+	}
+
+	revokedAt, _ := strconv.ParseInt(value, 10, 64)
+
+	return issuedAt.UnixNano() < revokedAt, nil
+}
+
+// 清理已过期的吊销记录
+func (model *RevokedToken) ClearExpired() error {
+	return db.Client.Where("expires_at < ?", time.Now()).Delete(&RevokedToken{}).Error
+}
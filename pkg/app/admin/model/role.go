@@ -1,19 +1,70 @@
 package model
 
 import (
+	"strconv"
 	"time"
 
 	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/form/fields/checkbox"
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/form/fields/radio"
+	"github.com/quarkcloudio/quark-go/v2/pkg/cache"
 	"github.com/quarkcloudio/quark-go/v2/pkg/dal/db"
 )
 
 // 角色
 type Role struct {
-	Id        int       `json:"id" gorm:"autoIncrement"`
-	Name      string    `json:"name" gorm:"size:255;not null"`
-	GuardName string    `json:"guard_name" gorm:"size:100;not null"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	Id          int       `json:"id" gorm:"autoIncrement"`
+	Name        string    `json:"name" gorm:"size:255;not null"`
+	GuardName   string    `json:"guard_name" gorm:"size:100;not null"`
+	DataScope   int       `json:"data_scope" gorm:"size:1;not null;default:1"`
+	RequireTotp int       `json:"require_totp" gorm:"size:1;not null;default:0"` // 是否强制开启两步验证
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// 数据权限范围
+const (
+	DataScopeAll                   = 1 // 全部数据
+	DataScopeDepartment            = 2 // 本部门数据
+	DataScopeSelf                  = 3 // 仅本人数据
+	DataScopeDepartmentAndChildren = 4 // 本部门及以下数据
+)
+
+// 数据权限范围从大到小的顺序
+var dataScopeRanks = map[int]int{
+	DataScopeAll:                   0,
+	DataScopeDepartmentAndChildren: 1,
+	DataScopeDepartment:            2,
+	DataScopeSelf:                  3,
+}
+
+// 超级管理员角色名称，拥有该角色的管理员不受权限限制，可在应用启动前修改
+var SuperAdminRoleName = "超级管理员"
+
+// 超级管理员角色id缓存键
+const superAdminRoleCacheKey = "super_admin_role_id"
+
+// 获取超级管理员角色id，不存在时返回0
+func (model *Role) GetSuperAdminRoleId() (roleId int, Error error) {
+	value, err := cache.Remember(superAdminRoleCacheKey, time.Minute*10, func() (string, error) {
+		role := Role{}
+		err := db.Client.
+			Where("name = ?", SuperAdminRoleName).
+			Where("guard_name = ?", "admin").
+			Limit(1).
+			Find(&role).Error
+
+		return strconv.Itoa(role.Id), err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return strconv.Atoi(value)
+}
+
+// 清除角色缓存，角色变更后调用
+func (model *Role) ClearCache() error {
+	return cache.Delete(superAdminRoleCacheKey)
 }
 
 // 获取角色列表
@@ -40,3 +91,39 @@ func (model *Role) GetListByIds(ids interface{}) (roles []*Role, Error error) {
 
 	return roles, err
 }
+
+// 数据权限范围选项
+func (model *Role) DataScopeOptions() []*radio.Option {
+	return []*radio.Option{
+		{Value: DataScopeAll, Label: "全部数据"},
+		{Value: DataScopeDepartmentAndChildren, Label: "本部门及以下数据"},
+		{Value: DataScopeDepartment, Label: "本部门数据"},
+		{Value: DataScopeSelf, Label: "仅本人数据"},
+	}
+}
+
+// 获取管理员的数据权限范围，拥有多个角色时取最大的范围，超级管理员拥有全部数据权限，未分配角色时仅能查看本人数据
+func (model *Role) GetDataScopeByAdminId(adminId int) (dataScope int, Error error) {
+	isSuperAdmin, err := (&CasbinRule{}).IsSuperAdmin(adminId)
+	if err != nil {
+		return DataScopeSelf, err
+	}
+	if isSuperAdmin {
+		return DataScopeAll, nil
+	}
+
+	roles, err := (&CasbinRule{}).GetUserRoles(adminId)
+	if err != nil {
+		return DataScopeSelf, err
+	}
+
+	dataScope = DataScopeSelf
+	for _, v := range roles {
+		rank, ok := dataScopeRanks[v.DataScope]
+		if ok && rank < dataScopeRanks[dataScope] {
+			dataScope = v.DataScope
+		}
+	}
+
+	return dataScope, nil
+}
@@ -55,7 +55,7 @@ func (p *BatchDeleteAction) GetApiParams() []string {
 func (p *BatchDeleteAction) Handle(ctx *builder.Context, query *gorm.DB) error {
 	err := query.Delete("").Error
 	if err != nil {
-		return ctx.JSON(200, message.Error(err.Error()))
+		return err
 	}
 
 	return ctx.JSON(200, message.Success("操作成功"))
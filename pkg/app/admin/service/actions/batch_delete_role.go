@@ -1,6 +1,7 @@
 package actions
 
 import (
+	"errors"
 	"strconv"
 	"strings"
 
@@ -55,12 +56,12 @@ func (p *BatchDeleteRoleAction) GetApiParams() []string {
 func (p *BatchDeleteRoleAction) Handle(ctx *builder.Context, query *gorm.DB) error {
 	id := ctx.Query("id")
 	if id == "" {
-		return ctx.JSON(200, message.Error("参数错误！"))
+		return errors.New("参数错误！")
 	}
 
 	err := query.Delete("").Error
 	if err != nil {
-		return ctx.JSON(200, message.Error(err.Error()))
+		return err
 	}
 
 	ids := strings.Split(id.(string), ",")
@@ -68,20 +69,26 @@ func (p *BatchDeleteRoleAction) Handle(ctx *builder.Context, query *gorm.DB) err
 		for _, v := range ids {
 			idInt, err := strconv.Atoi(v)
 			if err != nil {
-				return ctx.JSON(200, message.Error(err.Error()))
+				return err
 			}
 
 			// 清理casbin里的角色
-			(&model.CasbinRule{}).RemoveRoleMenuAndPermissions(idInt)
+			err = (&model.CasbinRule{}).RemoveRoleMenuAndPermissions(query, idInt)
+			if err != nil {
+				return err
+			}
 		}
 	} else {
 		idInt, err := strconv.Atoi(id.(string))
 		if err != nil {
-			return ctx.JSON(200, message.Error(err.Error()))
+			return err
 		}
 
 		// 清理casbin里的角色
-		(&model.CasbinRule{}).RemoveRoleMenuAndPermissions(idInt)
+		err = (&model.CasbinRule{}).RemoveRoleMenuAndPermissions(query, idInt)
+		if err != nil {
+			return err
+		}
 	}
 
 	return ctx.JSON(200, message.Success("操作成功"))
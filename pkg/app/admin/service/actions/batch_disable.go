@@ -55,7 +55,7 @@ func (p *BatchDisableAction) GetApiParams() []string {
 func (p *BatchDisableAction) Handle(ctx *builder.Context, query *gorm.DB) error {
 	err := query.Update("status", 0).Error
 	if err != nil {
-		return ctx.JSON(200, message.Error(err.Error()))
+		return err
 	}
 
 	return ctx.JSON(200, message.Success("操作成功"))
@@ -55,7 +55,7 @@ func (p *BatchEnableAction) GetApiParams() []string {
 func (p *BatchEnableAction) Handle(ctx *builder.Context, model *gorm.DB) error {
 	err := model.Update("status", 1).Error
 	if err != nil {
-		return ctx.JSON(200, message.Error(err.Error()))
+		return err
 	}
 
 	return ctx.JSON(200, message.Success("操作成功"))
@@ -2,6 +2,7 @@ package actions
 
 import (
 	"encoding/json"
+	"time"
 
 	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/message"
 	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/model"
@@ -29,20 +30,52 @@ func (p *ChangeAccountAction) Handle(ctx *builder.Context, query *gorm.DB) error
 		data["avatar"], _ = json.Marshal(data["avatar"])
 	}
 
-	// 加密密码
-	if data["password"] != nil {
-		data["password"] = hash.Make(data["password"].(string))
-	}
+	// 两步验证及密码状态信息只能通过对应的行为修改
+	delete(data, "totp_secret")
+	delete(data, "totp_enabled")
+	delete(data, "totp_recovery")
+	delete(data, "must_change_pwd")
+	delete(data, "pwd_changed_at")
 
 	// 获取登录管理员信息
 	adminInfo, err := (&model.Admin{}).GetAuthUser(ctx.Engine.GetConfig().AppKey, ctx.Token())
 	if err != nil {
-		return ctx.JSON(200, message.Error(err.Error()))
+		return err
+	}
+
+	// 校验密码策略并加密密码
+	password, _ := data["password"].(string)
+	if password != "" {
+		err = (&model.PasswordHistory{}).GetPolicy().Validate(adminInfo.Id, password)
+		if err != nil {
+			return err
+		}
+		data["password"] = hash.Make(password)
+		data["must_change_pwd"] = 0
+		data["pwd_changed_at"] = time.Now()
+	} else {
+		delete(data, "password")
 	}
 
 	err = query.Where("id", adminInfo.Id).Updates(data).Error
 	if err != nil {
-		return ctx.JSON(200, message.Error(err.Error()))
+		return err
+	}
+
+	// 修改密码后记录历史密码，并吊销已签发的令牌，需重新登录
+	if data["password"] != nil {
+		err = (&model.PasswordHistory{}).Add(query.Session(&gorm.Session{NewDB: true}), adminInfo.Id, data["password"].(string))
+		if err != nil {
+			return err
+		}
+		(&model.Admin{}).ClearPasswordCache(adminInfo.Id)
+
+		err = (&model.Admin{}).RevokeTokens(query, adminInfo.Id)
+		if err != nil {
+			return err
+		}
+
+		return ctx.JSON(200, message.Success("密码已修改，请重新登录", "/"))
 	}
 
 	return ctx.JSON(200, message.Success("操作成功"))
@@ -1,6 +1,8 @@
 package actions
 
 import (
+	"errors"
+
 	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/message"
 	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/template/resource/actions"
 	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
@@ -57,7 +59,7 @@ func (p *ChangeStatusAction) GetApiParams() []string {
 func (p *ChangeStatusAction) Handle(ctx *builder.Context, query *gorm.DB) error {
 	status := ctx.Query("status")
 	if status == "" {
-		return ctx.JSON(200, message.Error("参数错误！"))
+		return errors.New("参数错误！")
 	}
 
 	var fieldStatus int
@@ -69,7 +71,7 @@ func (p *ChangeStatusAction) Handle(ctx *builder.Context, query *gorm.DB) error
 
 	err := query.Update("status", fieldStatus).Error
 	if err != nil {
-		return ctx.JSON(200, message.Error(err.Error()))
+		return err
 	}
 
 	return ctx.JSON(200, message.Success("操作成功"))
@@ -2,6 +2,7 @@ package actions
 
 import (
 	"encoding/json"
+	"errors"
 
 	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/message"
 	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/model"
@@ -45,7 +46,7 @@ func (p *ChangeWebConfigAction) Handle(ctx *builder.Context, query *gorm.DB) err
 	}
 
 	if !result {
-		return ctx.JSON(200, message.Error("操作失败，请重试！"))
+		return errors.New("操作失败，请重试！")
 	}
 
 	// 刷新网站配置
@@ -53,7 +53,7 @@ func (p *DeleteAction) Init(ctx *builder.Context) interface{} {
 func (p *DeleteAction) Handle(ctx *builder.Context, query *gorm.DB) error {
 	err := query.Delete("").Error
 	if err != nil {
-		return ctx.JSON(200, message.Error(err.Error()))
+		return err
 	}
 
 	return ctx.JSON(200, message.Success("操作成功"))
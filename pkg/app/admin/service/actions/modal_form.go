@@ -1,8 +1,9 @@
 package actions
 
 import (
+	"errors"
+
 	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/form/rule"
-	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/message"
 	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/template/resource"
 	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/template/resource/actions"
 	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
@@ -67,5 +68,5 @@ func (p *ModalFormAction) Data(ctx *builder.Context) map[string]interface{} {
 // 执行行为句柄
 func (p *ModalFormAction) Handle(ctx *builder.Context, query *gorm.DB) error {
 
-	return ctx.JSON(200, message.Error("Method not implemented"))
+	return errors.New("Method not implemented")
 }
@@ -0,0 +1,78 @@
+package actions
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/message"
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/model"
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/template/resource/actions"
+	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
+	"gorm.io/gorm"
+)
+
+type ResetTotpAction struct {
+	actions.Action
+}
+
+// 重置两步验证，管理员丢失验证设备时使用，ResetTotp() | ResetTotp("重置两步验证")
+func ResetTotp(options ...interface{}) *ResetTotpAction {
+	action := &ResetTotpAction{}
+
+	// 文字
+	action.Name = "重置两步验证"
+	if len(options) == 1 {
+		action.Name = options[0].(string)
+	}
+
+	return action
+}
+
+// 初始化
+func (p *ResetTotpAction) Init(ctx *builder.Context) interface{} {
+
+	// 设置按钮类型,primary | ghost | dashed | link | text | default
+	p.Type = "link"
+
+	// 设置按钮大小,large | middle | small | default
+	p.Size = "small"
+
+	//  执行成功后刷新的组件
+	p.Reload = "table"
+
+	// 当行为在表格行展示时，支持js表达式
+	p.WithConfirm("确定要重置两步验证吗？", "重置后该管理员需重新绑定验证器", "modal")
+
+	// 设置展示位置
+	p.SetOnlyOnIndexTableRow(true)
+
+	return p
+}
+
+// 行为接口接收的参数，当行为在表格行展示的时候，可以配置当前行的任意字段
+func (p *ResetTotpAction) GetApiParams() []string {
+	return []string{
+		"id",
+	}
+}
+
+// 执行行为句柄
+func (p *ResetTotpAction) Handle(ctx *builder.Context, query *gorm.DB) error {
+	err := query.Updates(map[string]interface{}{
+		"totp_secret":   "",
+		"totp_enabled":  0,
+		"totp_recovery": "",
+	}).Error
+	if err != nil {
+		return err
+	}
+
+	id, _ := ctx.Query("id", "").(string)
+	for _, v := range strings.Split(id, ",") {
+		if adminId, err := strconv.Atoi(v); err == nil {
+			(&model.Admin{}).ClearTotpCache(adminId)
+		}
+	}
+
+	return ctx.JSON(200, message.Success("操作成功"))
+}
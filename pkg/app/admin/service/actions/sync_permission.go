@@ -1,11 +1,14 @@
 package actions
 
 import (
+	"errors"
+	"reflect"
 	"strings"
 
 	"github.com/gobeam/stringy"
 	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/message"
 	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/model"
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/template/resource"
 	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/template/resource/actions"
 	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
 	"github.com/quarkcloudio/quark-go/v2/pkg/dal/db"
@@ -55,54 +58,86 @@ func (p *SyncPermissionAction) Handle(ctx *builder.Context, query *gorm.DB) erro
 	var names []string
 	var currentNames []string
 	db.Client.Model(&model.Permission{}).Pluck("name", &names)
+
+	// 添加权限，数据库中或当前同步中已存在时跳过
+	addPermission := func(name string, path string, method string, remark string) {
+		currentNames = append(currentNames, name)
+
+		// 判断数据库中是否已存在
+		for _, nv := range names {
+			if nv == name {
+				return
+			}
+		}
+
+		// 判断当前同步中是否已存在
+		for _, pv := range data {
+			if pv.Name == name {
+				return
+			}
+		}
+
+		data = append(data, model.Permission{
+			Name:      name,
+			Method:    method,
+			Path:      path,
+			GuardName: "admin",
+			Remark:    remark,
+		})
+	}
+
 	for _, v := range permissions {
 		if strings.Contains(v.Url, "/api/admin") {
-			has := false
-			hasPermission := false
 			url := strings.ReplaceAll(v.Url, "/api/admin/", "")
 			url = strings.ReplaceAll(url, "/", "_") + "_" + strings.ToLower(v.Method)
 			name := stringy.
 				New(url).
 				CamelCase("?", "")
-			currentNames = append(currentNames, name)
+			addPermission(name, v.Url, v.Method, "")
+		}
+	}
 
-			// 判断数据库中是否已存在
-			for _, nv := range names {
-				if nv == name {
-					has = true
-				}
-			}
+	// 字段权限，例如articleFieldTitleRead
+	for _, provider := range ctx.Engine.GetProviders() {
+		fieldPermissioner, ok := provider.(interface {
+			Fields(ctx *builder.Context) []interface{}
+			FieldPermissions(resource string, fields []interface{}) []*resource.FieldPermission
+		})
+		if !ok {
+			continue
+		}
 
-			// 判断当前同步中是否已存在
-			for _, pv := range data {
-				if pv.Name == name {
-					hasPermission = true
-				}
-			}
+		providerName := reflect.TypeOf(provider).String()
+		getNames := strings.Split(providerName, ".")
+		resourceName := strings.ToLower(getNames[len(getNames)-1])
+
+		for _, v := range fieldPermissioner.FieldPermissions(resourceName, fieldPermissioner.Fields(ctx)) {
+			url := strings.ReplaceAll(v.Path, "/api/admin/", "")
+			url = strings.ReplaceAll(url, "/", "_") + "_" + strings.ToLower(v.Method)
+			name := stringy.
+				New(url).
+				CamelCase("?", "")
 
-			if !has && !hasPermission {
-				permission := model.Permission{
-					Name:      name,
-					Method:    v.Method,
-					Path:      v.Url,
-					GuardName: "admin",
-				}
-				data = append(data, permission)
+			remark := "查看字段：" + v.Label
+			if v.Method == resource.FieldWrite {
+				remark = "编辑字段：" + v.Label
 			}
+			addPermission(name, v.Path, v.Method, remark)
 		}
 	}
+
 	if len(data) == 0 {
-		return ctx.JSON(200, message.Error("暂无新增权限！"))
+		return errors.New("暂无新增权限！")
 	}
 
 	err := query.Create(data).Error
 	if err != nil {
-		return ctx.JSON(200, message.Error(err.Error()))
+		return err
 	}
 
 	err = db.Client.Model(&model.Permission{}).Where("name NOT IN ?", currentNames).Delete("").Error
 	if err != nil {
-		return ctx.JSON(200, message.Error(err.Error()))
+		return err
 	}
 
 	return ctx.JSON(200, message.Success("操作成功"))
@@ -0,0 +1,94 @@
+package actions
+
+import (
+	"errors"
+
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/form/rule"
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/message"
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/model"
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/template/resource"
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/template/resource/actions"
+	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
+	"gorm.io/gorm"
+)
+
+type TotpDisableAction struct {
+	actions.ModalForm
+}
+
+// 关闭两步验证
+func TotpDisable() *TotpDisableAction {
+	return &TotpDisableAction{}
+}
+
+// 初始化
+func (p *TotpDisableAction) Init(ctx *builder.Context) interface{} {
+
+	// 文字
+	p.Name = "关闭两步验证"
+
+	// 类型
+	p.Type = "link"
+
+	// 关闭时销毁 Modal 里的子元素
+	p.DestroyOnClose = true
+
+	// 在表单页右上角自定义区域展示
+	p.SetOnlyOnFormExtra(true)
+
+	return p
+}
+
+// 字段
+func (p *TotpDisableAction) Fields(ctx *builder.Context) []interface{} {
+	field := &resource.Field{}
+
+	return []interface{}{
+		field.Text("code", "验证码").
+			SetRules([]*rule.Rule{
+				rule.Required(true, "验证码必须填写"),
+			}).
+			SetPlaceholder("请输入验证器App中的6位验证码"),
+	}
+}
+
+// 执行行为句柄
+func (p *TotpDisableAction) Handle(ctx *builder.Context, query *gorm.DB) error {
+	data := map[string]interface{}{}
+	ctx.Bind(&data)
+	code, _ := data["code"].(string)
+
+	// 获取登录管理员信息
+	claims, err := (&model.Admin{}).GetAuthUser(ctx.Engine.GetConfig().AppKey, ctx.Token())
+	if err != nil {
+		return err
+	}
+
+	required, err := (&model.Admin{}).IsTotpRequired(claims.Id)
+	if err != nil {
+		return err
+	}
+	if required {
+		return errors.New("当前账号要求开启两步验证，无法关闭")
+	}
+
+	adminInfo, err := (&model.Admin{}).GetInfoById(claims.Id)
+	if err != nil {
+		return err
+	}
+	if !(&model.Admin{}).CheckTotpCode(adminInfo, code) {
+		return errors.New("验证码错误")
+	}
+
+	err = query.Where("id", adminInfo.Id).Updates(map[string]interface{}{
+		"totp_secret":   "",
+		"totp_enabled":  0,
+		"totp_recovery": "",
+	}).Error
+	if err != nil {
+		return err
+	}
+	(&model.Admin{}).ClearTotpCache(adminInfo.Id)
+
+	return ctx.JSON(200, message.Success("两步验证已关闭"))
+}
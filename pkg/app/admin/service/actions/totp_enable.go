@@ -0,0 +1,120 @@
+package actions
+
+import (
+	"strings"
+
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/form/rule"
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/message"
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/model"
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/template/resource"
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/template/resource/actions"
+	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
+	"github.com/quarkcloudio/quark-go/v2/pkg/utils/qrcode"
+	"gorm.io/gorm"
+)
+
+type TotpEnableAction struct {
+	actions.ModalForm
+}
+
+// 开启两步验证
+func TotpEnable() *TotpEnableAction {
+	return &TotpEnableAction{}
+}
+
+// 初始化
+func (p *TotpEnableAction) Init(ctx *builder.Context) interface{} {
+
+	// 文字
+	p.Name = "开启两步验证"
+
+	// 类型
+	p.Type = "link"
+
+	// 关闭时销毁 Modal 里的子元素
+	p.DestroyOnClose = true
+
+	// 在表单页右上角自定义区域展示
+	p.SetOnlyOnFormExtra(true)
+
+	return p
+}
+
+// 字段
+func (p *TotpEnableAction) Fields(ctx *builder.Context) []interface{} {
+	field := &resource.Field{}
+
+	adminInfo, err := (&model.Admin{}).GetAuthUser(ctx.Engine.GetConfig().AppKey, ctx.Token())
+	if err != nil {
+		return []interface{}{}
+	}
+
+	// 绑定中的密钥
+	secret, err := (&model.Admin{}).GetTotpEnrollSecret(adminInfo.Id)
+	if err != nil {
+		return []interface{}{
+			field.Display("提示").SetValue(err.Error()),
+		}
+	}
+
+	// 二维码
+	image, err := qrcode.DataURI((&model.Admin{}).GetTotpURL(adminInfo.Username, secret), 200)
+	if err != nil {
+		return []interface{}{
+			field.Display("提示").SetValue(err.Error()),
+		}
+	}
+
+	return []interface{}{
+		field.Display("二维码").SetValue("<img src='" + image + "' width='200' height='200' />"),
+
+		field.Display("密钥").SetValue(secret),
+
+		field.Text("code", "验证码").
+			SetRules([]*rule.Rule{
+				rule.Required(true, "验证码必须填写"),
+			}).
+			SetPlaceholder("请输入验证器App中的6位验证码"),
+	}
+}
+
+// 执行行为句柄
+func (p *TotpEnableAction) Handle(ctx *builder.Context, query *gorm.DB) error {
+	data := map[string]interface{}{}
+	ctx.Bind(&data)
+	code, _ := data["code"].(string)
+
+	// 获取登录管理员信息
+	adminInfo, err := (&model.Admin{}).GetAuthUser(ctx.Engine.GetConfig().AppKey, ctx.Token())
+	if err != nil {
+		return err
+	}
+
+	secret, err := (&model.Admin{}).CheckTotpEnroll(adminInfo.Id, code)
+	if err != nil {
+		return err
+	}
+
+	recoveryCodes, hashedCodes, err := (&model.Admin{}).MakeTotpRecovery()
+	if err != nil {
+		return err
+	}
+
+	err = query.Where("id", adminInfo.Id).Updates(map[string]interface{}{
+		"totp_secret":   secret,
+		"totp_enabled":  1,
+		"totp_recovery": hashedCodes,
+	}).Error
+	if err != nil {
+		return err
+	}
+	(&model.Admin{}).ClearTotpCache(adminInfo.Id)
+
+	return ctx.JSON(200, message.Success(
+		"两步验证已开启，请妥善保存恢复码："+strings.Join(recoveryCodes, " "),
+		"",
+		map[string]interface{}{
+			"recoveryCodes": recoveryCodes,
+		},
+	))
+}
@@ -0,0 +1,95 @@
+package actions
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/form/rule"
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/message"
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/model"
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/template/resource"
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/template/resource/actions"
+	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
+	"gorm.io/gorm"
+)
+
+type TotpRecoveryAction struct {
+	actions.ModalForm
+}
+
+// 重新生成两步验证恢复码
+func TotpRecovery() *TotpRecoveryAction {
+	return &TotpRecoveryAction{}
+}
+
+// 初始化
+func (p *TotpRecoveryAction) Init(ctx *builder.Context) interface{} {
+
+	// 文字
+	p.Name = "重新生成恢复码"
+
+	// 类型
+	p.Type = "link"
+
+	// 关闭时销毁 Modal 里的子元素
+	p.DestroyOnClose = true
+
+	// 在表单页右上角自定义区域展示
+	p.SetOnlyOnFormExtra(true)
+
+	return p
+}
+
+// 字段
+func (p *TotpRecoveryAction) Fields(ctx *builder.Context) []interface{} {
+	field := &resource.Field{}
+
+	return []interface{}{
+		field.Display("提示").SetValue("重新生成后，旧的恢复码将全部失效"),
+
+		field.Text("code", "验证码").
+			SetRules([]*rule.Rule{
+				rule.Required(true, "验证码必须填写"),
+			}).
+			SetPlaceholder("请输入验证器App中的6位验证码"),
+	}
+}
+
+// 执行行为句柄
+func (p *TotpRecoveryAction) Handle(ctx *builder.Context, query *gorm.DB) error {
+	data := map[string]interface{}{}
+	ctx.Bind(&data)
+	code, _ := data["code"].(string)
+
+	// 获取登录管理员信息
+	claims, err := (&model.Admin{}).GetAuthUser(ctx.Engine.GetConfig().AppKey, ctx.Token())
+	if err != nil {
+		return err
+	}
+
+	adminInfo, err := (&model.Admin{}).GetInfoById(claims.Id)
+	if err != nil {
+		return err
+	}
+	if !(&model.Admin{}).CheckTotpCode(adminInfo, code) {
+		return errors.New("验证码错误")
+	}
+
+	recoveryCodes, hashedCodes, err := (&model.Admin{}).MakeTotpRecovery()
+	if err != nil {
+		return err
+	}
+
+	err = query.Where("id", adminInfo.Id).Update("totp_recovery", hashedCodes).Error
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(200, message.Success(
+		"恢复码已重新生成，请妥善保存："+strings.Join(recoveryCodes, " "),
+		"",
+		map[string]interface{}{
+			"recoveryCodes": recoveryCodes,
+		},
+	))
+}
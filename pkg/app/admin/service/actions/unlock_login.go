@@ -0,0 +1,72 @@
+package actions
+
+import (
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/message"
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/model"
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/template/resource/actions"
+	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
+	"gorm.io/gorm"
+)
+
+type UnlockLoginAction struct {
+	actions.Action
+}
+
+// 解除登录锁定，UnlockLogin() | UnlockLogin("解除锁定")
+func UnlockLogin(options ...interface{}) *UnlockLoginAction {
+	action := &UnlockLoginAction{}
+
+	// 文字
+	action.Name = "解除锁定"
+	if len(options) == 1 {
+		action.Name = options[0].(string)
+	}
+
+	return action
+}
+
+// 初始化
+func (p *UnlockLoginAction) Init(ctx *builder.Context) interface{} {
+
+	// 设置按钮类型,primary | ghost | dashed | link | text | default
+	p.Type = "link"
+
+	// 设置按钮大小,large | middle | small | default
+	p.Size = "small"
+
+	//  执行成功后刷新的组件
+	p.Reload = "table"
+
+	// 当行为在表格行展示时，支持js表达式
+	p.WithConfirm("确定要解除登录锁定吗？", "解除后将清空该管理员的登录失败次数", "modal")
+
+	// 设置展示位置
+	p.SetOnlyOnIndexTableRow(true)
+
+	return p
+}
+
+// 行为接口接收的参数，当行为在表格行展示的时候，可以配置当前行的任意字段
+func (p *UnlockLoginAction) GetApiParams() []string {
+	return []string{
+		"id",
+	}
+}
+
+// 执行行为句柄
+func (p *UnlockLoginAction) Handle(ctx *builder.Context, query *gorm.DB) error {
+	usernames := []string{}
+	err := query.Pluck("username", &usernames).Error
+	if err != nil {
+		return err
+	}
+
+	for _, username := range usernames {
+		err = (&model.LoginAttempt{}).Unlock(username)
+		if err != nil {
+			return err
+		}
+	}
+
+	return ctx.JSON(200, message.Success("操作成功"))
+}
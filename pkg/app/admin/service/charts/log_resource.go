@@ -0,0 +1,58 @@
+package charts
+
+import (
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/chart"
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/template/resource/charts"
+	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
+	"gorm.io/gorm"
+)
+
+type LogResourceChart struct {
+	charts.Chart
+}
+
+// 图表数据
+type logResourceData struct {
+	Resource string `json:"resource"`
+	Total    int64  `json:"total"`
+}
+
+// 操作资源分布
+func LogResource() *LogResourceChart {
+	return &LogResourceChart{}
+}
+
+// 初始化
+func (p *LogResourceChart) Init(ctx *builder.Context) interface{} {
+	p.Title = "操作资源分布"
+
+	return p
+}
+
+// 图表组件
+func (p *LogResourceChart) Component(api string) interface{} {
+	return chart.NewColumn(nil).
+		SetApi(api).
+		SetAutoFit(true).
+		SetHeight(240).
+		SetXField("resource").
+		SetYField("total").
+		SetMeta(map[string]interface{}{
+			"resource": map[string]interface{}{"alias": "资源"},
+			"total":    map[string]interface{}{"alias": "操作次数"},
+		})
+}
+
+// 获取图表数据，统计操作次数最多的10个资源
+func (p *LogResourceChart) Handle(ctx *builder.Context, query *gorm.DB) (interface{}, error) {
+	data := []logResourceData{}
+	err := query.
+		Select("action_logs.resource AS resource, COUNT(*) AS total").
+		Where("action_logs.resource <> ?", "").
+		Group("action_logs.resource").
+		Order("total desc").
+		Limit(10).
+		Scan(&data).Error
+
+	return data, err
+}
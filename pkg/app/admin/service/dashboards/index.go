@@ -24,6 +24,9 @@ func (p *Index) Cards(ctx *builder.Context) []interface{} {
 		&metrics.TotalLog{},
 		&metrics.TotalPicture{},
 		&metrics.TotalFile{},
+		&metrics.LogTrend{},
+		&metrics.AdminSexPartition{},
+		&metrics.LatestLogin{},
 		&metrics.SystemInfo{},
 		&metrics.TeamInfo{},
 	}
@@ -0,0 +1,47 @@
+package filters
+
+import (
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/template/resource/filters"
+	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
+	"gorm.io/gorm"
+)
+
+type BooleanField struct {
+	filters.Boolean
+}
+
+// 布尔值
+func Boolean(column string, name string) *BooleanField {
+	field := &BooleanField{}
+
+	field.Column = column
+	field.Name = name
+
+	return field
+}
+
+// 设置选项文字
+func (p *BooleanField) SetLabels(trueLabel string, falseLabel string) *BooleanField {
+	p.TrueLabel = trueLabel
+	p.FalseLabel = falseLabel
+
+	return p
+}
+
+// 执行查询
+func (p *BooleanField) Apply(ctx *builder.Context, query *gorm.DB, value interface{}) *gorm.DB {
+	switch v := value.(type) {
+	case bool:
+		if v {
+			return query.Where(p.Column+" = ?", 1)
+		}
+		return query.Where(p.Column+" = ?", 0)
+	case string:
+		if v == "1" || v == "true" {
+			return query.Where(p.Column+" = ?", 1)
+		}
+		return query.Where(p.Column+" = ?", 0)
+	}
+
+	return query.Where(p.Column+" = ?", value)
+}
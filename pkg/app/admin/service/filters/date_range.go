@@ -0,0 +1,31 @@
+package filters
+
+import (
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/template/resource/filters"
+	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
+	"gorm.io/gorm"
+)
+
+type DateRangeField struct {
+	filters.DateRange
+}
+
+// 日期范围
+func DateRange(column string, name string) *DateRangeField {
+	field := &DateRangeField{}
+
+	field.Column = column
+	field.Name = name
+
+	return field
+}
+
+// 执行查询
+func (p *DateRangeField) Apply(ctx *builder.Context, query *gorm.DB, value interface{}) *gorm.DB {
+	values, ok := value.([]interface{})
+	if !ok || len(values) != 2 {
+		return query
+	}
+
+	return query.Where(p.Column+" BETWEEN ? AND ?", values[0], values[1])
+}
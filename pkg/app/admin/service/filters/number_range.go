@@ -0,0 +1,39 @@
+package filters
+
+import (
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/template/resource/filters"
+	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
+	"gorm.io/gorm"
+)
+
+type NumberRangeField struct {
+	filters.NumberRange
+}
+
+// 数值范围，提交的值格式为 [min, max]，可只填写其中一端
+func NumberRange(column string, name string) *NumberRangeField {
+	field := &NumberRangeField{}
+
+	field.Column = column
+	field.Name = name
+
+	return field
+}
+
+// 执行查询
+func (p *NumberRangeField) Apply(ctx *builder.Context, query *gorm.DB, value interface{}) *gorm.DB {
+	values, ok := value.([]interface{})
+	if !ok || len(values) != 2 {
+		return query
+	}
+
+	if values[0] != nil && values[0] != "" {
+		query = query.Where(p.Column+" >= ?", values[0])
+	}
+
+	if values[1] != nil && values[1] != "" {
+		query = query.Where(p.Column+" <= ?", values[1])
+	}
+
+	return query
+}
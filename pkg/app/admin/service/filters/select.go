@@ -0,0 +1,45 @@
+package filters
+
+import (
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/form/fields/selectfield"
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/template/resource/filters"
+	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
+	"gorm.io/gorm"
+)
+
+type SelectField struct {
+	filters.Select
+}
+
+// 下拉框
+func Select(column string, name string, options []*selectfield.Option) *SelectField {
+	field := &SelectField{}
+
+	field.Column = column
+	field.Name = name
+	field.SelectOptions = options
+
+	return field
+}
+
+// 多选下拉框
+func MultipleSelect(column string, name string, options []*selectfield.Option) *SelectField {
+	field := Select(column, name, options)
+	field.Multiple = true
+
+	return field
+}
+
+// 执行查询
+func (p *SelectField) Apply(ctx *builder.Context, query *gorm.DB, value interface{}) *gorm.DB {
+	if values, ok := value.([]interface{}); ok {
+		return query.Where(p.Column+" IN ?", values)
+	}
+
+	return query.Where(p.Column+" = ?", value)
+}
+
+// 属性
+func (p *SelectField) Options(ctx *builder.Context) interface{} {
+	return p.SelectOptions
+}
@@ -0,0 +1,27 @@
+package filters
+
+import (
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/template/resource/filters"
+	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
+	"gorm.io/gorm"
+)
+
+type StatusField struct {
+	filters.Boolean
+}
+
+// 状态
+func Status() *StatusField {
+	field := &StatusField{}
+	field.Column = "status"
+	field.Name = "状态"
+	field.TrueLabel = "正常"
+	field.FalseLabel = "禁用"
+
+	return field
+}
+
+// 执行查询
+func (p *StatusField) Apply(ctx *builder.Context, query *gorm.DB, value interface{}) *gorm.DB {
+	return query.Where("status = ?", value)
+}
@@ -1,6 +1,9 @@
 package logins
 
 import (
+	"encoding/json"
+	"math"
+	"strconv"
 	"time"
 
 	"github.com/dchest/captcha"
@@ -19,6 +22,9 @@ type Index struct {
 	login.Template
 }
 
+// 用户不存在时用于校验的密码哈希，使响应时间与用户存在时一致
+var dummyPassword = hash.Make("quarkgo")
+
 type Captcha struct {
 	Id    string `json:"id" form:"id"`
 	Value string `json:"value" form:"value"`
@@ -30,6 +36,11 @@ type LoginRequest struct {
 	Captcha  *Captcha `json:"captcha" form:"captcha"`
 }
 
+type VerifyRequest struct {
+	PendingToken string `json:"pendingToken" form:"pendingToken"` // 账号密码校验通过后返回的凭证
+	Code         string `json:"code" form:"code"`                 // 验证器App中的验证码或恢复码
+}
+
 // 初始化
 func (p *Index) Init(ctx *builder.Context) interface{} {
 
@@ -110,29 +121,253 @@ func (p *Index) Handle(ctx *builder.Context) error {
 		return ctx.JSON(200, message.Error("用户名或密码不能为空"))
 	}
 
+	// 登录失败次数过多时暂时锁定
+	if lockedFor := (&model.LoginAttempt{}).LockedFor(loginRequest.Username, ctx.ClientIP()); lockedFor > 0 {
+		return ctx.JSON(200, message.Error(lockedMessage(lockedFor)))
+	}
+
 	adminInfo, err := (&model.Admin{}).GetInfoByUsername(loginRequest.Username)
+	if err != nil && err != gorm.ErrRecordNotFound {
+		return ctx.JSON(200, message.Error(err.Error()))
+	}
+
+	// 检验账号和密码，用户不存在时同样校验哈希，避免通过响应时间枚举用户名
+	if err == gorm.ErrRecordNotFound {
+		hash.Check(dummyPassword, loginRequest.Password)
+		return p.loginFailed(ctx, loginRequest.Username, 0)
+	}
+	if !hash.Check(adminInfo.Password, loginRequest.Password) {
+		return p.loginFailed(ctx, loginRequest.Username, adminInfo.Id)
+	}
+
+	// 已开启两步验证，返回凭证，通过两步验证后再签发令牌
+	if adminInfo.TotpEnabled == 1 {
+		pendingToken, err := p.SavePendingToken(adminInfo.Id)
+		if err != nil {
+			return ctx.JSON(200, message.Error(err.Error()))
+		}
+
+		return ctx.JSON(200, message.Success("请输入两步验证码", "", map[string]interface{}{
+			"totpRequired": true,
+			"pendingToken": pendingToken,
+			"expiresIn":    int(login.PendingTokenTTL.Seconds()),
+		}))
+	}
+
+	return p.loginResult(ctx, adminInfo)
+}
+
+// 两步验证方法，验证码或恢复码校验通过后签发令牌
+func (p *Index) Verify(ctx *builder.Context) error {
+	verifyRequest := &VerifyRequest{}
+	if err := ctx.Bind(verifyRequest); err != nil {
+		return ctx.JSON(200, message.Error(err.Error()))
+	}
+	if verifyRequest.Code == "" {
+		return ctx.JSON(200, message.Error("验证码不能为空"))
+	}
+
+	adminId, err := p.GetPendingToken(verifyRequest.PendingToken)
+	if err != nil {
+		return ctx.JSON(200, message.Error(err.Error()))
+	}
+
+	adminInfo, err := (&model.Admin{}).GetInfoById(adminId)
 	if err != nil {
-		if err == gorm.ErrRecordNotFound {
-			return ctx.JSON(200, message.Error("用户不存在"))
+		p.DeletePendingToken(verifyRequest.PendingToken)
+		return ctx.JSON(200, message.Error("用户不存在或已被禁用"))
+	}
+
+	// 登录失败次数过多时暂时锁定
+	if lockedFor := (&model.LoginAttempt{}).LockedFor(adminInfo.Username, ctx.ClientIP()); lockedFor > 0 {
+		p.DeletePendingToken(verifyRequest.PendingToken)
+		return ctx.JSON(200, message.Error(lockedMessage(lockedFor)))
+	}
+
+	result, err := (&model.Admin{}).VerifyTotp(adminInfo, verifyRequest.Code)
+	if err != nil {
+		return ctx.JSON(200, message.Error(err.Error()))
+	}
+	if !result {
+
+		// 两步验证失败同样计入登录失败次数
+		delay, locked, err := (&model.LoginAttempt{}).Fail(adminInfo.Username, ctx.ClientIP())
+		if err != nil {
+			return ctx.JSON(200, message.Error(err.Error()))
+		}
+		if locked {
+			p.DeletePendingToken(verifyRequest.PendingToken)
+			p.lockedLog(ctx, adminInfo.Username, adminInfo.Id)
+			return ctx.JSON(200, message.Error(lockedMessage(model.LoginLockDuration)))
+		}
+		time.Sleep(delay)
+
+		remaining := p.FailPendingToken(verifyRequest.PendingToken)
+		if remaining == 0 {
+			return ctx.JSON(200, message.Error("验证码错误次数过多，请重新登录"))
 		}
+
+		return ctx.JSON(200, message.Error("验证码错误，还可尝试"+strconv.Itoa(remaining)+"次"))
+	}
+	p.DeletePendingToken(verifyRequest.PendingToken)
+
+	return p.loginResult(ctx, adminInfo)
+}
+
+// 刷新令牌方法，旧的刷新令牌使用后立即失效
+func (p *Index) Refresh(ctx *builder.Context) error {
+	data := map[string]interface{}{}
+	json.Unmarshal(ctx.Body(), &data)
+
+	refreshToken, _ := data["refreshToken"].(string)
+	if refreshToken == "" {
+		refreshToken = ctx.Token()
+	}
+
+	claims, err := (&model.Admin{}).GetAuthUser(ctx.Engine.GetConfig().AppKey, refreshToken)
+	if err != nil || claims.TokenType != model.RefreshTokenType {
+		return ctx.JSON(401, message.Error("刷新令牌无效"))
+	}
+
+	revoked, err := (&model.Admin{}).IsTokenRevoked(claims)
+	if err != nil {
 		return ctx.JSON(200, message.Error(err.Error()))
 	}
+	if revoked {
+		return ctx.JSON(401, message.Error("刷新令牌已失效"))
+	}
 
-	// 检验账号和密码
-	if !hash.Check(adminInfo.Password, loginRequest.Password) {
-		return ctx.JSON(200, message.Error("用户名或密码错误"))
+	adminInfo, err := (&model.Admin{}).GetInfoById(claims.Id)
+	if err != nil {
+		return ctx.JSON(401, message.Error("用户不存在或已被禁用"))
 	}
 
-	// 更新登录信息
+	// 占用并吊销旧的刷新令牌，并发刷新时仅第一个请求签发新令牌
+	consumed, err := (&model.RevokedToken{}).Consume(claims.ID, "admin|"+strconv.Itoa(claims.Id), claims.ExpiresAt.Time)
+	if err != nil {
+		return ctx.JSON(200, message.Error(err.Error()))
+	}
+	if !consumed {
+		return ctx.JSON(401, message.Error("刷新令牌已失效"))
+	}
+
+	tokens, err := p.issueTokens(ctx, adminInfo)
+	if err != nil {
+		return ctx.JSON(200, message.Error(err.Error()))
+	}
+
+	return ctx.JSON(200, message.Success("操作成功", "", tokens))
+}
+
+// 退出方法，吊销当前的访问令牌及刷新令牌
+func (p *Index) Logout(ctx *builder.Context) error {
+	data := map[string]interface{}{}
+	json.Unmarshal(ctx.Body(), &data)
+
+	tokens := []string{ctx.Token()}
+	if refreshToken, ok := data["refreshToken"].(string); ok && refreshToken != "" {
+		tokens = append(tokens, refreshToken)
+	}
+
+	for _, token := range tokens {
+		claims, err := (&model.Admin{}).GetAuthUser(ctx.Engine.GetConfig().AppKey, token)
+		if err != nil {
+			continue
+		}
+
+		err = (&model.RevokedToken{}).Revoke(claims.ID, "admin|"+strconv.Itoa(claims.Id), claims.ExpiresAt.Time)
+		if err != nil {
+			return ctx.JSON(200, message.Error(err.Error()))
+		}
+	}
+
+	return ctx.JSON(200, message.Success("退出成功", "/"))
+}
+
+// 登录失败，记录失败次数并延迟响应，达到最大失败次数时锁定并记录日志
+func (p *Index) loginFailed(ctx *builder.Context, username string, adminId int) error {
+	delay, locked, err := (&model.LoginAttempt{}).Fail(username, ctx.ClientIP())
+	if err != nil {
+		return ctx.JSON(200, message.Error(err.Error()))
+	}
+	if locked {
+		p.lockedLog(ctx, username, adminId)
+		return ctx.JSON(200, message.Error(lockedMessage(model.LoginLockDuration)))
+	}
+	time.Sleep(delay)
+
+	return ctx.JSON(200, message.Error("用户名或密码错误"))
+}
+
+// 记录登录锁定日志
+func (p *Index) lockedLog(ctx *builder.Context, username string, adminId int) {
+	(&model.ActionLog{}).InsertGetId(&model.ActionLog{
+		ObjectId: adminId,
+		Url:      ctx.Path(),
+		Remark:   "登录失败次数过多，已锁定：" + username,
+		Ip:       ctx.ClientIP(),
+		Type:     "admin",
+		Method:   ctx.Method(),
+		Resource: "login",
+		UriKey:   "lock",
+	})
+}
+
+// 锁定提示
+func lockedMessage(lockedFor time.Duration) string {
+	minutes := int(math.Ceil(lockedFor.Minutes()))
+
+	return "登录失败次数过多，请" + strconv.Itoa(minutes) + "分钟后再试"
+}
+
+// 更新登录信息并签发令牌，被要求开启两步验证但尚未开启时跳转到个人设置
+func (p *Index) loginResult(ctx *builder.Context, adminInfo *model.Admin) error {
+	(&model.LoginAttempt{}).Clear(adminInfo.Username)
 	(&model.Admin{}).UpdateLastLogin(adminInfo.Id, ctx.ClientIP(), time.Now())
 
-	// 获取token字符串
-	tokenString, err := ctx.JwtToken((&model.Admin{}).GetClaims(adminInfo))
+	tokens, err := p.issueTokens(ctx, adminInfo)
+	if err != nil {
+		return ctx.JSON(200, message.Error(err.Error()))
+	}
+
+	needChange, err := (&model.Admin{}).NeedChangePassword(adminInfo.Id)
+	if err != nil {
+		return ctx.JSON(200, message.Error(err.Error()))
+	}
+	if needChange {
+		tokens["passwordChangeRequired"] = true
+
+		return ctx.JSON(200, message.Success("登录成功，请先修改密码", "/layout/index?api=/api/admin/account/setting/form", tokens))
+	}
+
+	needEnroll, err := (&model.Admin{}).NeedEnrollTotp(adminInfo.Id)
 	if err != nil {
 		return ctx.JSON(200, message.Error(err.Error()))
 	}
+	if needEnroll {
+		tokens["totpEnrollRequired"] = true
+
+		return ctx.JSON(200, message.Success("登录成功，请先开启两步验证", "/layout/index?api=/api/admin/account/setting/form", tokens))
+	}
+
+	return ctx.JSON(200, message.Success("登录成功", "", tokens))
+}
+
+// 签发访问令牌及刷新令牌
+func (p *Index) issueTokens(ctx *builder.Context, adminInfo *model.Admin) (map[string]interface{}, error) {
+	tokenString, err := ctx.JwtToken((&model.Admin{}).GetClaims(adminInfo))
+	if err != nil {
+		return nil, err
+	}
+
+	refreshTokenString, err := ctx.JwtToken((&model.Admin{}).GetRefreshClaims(adminInfo))
+	if err != nil {
+		return nil, err
+	}
 
-	return ctx.JSON(200, message.Success("登录成功", "", map[string]string{
-		"token": tokenString,
-	}))
+	return map[string]interface{}{
+		"token":        tokenString,
+		"refreshToken": refreshTokenString,
+		"expiresIn":    int(ctx.Engine.GetConfig().TokenExpire.Seconds()),
+	}, nil
 }
@@ -0,0 +1,29 @@
+package metrics
+
+import (
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/model"
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/template/dashboard/metrics"
+	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
+	"github.com/quarkcloudio/quark-go/v2/pkg/dal/db"
+)
+
+type AdminSexPartition struct {
+	metrics.Partition
+}
+
+// 初始化
+func (p *AdminSexPartition) Init() *AdminSexPartition {
+	p.Title = "管理员性别"
+	p.Col = 12
+	p.Labels = map[string]string{"1": "男", "2": "女"}
+
+	return p
+}
+
+// 计算数值
+func (p *AdminSexPartition) Calculate(ctx *builder.Context) interface{} {
+
+	return p.
+		Init().
+		Count(ctx, db.Client.Model(&model.Admin{}), "sex")
+}
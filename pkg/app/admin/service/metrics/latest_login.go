@@ -0,0 +1,41 @@
+package metrics
+
+import (
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/table"
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/model"
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/template/dashboard/metrics"
+	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
+	"github.com/quarkcloudio/quark-go/v2/pkg/dal/db"
+)
+
+type LatestLogin struct {
+	metrics.Table
+}
+
+// 初始化
+func (p *LatestLogin) Init() *LatestLogin {
+	p.Title = "最近登录"
+	p.Col = 24
+	p.Limit = 5
+	p.DateColumn = "last_login_time"
+	p.Ranges = metrics.DefaultRanges()
+	p.Columns = []*table.Column{
+		table.NewColumn().SetTitle("用户名").SetAttribute("username"),
+		table.NewColumn().SetTitle("昵称").SetAttribute("nickname"),
+		table.NewColumn().SetTitle("最后登录IP").SetAttribute("last_login_ip"),
+		table.NewColumn().SetTitle("最后登录时间").SetAttribute("last_login_time").SetValueType("dateTime"),
+	}
+
+	return p
+}
+
+// 计算数值
+func (p *LatestLogin) Calculate(ctx *builder.Context) interface{} {
+
+	return p.
+		Init().
+		Rows(ctx, db.Client.
+			Model(&model.Admin{}).
+			Select("id", "username", "nickname", "last_login_ip", "last_login_time").
+			Order("last_login_time desc"))
+}
@@ -0,0 +1,29 @@
+package metrics
+
+import (
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/model"
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/template/dashboard/metrics"
+	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
+	"github.com/quarkcloudio/quark-go/v2/pkg/dal/db"
+)
+
+type LogTrend struct {
+	metrics.Trend
+}
+
+// 初始化
+func (p *LogTrend) Init() *LogTrend {
+	p.Title = "操作趋势"
+	p.Col = 12
+	p.Unit = metrics.UnitDay
+
+	return p
+}
+
+// 计算数值
+func (p *LogTrend) Calculate(ctx *builder.Context) interface{} {
+
+	return p.
+		Init().
+		Count(ctx, db.Client.Model(&model.ActionLog{}))
+}
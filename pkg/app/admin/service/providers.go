@@ -16,6 +16,7 @@ var Providers = []interface{}{
 	&resources.User{},
 	&resources.Admin{},
 	&resources.Role{},
+	&resources.Department{},
 	&resources.Permission{},
 	&resources.Menu{},
 	&resources.ActionLog{},
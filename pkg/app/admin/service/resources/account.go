@@ -45,6 +45,9 @@ func (p *Account) FormApi(ctx *builder.Context) string {
 func (p *Account) Fields(ctx *builder.Context) []interface{} {
 	field := &resource.Field{}
 
+	// 密码策略
+	policy := (&model.PasswordHistory{}).GetPolicy()
+
 	return []interface{}{
 
 		field.Image("avatar", "头像").OnlyOnForms(),
@@ -91,22 +94,70 @@ func (p *Account) Fields(ctx *builder.Context) []interface{} {
 			SetDefault(1),
 
 		field.Password("password", "密码").
+			SetRules(policy.Rules("")).
 			SetCreationRules([]*rule.Rule{
 				rule.New().SetRequired().SetMessage("密码必须填写"),
 			}).
+			SetExtra(policy.Description() + "，不修改请留空").
 			OnlyOnForms(),
+
+		field.Display("两步验证").SetValue(p.totpStatus(ctx)),
 	}
 }
 
 // 行为
 func (p *Account) Actions(ctx *builder.Context) []interface{} {
-	return []interface{}{
+	items := []interface{}{
 		actions.ChangeAccount(),
 		actions.FormSubmit(),
 		actions.FormReset(),
 		actions.FormBack(),
 		actions.FormExtraBack(),
 	}
+
+	// 根据两步验证的开启状态展示对应行为
+	if p.totpEnabled(ctx) {
+		items = append(items, actions.TotpRecovery(), actions.TotpDisable())
+	} else {
+		items = append(items, actions.TotpEnable())
+	}
+
+	return items
+}
+
+// 判断当前管理员是否已开启两步验证
+func (p *Account) totpEnabled(ctx *builder.Context) bool {
+	adminInfo, err := (&model.Admin{}).GetAuthUser(ctx.Engine.GetConfig().AppKey, ctx.Token())
+	if err != nil {
+		return false
+	}
+
+	var count int64
+	db.Client.
+		Model(&model.Admin{}).
+		Where("id = ?", adminInfo.Id).
+		Where("totp_enabled = ?", 1).
+		Count(&count)
+
+	return count > 0
+}
+
+// 两步验证状态
+func (p *Account) totpStatus(ctx *builder.Context) string {
+	if p.totpEnabled(ctx) {
+		return "已开启"
+	}
+
+	adminInfo, err := (&model.Admin{}).GetAuthUser(ctx.Engine.GetConfig().AppKey, ctx.Token())
+	if err != nil {
+		return "未开启"
+	}
+	required, _ := (&model.Admin{}).IsTotpRequired(adminInfo.Id)
+	if required {
+		return "未开启，当前账号要求开启两步验证，请点击右上角开启两步验证"
+	}
+
+	return "未开启"
 }
 
 // 创建页面显示前回调
@@ -119,6 +170,8 @@ func (p *Account) BeforeCreating(ctx *builder.Context) map[string]interface{} {
 		First(&data)
 
 	delete(data, "password")
+	delete(data, "totp_secret")
+	delete(data, "totp_recovery")
 
 	return data
 }
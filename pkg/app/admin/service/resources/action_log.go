@@ -1,10 +1,14 @@
 package resources
 
 import (
+	"encoding/json"
+	"html/template"
+	"sort"
 	"time"
 
 	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/model"
 	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/service/actions"
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/service/charts"
 	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/service/searches"
 	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/template/resource"
 	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
@@ -50,6 +54,11 @@ func (p *ActionLog) Fields(ctx *builder.Context) []interface{} {
 		field.ID("id", "ID"),
 		field.Text("username", "用户"),
 		field.Text("url", "行为").SetEllipsis(true),
+		field.Text("remark", "操作"),
+		field.Text("resource", "资源").OnlyOnDetail(),
+		field.Text("uri_key", "行为标识").OnlyOnDetail(),
+		field.Text("method", "请求方法").OnlyOnDetail(),
+		field.Text("record_id", "记录ID").OnlyOnDetail(),
 		field.Text("ip", "IP"),
 		field.Datetime("created_at", "发生时间", func() interface{} {
 			if p.Field["created_at"] == nil {
@@ -58,6 +67,11 @@ func (p *ActionLog) Fields(ctx *builder.Context) []interface{} {
 
 			return p.Field["created_at"].(time.Time).Format("2006-01-02 15:04:05")
 		}),
+		field.Text("diff", "变更内容", func() interface{} {
+			diff, _ := p.Field["diff"].(string)
+
+			return renderDiff(diff)
+		}).OnlyOnDetail(),
 	}
 }
 
@@ -66,6 +80,7 @@ func (p *ActionLog) Searches(ctx *builder.Context) []interface{} {
 	return []interface{}{
 		searches.Input("url", "行为"),
 		searches.Input("ip", "IP"),
+		searches.Department("admins.department_id", "部门"),
 	}
 }
 
@@ -73,6 +88,76 @@ func (p *ActionLog) Searches(ctx *builder.Context) []interface{} {
 func (p *ActionLog) Actions(ctx *builder.Context) []interface{} {
 	return []interface{}{
 		actions.BatchDelete(),
+		actions.DetailLink(),
 		actions.Delete(),
 	}
 }
+
+// 图表
+func (p *ActionLog) Charts(ctx *builder.Context) []interface{} {
+	return []interface{}{
+		charts.LogResource(),
+	}
+}
+
+// 列表页表格主体
+func (p *ActionLog) IndexTableExtraRender(ctx *builder.Context) interface{} {
+	return p.ChartComponentRender(ctx, charts.LogResource())
+}
+
+// 详情查询
+func (p *ActionLog) DetailQuery(ctx *builder.Context, query *gorm.DB) *gorm.DB {
+	id := ctx.Query("id", "")
+	if id != "" {
+		query.Where("action_logs.id = ?", id)
+	}
+
+	return query
+}
+
+// 将变更内容渲染为表格，字段值已在记录时脱敏
+func renderDiff(diff string) string {
+	changes, err := (&model.ActionLog{Diff: diff}).GetDiff()
+	if err != nil || len(changes) == 0 {
+		return "无"
+	}
+
+	ids := []string{}
+	for id := range changes {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	html := "<table style='border-collapse:collapse'><tr><th>记录ID</th><th>字段</th><th>修改前</th><th>修改后</th></tr>"
+	for _, id := range ids {
+		fields := []string{}
+		for name := range changes[id] {
+			fields = append(fields, name)
+		}
+		sort.Strings(fields)
+
+		for _, name := range fields {
+			change := changes[id][name]
+			html = html + "<tr><td>" + template.HTMLEscapeString(id) + "</td>" +
+				"<td>" + template.HTMLEscapeString(name) + "</td>" +
+				"<td>" + template.HTMLEscapeString(diffValue(change.Old)) + "</td>" +
+				"<td>" + template.HTMLEscapeString(diffValue(change.New)) + "</td></tr>"
+		}
+	}
+
+	return html + "</table>"
+}
+
+// 格式化变更的值
+func diffValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	}
+
+	data, _ := json.Marshal(value)
+
+	return string(data)
+}
@@ -1,6 +1,7 @@
 package resources
 
 import (
+	"fmt"
 	"strconv"
 	"strings"
 	"time"
@@ -46,6 +47,12 @@ func (p *Admin) Fields(ctx *builder.Context) []interface{} {
 	// 角色列表
 	roles, _ := (&model.Role{}).List()
 
+	// 部门列表
+	departments, _ := (&model.Department{}).TreeSelect(false)
+
+	// 密码策略
+	policy := (&model.PasswordHistory{}).GetPolicy()
+
 	return []interface{}{
 		field.ID("id", "ID"),
 
@@ -82,6 +89,10 @@ func (p *Admin) Fields(ctx *builder.Context) []interface{} {
 			SetOptions(roles).
 			HideWhenImporting(true),
 
+		field.TreeSelect("department_id", "部门").
+			SetData(departments).
+			HideWhenImporting(true),
+
 		field.Text("nickname", "昵称").
 			SetEditable(true).
 			SetRules([]*rule.Rule{
@@ -130,12 +141,20 @@ func (p *Admin) Fields(ctx *builder.Context) []interface{} {
 			SetDefault(1),
 
 		field.Password("password", "密码").
+			SetRules(policy.Rules("{id}")).
 			SetCreationRules([]*rule.Rule{
 				rule.Required(true, "密码必须填写"),
 			}).
+			SetExtra(policy.Description()).
 			OnlyOnForms().
 			ShowOnImporting(true),
 
+		field.Switch("must_change_pwd", "下次登录修改密码").
+			SetTrueValue("是").
+			SetFalseValue("否").
+			SetDefault(false).
+			OnlyOnForms(),
+
 		field.Datetime("last_login_time", "最后登录时间", func() interface{} {
 			if p.Field["last_login_time"] == nil {
 				return p.Field["last_login_time"]
@@ -148,6 +167,14 @@ func (p *Admin) Fields(ctx *builder.Context) []interface{} {
 			return p.Field["last_login_time"].(time.Time).Format("2006-01-02 15:04:05")
 		}).OnlyOnIndex(),
 
+		field.Text("totp_enabled", "两步验证", func() interface{} {
+			if fmt.Sprint(p.Field["totp_enabled"]) == "1" {
+				return "已开启"
+			}
+
+			return "未开启"
+		}).OnlyOnIndex(),
+
 		field.Switch("status", "状态").
 			SetRules([]*rule.Rule{
 				rule.Required(true, "请选择状态"),
@@ -165,6 +192,7 @@ func (p *Admin) Searches(ctx *builder.Context) []interface{} {
 	return []interface{}{
 		searches.Input("username", "用户名"),
 		searches.Input("nickname", "昵称"),
+		searches.Department("department_id", "部门"),
 		searches.Status(),
 		searches.DatetimeRange("last_login_time", "登录时间"),
 	}
@@ -183,6 +211,8 @@ func (p *Admin) Actions(ctx *builder.Context) []interface{} {
 		actions.More().
 			SetActions([]interface{}{
 				actions.EditLink(),
+				actions.ResetTotp(),
+				actions.UnlockLogin(),
 				actions.Delete(),
 			}),
 		actions.FormSubmit(),
@@ -195,8 +225,10 @@ func (p *Admin) Actions(ctx *builder.Context) []interface{} {
 // 编辑页面显示前回调
 func (p *Admin) BeforeEditing(ctx *builder.Context, data map[string]interface{}) map[string]interface{} {
 
-	// 编辑页面清理password
+	// 编辑页面清理password及两步验证信息
 	delete(data, "password")
+	delete(data, "totp_secret")
+	delete(data, "totp_recovery")
 
 	roles, err := (&model.CasbinRule{}).GetUserRoles(data["id"].(int))
 	if err == nil {
@@ -211,7 +243,13 @@ func (p *Admin) BeforeEditing(ctx *builder.Context, data map[string]interface{})
 }
 
 // 保存数据前回调
-func (p *Admin) BeforeSaving(ctx *builder.Context, submitData map[string]interface{}) (map[string]interface{}, error) {
+func (p *Admin) BeforeSaving(ctx *builder.Context, tx *gorm.DB, submitData map[string]interface{}) (map[string]interface{}, error) {
+
+	// 两步验证信息只能由管理员本人开启，或通过重置行为清除
+	delete(submitData, "totp_secret")
+	delete(submitData, "totp_enabled")
+	delete(submitData, "totp_recovery")
+	delete(submitData, "pwd_changed_at")
 
 	// 加密密码
 	if submitData["password"] != nil {
@@ -222,16 +260,24 @@ func (p *Admin) BeforeSaving(ctx *builder.Context, submitData map[string]interfa
 }
 
 // 保存后回调
-func (p *Admin) AfterSaved(ctx *builder.Context, id int, data map[string]interface{}, result *gorm.DB) error {
+func (p *Admin) AfterSaved(ctx *builder.Context, tx *gorm.DB, id int, data map[string]interface{}) error {
 
-	// 导入操作，直接返回
-	if ctx.IsImport() {
-		return result.Error
+	// 记录密码修改时间及历史密码
+	if password, ok := data["password"].(string); ok {
+		err := tx.Model(&model.Admin{}).Where("id = ?", id).Update("pwd_changed_at", time.Now()).Error
+		if err != nil {
+			return err
+		}
+		err = (&model.PasswordHistory{}).Add(tx, id, password)
+		if err != nil {
+			return err
+		}
 	}
+	(&model.Admin{}).ClearPasswordCache(id)
 
-	// 返回错误信息
-	if result.Error != nil {
-		return ctx.JSON(200, message.Error(result.Error.Error()))
+	// 导入操作，直接返回
+	if ctx.IsImport() {
+		return nil
 	}
 
 	if data["role_ids"] != nil {
@@ -242,15 +288,78 @@ func (p *Admin) AfterSaved(ctx *builder.Context, id int, data map[string]interfa
 				ids = append(ids, roleId)
 			}
 
-			err := (&model.CasbinRule{}).AddUserRole(id, ids)
+			err := (&model.CasbinRule{}).AddUserRole(tx, id, ids)
 			if err != nil {
-				return ctx.JSON(200, message.Error(err.Error()))
+				return err
 			}
 		}
 	}
 
+	// 修改密码或禁用后强制下线
+	if data["password"] != nil || isDisabled(data["status"]) {
+		err := (&model.Admin{}).RevokeTokens(tx, id)
+		if err != nil {
+			return err
+		}
+	}
+
 	return ctx.JSON(200, message.Success(
 		"操作成功",
 		strings.Replace("/layout/index?api="+resource.IndexPath, ":resource", ctx.Param("resource"), -1),
 	))
 }
+
+// 行内编辑后回调
+func (p *Admin) AfterEditable(ctx *builder.Context, tx *gorm.DB, id interface{}, field string, value interface{}) error {
+	if field != "status" || !isDisabled(value) {
+		return nil
+	}
+
+	adminId, err := strconv.Atoi(fmt.Sprint(id))
+	if err != nil {
+		return err
+	}
+
+	return (&model.Admin{}).RevokeTokens(tx, adminId)
+}
+
+// 行为执行后回调，被删除或禁用的管理员强制下线
+func (p *Admin) AfterAction(ctx *builder.Context, tx *gorm.DB, uriKey string, query *gorm.DB) error {
+	id, ok := ctx.Query("id").(string)
+	if !ok || id == "" {
+		return nil
+	}
+
+	admins := []model.Admin{}
+	err := tx.Unscoped().Where("id IN ?", strings.Split(id, ",")).Find(&admins).Error
+	if err != nil {
+		return err
+	}
+
+	for _, admin := range admins {
+		if admin.DeletedAt.Valid || admin.Status != 1 {
+			err = (&model.Admin{}).RevokeTokens(tx, admin.Id)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// 判断状态值是否为禁用
+func isDisabled(status interface{}) bool {
+	switch value := status.(type) {
+	case bool:
+		return !value
+	case float64:
+		return value == 0
+	case int:
+		return value == 0
+	case string:
+		return value == "0" || value == "false"
+	}
+
+	return false
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/service/searches"
 	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/template/resource"
 	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
+	"gorm.io/gorm"
 )
 
 type Config struct {
@@ -126,3 +127,23 @@ func (p *Config) Actions(ctx *builder.Context) []interface{} {
 		actions.FormExtraBack(),
 	}
 }
+
+// 保存后回调
+func (p *Config) AfterSaved(ctx *builder.Context, tx *gorm.DB, id int, data map[string]interface{}) error {
+	err := (&model.Config{}).ClearCache()
+	if err != nil {
+		return err
+	}
+
+	return p.Template.AfterSaved(ctx, tx, id, data)
+}
+
+// 行内编辑后回调
+func (p *Config) AfterEditable(ctx *builder.Context, tx *gorm.DB, id interface{}, field string, value interface{}) error {
+	return (&model.Config{}).ClearCache()
+}
+
+// 行为执行后回调
+func (p *Config) AfterAction(ctx *builder.Context, tx *gorm.DB, uriKey string, query *gorm.DB) error {
+	return (&model.Config{}).ClearCache()
+}
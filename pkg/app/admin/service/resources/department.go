@@ -0,0 +1,186 @@
+package resources
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/form/rule"
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/model"
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/service/actions"
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/service/searches"
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/template/resource"
+	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
+	"github.com/quarkcloudio/quark-go/v2/pkg/utils/lister"
+	"gorm.io/gorm"
+)
+
+type Department struct {
+	resource.Template
+}
+
+// 初始化
+func (p *Department) Init(ctx *builder.Context) interface{} {
+
+	// 标题
+	p.Title = "部门"
+
+	// 模型
+	p.Model = &model.Department{}
+
+	// 分页
+	p.PerPage = false
+
+	// 默认排序
+	p.QueryOrder = "sort asc"
+
+	return p
+}
+
+// 字段
+func (p *Department) Fields(ctx *builder.Context) []interface{} {
+	field := &resource.Field{}
+
+	// 部门列表
+	departments, _ := (&model.Department{}).TreeSelect(true)
+
+	return []interface{}{
+		field.Hidden("id", "ID"), // 列表读取且不展示的字段
+
+		field.Hidden("pid", "PID").OnlyOnIndex(), // 列表读取且不展示的字段
+
+		field.Text("name", "名称").
+			SetRules([]*rule.Rule{
+				rule.Required(true, "名称必须填写"),
+			}),
+
+		field.TreeSelect("pid", "上级部门").
+			SetData(departments).
+			SetDefault(0).
+			OnlyOnForms(),
+
+		field.Number("sort", "排序").
+			SetEditable(true).
+			SetDefault(0),
+
+		field.Switch("status", "状态").
+			SetTrueValue("正常").
+			SetFalseValue("禁用").
+			SetEditable(true).
+			SetDefault(true),
+	}
+}
+
+// 搜索
+func (p *Department) Searches(ctx *builder.Context) []interface{} {
+	return []interface{}{
+		searches.Input("name", "名称"),
+		searches.Status(),
+	}
+}
+
+// 行为
+func (p *Department) Actions(ctx *builder.Context) []interface{} {
+	return []interface{}{
+		actions.CreateDrawer(),
+		actions.BatchDelete(),
+		actions.BatchDisable(),
+		actions.BatchEnable(),
+		actions.ChangeStatus(),
+		actions.EditDrawer(),
+		actions.Delete(),
+		actions.FormSubmit(),
+		actions.FormReset(),
+		actions.FormBack(),
+		actions.FormExtraBack(),
+	}
+}
+
+// 列表页面显示前回调
+func (p *Department) BeforeIndexShowing(ctx *builder.Context, list []map[string]interface{}) []interface{} {
+	data := ctx.AllQuerys()
+	if search, ok := data["search"].(map[string]interface{}); ok && search != nil {
+		result := []interface{}{}
+		for _, v := range list {
+			result = append(result, v)
+		}
+
+		return result
+	}
+
+	// 转换成树形表格
+	tree, _ := lister.ListToTree(list, "id", "pid", "children", 0)
+
+	return tree
+}
+
+// 保存数据前回调
+func (p *Department) BeforeSaving(ctx *builder.Context, tx *gorm.DB, submitData map[string]interface{}) (map[string]interface{}, error) {
+	id, ok := submitData["id"].(float64)
+	if !ok || submitData["pid"] == nil {
+		return submitData, nil
+	}
+
+	// 上级部门不能为当前部门或其子部门
+	childrenIds, err := (&model.Department{}).GetChildrenIds(int(id))
+	if err != nil {
+		return submitData, err
+	}
+	pid, _ := submitData["pid"].(float64)
+	for _, v := range childrenIds {
+		if v == int(pid) {
+			return submitData, errors.New("上级部门不能为当前部门或其子部门")
+		}
+	}
+
+	return submitData, nil
+}
+
+// 行为执行后回调，删除的部门下存在子部门或管理员时回滚
+func (p *Department) AfterAction(ctx *builder.Context, tx *gorm.DB, uriKey string, query *gorm.DB) error {
+	id, ok := ctx.Query("id").(string)
+	if !ok || id == "" {
+		return nil
+	}
+
+	ids := strings.Split(id, ",")
+	existIds := []string{}
+	err := tx.Model(&model.Department{}).Where("id IN ?", ids).Pluck("id", &existIds).Error
+	if err != nil {
+		return err
+	}
+
+	deletedIds := []string{}
+	for _, v := range ids {
+		deleted := true
+		for _, ev := range existIds {
+			if ev == v {
+				deleted = false
+			}
+		}
+		if deleted {
+			deletedIds = append(deletedIds, v)
+		}
+	}
+	if len(deletedIds) == 0 {
+		return nil
+	}
+
+	var count int64
+	err = tx.Model(&model.Department{}).Where("pid IN ?", deletedIds).Count(&count).Error
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return errors.New("请先删除子部门")
+	}
+
+	err = tx.Model(&model.Admin{}).Where("department_id IN ?", deletedIds).Count(&count).Error
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return errors.New("部门下存在管理员，无法删除")
+	}
+
+	return nil
+}
@@ -233,20 +233,31 @@ func (p *Menu) BeforeEditing(ctx *builder.Context, data map[string]interface{})
 }
 
 // 保存后回调
-func (p *Menu) AfterSaved(ctx *builder.Context, id int, data map[string]interface{}, result *gorm.DB) error {
+func (p *Menu) AfterSaved(ctx *builder.Context, tx *gorm.DB, id int, data map[string]interface{}) error {
+	err := (&model.Menu{}).ClearCache()
+	if err != nil {
+		return err
+	}
+
 	if data["permission_ids"] != nil {
-		err := (&model.CasbinRule{}).AddMenuPermission(id, data["permission_ids"])
+		err = (&model.CasbinRule{}).AddMenuPermission(tx, id, data["permission_ids"])
 		if err != nil {
-			return ctx.JSON(200, message.Error(err.Error()))
+			return err
 		}
 	}
 
-	if result.Error != nil {
-		return ctx.JSON(200, message.Error(result.Error.Error()))
-	}
-
 	return ctx.JSON(200, message.Success(
 		"操作成功",
 		strings.Replace("/layout/index?api="+resource.IndexPath, ":resource", ctx.Param("resource"), -1),
 	))
 }
+
+// 行内编辑后回调
+func (p *Menu) AfterEditable(ctx *builder.Context, tx *gorm.DB, id interface{}, field string, value interface{}) error {
+	return (&model.Menu{}).ClearCache()
+}
+
+// 行为执行后回调
+func (p *Menu) AfterAction(ctx *builder.Context, tx *gorm.DB, uriKey string, query *gorm.DB) error {
+	return (&model.Menu{}).ClearCache()
+}
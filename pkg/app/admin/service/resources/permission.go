@@ -8,6 +8,7 @@ import (
 	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/service/searches"
 	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/template/resource"
 	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
+	"gorm.io/gorm"
 )
 
 type Permission struct {
@@ -108,3 +109,15 @@ func (p *Permission) Actions(ctx *builder.Context) []interface{} {
 		actions.FormExtraBack(),
 	}
 }
+
+// 保存数据前回调
+func (p *Permission) BeforeSaving(ctx *builder.Context, tx *gorm.DB, submitData map[string]interface{}) (map[string]interface{}, error) {
+	if path, ok := submitData["path"].(string); ok {
+		err := (&model.CasbinRule{}).ValidatePath(path)
+		if err != nil {
+			return submitData, err
+		}
+	}
+
+	return submitData, nil
+}
@@ -48,6 +48,16 @@ func (p *Role) Fields(ctx *builder.Context) []interface{} {
 			}),
 
 		field.Text("guard_name", "GuardName").SetDefault("admin"),
+
+		field.Radio("data_scope", "数据权限").
+			SetOptions((&model.Role{}).DataScopeOptions()).
+			SetDefault(model.DataScopeAll),
+
+		field.Switch("require_totp", "强制两步验证").
+			SetTrueValue("是").
+			SetFalseValue("否").
+			SetDefault(false),
+
 		field.Tree("menu_ids", "权限").SetData(treeData).OnlyOnForms(),
 		field.Datetime("created_at", "创建时间", func() interface{} {
 			if p.Field["created_at"] == nil {
@@ -104,7 +114,12 @@ func (p *Role) BeforeEditing(ctx *builder.Context, data map[string]interface{})
 }
 
 // 保存后回调
-func (p *Role) AfterSaved(ctx *builder.Context, id int, data map[string]interface{}, result *gorm.DB) error {
+func (p *Role) AfterSaved(ctx *builder.Context, tx *gorm.DB, id int, data map[string]interface{}) error {
+	err := (&model.Role{}).ClearCache()
+	if err != nil {
+		return err
+	}
+
 	if data["menu_ids"] != nil {
 		if menuIds, ok := data["menu_ids"].([]interface{}); ok {
 			ids := []int{}
@@ -113,9 +128,9 @@ func (p *Role) AfterSaved(ctx *builder.Context, id int, data map[string]interfac
 				ids = append(ids, menuId)
 			}
 
-			err := (&model.CasbinRule{}).AddMenuAndPermissionToRole(id, ids)
+			err = (&model.CasbinRule{}).AddMenuAndPermissionToRole(tx, id, ids)
 			if err != nil {
-				return ctx.JSON(200, message.Error(err.Error()))
+				return err
 			}
 		}
 	}
@@ -125,3 +140,13 @@ func (p *Role) AfterSaved(ctx *builder.Context, id int, data map[string]interfac
 		strings.Replace("/layout/index?api="+resource.IndexPath, ":resource", ctx.Param("resource"), -1),
 	))
 }
+
+// 行为执行后回调
+func (p *Role) AfterAction(ctx *builder.Context, tx *gorm.DB, uriKey string, query *gorm.DB) error {
+	err := (&model.Role{}).ClearCache()
+	if err != nil {
+		return err
+	}
+
+	return (&model.Menu{}).ClearCache()
+}
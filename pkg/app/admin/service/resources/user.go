@@ -12,6 +12,7 @@ import (
 	"github.com/quarkcloudio/quark-go/v2/pkg/app/miniapp/model"
 	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
 	"github.com/quarkcloudio/quark-go/v2/pkg/utils/hash"
+	"gorm.io/gorm"
 )
 
 type User struct {
@@ -181,7 +182,7 @@ func (p *User) BeforeEditing(ctx *builder.Context, data map[string]interface{})
 }
 
 // 保存数据前回调
-func (p *User) BeforeSaving(ctx *builder.Context, submitData map[string]interface{}) (map[string]interface{}, error) {
+func (p *User) BeforeSaving(ctx *builder.Context, tx *gorm.DB, submitData map[string]interface{}) (map[string]interface{}, error) {
 
 	// 加密密码
 	if submitData["password"] != nil {
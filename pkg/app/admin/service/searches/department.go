@@ -0,0 +1,47 @@
+package searches
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/model"
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/template/resource/searches"
+	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
+	"gorm.io/gorm"
+)
+
+type DepartmentField struct {
+	searches.TreeSelect
+}
+
+// 部门，查询时包含子部门，Department("department_id", "部门")
+func Department(column string, name string) *DepartmentField {
+	field := &DepartmentField{}
+
+	field.Column = column
+	field.Name = name
+
+	return field
+}
+
+// 执行查询
+func (p *DepartmentField) Apply(ctx *builder.Context, query *gorm.DB, value interface{}) *gorm.DB {
+	departmentId, err := strconv.Atoi(fmt.Sprint(value))
+	if err != nil {
+		return query
+	}
+
+	ids, err := (&model.Department{}).GetChildrenIds(departmentId)
+	if err != nil {
+		return query
+	}
+
+	return query.Where(p.Column+" IN ?", ids)
+}
+
+// 属性
+func (p *DepartmentField) Options(ctx *builder.Context) interface{} {
+	options, _ := (&model.Department{}).TreeSelect(false)
+
+	return options
+}
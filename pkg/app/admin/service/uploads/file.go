@@ -1,6 +1,7 @@
 package uploads
 
 import (
+	"encoding/json"
 	"reflect"
 	"time"
 
@@ -46,6 +47,57 @@ func (p *File) Init(ctx *builder.Context) interface{} {
 	return p
 }
 
+// 初始化路由映射
+func (p *File) RouteInit() interface{} {
+	p.Any("/api/admin/upload/:resource/delete", p.Delete)
+	p.POST("/api/admin/upload/:resource/handle", p.Handle)
+	p.POST("/api/admin/upload/:resource/base64Handle", p.HandleFromBase64)
+	p.POST("/api/admin/upload/:resource/multipart/init", p.MultipartInit)
+	p.POST("/api/admin/upload/:resource/multipart/part", p.MultipartPart)
+	p.POST("/api/admin/upload/:resource/multipart/complete", p.MultipartComplete)
+	p.POST("/api/admin/upload/:resource/multipart/abort", p.MultipartAbort)
+
+	return p
+}
+
+// 文件删除
+func (p *File) Delete(ctx *builder.Context) error {
+	data := map[string]interface{}{}
+	json.Unmarshal(ctx.Body(), &data)
+	if data["id"] == nil || data["id"] == "" {
+		return ctx.JSON(200, message.Error("参数错误！"))
+	}
+
+	fileInfo, err := (&model.File{}).GetInfoById(data["id"])
+	if err != nil {
+		return ctx.JSON(200, message.Error(err.Error()))
+	}
+
+	err = (&model.File{}).DeleteById(fileInfo.Id)
+	if err != nil {
+		return ctx.JSON(200, message.Error(err.Error()))
+	}
+
+	// 没有其他记录引用时，删除存储的文件
+	count, err := (&model.File{}).CountByPath(fileInfo.Path)
+	if err != nil {
+		return ctx.JSON(200, message.Error(err.Error()))
+	}
+	if count == 0 {
+		driver, err := p.GetStorage()
+		if err != nil {
+			return ctx.JSON(200, message.Error(err.Error()))
+		}
+
+		err = driver.Delete(fileInfo.Path)
+		if err != nil {
+			return ctx.JSON(200, message.Error(err.Error()))
+		}
+	}
+
+	return ctx.JSON(200, message.Success("操作成功"))
+}
+
 // 上传前回调
 func (p *File) BeforeHandle(ctx *builder.Context, fileSystem *storage.FileSystem) (*storage.FileSystem, *storage.FileInfo, error) {
 	fileHash, err := fileSystem.GetFileHash()
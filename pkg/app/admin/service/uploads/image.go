@@ -1,6 +1,7 @@
 package uploads
 
 import (
+	"bytes"
 	"encoding/base64"
 	"encoding/json"
 	"reflect"
@@ -35,6 +36,13 @@ func (p *Image) Init(ctx *builder.Context) interface{} {
 	// 设置文件上传路径
 	p.SavePath = "./web/app/storage/images/" + time.Now().Format("20060102") + "/"
 
+	// 图片衍生版本，webp需要通过storage.RegisterImageEncoder注册编码器后才会生成
+	p.ImageVariants = []*storage.ImageVariant{
+		storage.NewImageVariant("thumbnail", 150, 150).SetMode(storage.ImageFill),
+		storage.NewImageVariant("medium", 800, 800),
+		storage.NewImageVariant("webp", 0, 0).SetFormat("webp").SetQuality(80),
+	}
+
 	return p
 }
 
@@ -43,6 +51,7 @@ func (p *Image) RouteInit() interface{} {
 	p.GET("/api/admin/upload/:resource/getList", p.GetList)
 	p.Any("/api/admin/upload/:resource/delete", p.Delete)
 	p.POST("/api/admin/upload/:resource/crop", p.Crop)
+	p.GET("/api/admin/upload/:resource/variant", p.Variant)
 	p.POST("/api/admin/upload/:resource/handle", p.Handle)
 	p.POST("/api/admin/upload/:resource/base64Handle", p.HandleFromBase64)
 
@@ -97,11 +106,38 @@ func (p *Image) GetList(ctx *builder.Context) error {
 func (p *Image) Delete(ctx *builder.Context) error {
 	data := map[string]interface{}{}
 	json.Unmarshal(ctx.Body(), &data)
-	if data["id"] == "" {
+	if data["id"] == nil || data["id"] == "" {
 		return ctx.JSON(200, message.Error("参数错误！"))
 	}
 
-	err := (&model.Picture{}).DeleteById(data["id"])
+	pictureInfo, err := (&model.Picture{}).GetInfoById(data["id"])
+	if err != nil {
+		return ctx.JSON(200, message.Error(err.Error()))
+	}
+
+	err = (&model.Picture{}).DeleteById(pictureInfo.Id)
+	if err != nil {
+		return ctx.JSON(200, message.Error(err.Error()))
+	}
+
+	// 没有其他记录引用时，删除存储的文件
+	count, err := (&model.Picture{}).CountByPath(pictureInfo.Path)
+	if err != nil {
+		return ctx.JSON(200, message.Error(err.Error()))
+	}
+	if count == 0 {
+		driver, err := p.GetStorage()
+		if err != nil {
+			return ctx.JSON(200, message.Error(err.Error()))
+		}
+
+		err = driver.Delete(pictureInfo.Path)
+		if err != nil {
+			return ctx.JSON(200, message.Error(err.Error()))
+		}
+	}
+
+	err = p.deleteVariants(ctx, pictureInfo.Id)
 	if err != nil {
 		return ctx.JSON(200, message.Error(err.Error()))
 	}
@@ -109,6 +145,103 @@ func (p *Image) Delete(ctx *builder.Context) error {
 	return ctx.JSON(200, message.Success("操作成功"))
 }
 
+// 获取图片衍生版本，不存在时按配置生成后跳转
+func (p *Image) Variant(ctx *builder.Context) error {
+	id := ctx.Query("id", "")
+	name := ctx.Query("name", "").(string)
+	if id == "" || name == "" {
+		return ctx.JSON(200, message.Error("参数错误！"))
+	}
+
+	pictureInfo, err := (&model.Picture{}).GetInfoById(id)
+	if err != nil {
+		return ctx.JSON(200, message.Error(err.Error()))
+	}
+
+	variant, _ := (&model.PictureVariant{}).GetInfo(pictureInfo.Id, name)
+	if variant.Id == 0 {
+		variants := []*storage.ImageVariant{}
+		for _, v := range ctx.Template.(upload.Uploader).GetImageVariants() {
+			if v.Name == name {
+				variants = append(variants, v)
+			}
+		}
+		if len(variants) == 0 {
+			return ctx.JSON(200, message.Error("图片版本不存在："+name))
+		}
+
+		err = p.makeVariants(ctx, pictureInfo.Id, pictureInfo.Path, variants)
+		if err != nil {
+			return ctx.JSON(200, message.Error(err.Error()))
+		}
+	}
+
+	return ctx.Redirect(302, (&model.Picture{}).GetPath(pictureInfo.Id, name))
+}
+
+// 生成图片衍生版本并记录到数据库
+func (p *Image) makeVariants(ctx *builder.Context, pictureId int, path string, variants []*storage.ImageVariant) error {
+	results, err := p.variantFileSystem(ctx).MakeImageVariants(path, variants)
+	if err != nil {
+		return err
+	}
+
+	for name, result := range results {
+		err = (&model.PictureVariant{}).Save(&model.PictureVariant{
+			PictureId: pictureId,
+			Name:      name,
+			Width:     result.Width,
+			Height:    result.Height,
+			Size:      result.Size,
+			Ext:       result.Ext,
+			Path:      result.Path,
+			Url:       result.Url,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// 删除图片衍生版本，没有其他记录引用时删除存储的文件
+func (p *Image) deleteVariants(ctx *builder.Context, pictureId int) error {
+	variants, err := (&model.PictureVariant{}).GetListByPictureId(pictureId)
+	if err != nil {
+		return err
+	}
+
+	err = (&model.PictureVariant{}).DeleteByPictureId(pictureId)
+	if err != nil {
+		return err
+	}
+
+	paths := []string{}
+	for _, variant := range variants {
+		count, err := (&model.PictureVariant{}).CountByPath(variant.Path)
+		if err != nil {
+			return err
+		}
+		if count == 0 {
+			paths = append(paths, variant.Path)
+		}
+	}
+
+	return p.variantFileSystem(ctx).DeleteImageVariants(paths)
+}
+
+// 获取处理衍生版本使用的文件系统
+func (p *Image) variantFileSystem(ctx *builder.Context) *storage.FileSystem {
+	template := ctx.Template.(upload.Uploader)
+
+	return storage.New(&storage.Config{
+		Driver:      template.GetDriver(),
+		OSSConfig:   template.GetOSSConfig(),
+		MinioConfig: template.GetMinioConfig(),
+	})
+}
+
 // 图片裁剪
 func (p *Image) Crop(ctx *builder.Context) error {
 	var (
@@ -215,7 +348,7 @@ func (p *Image) Crop(ctx *builder.Context) error {
 			MinioConfig:      minioConfig.(*storage.MinioConfig),
 		}).
 		Reader(&storage.File{
-			Content: fileData,
+			Content: bytes.NewReader(fileData),
 		})
 
 	// 上传前回调
@@ -271,6 +404,16 @@ func (p *Image) Crop(ctx *builder.Context) error {
 		Status:  1,
 	})
 
+	// 重新生成衍生版本
+	err = p.deleteVariants(ctx, pictureInfo.Id)
+	if err != nil {
+		return ctx.JSON(200, message.Error(err.Error()))
+	}
+	err = p.makeVariants(ctx, pictureInfo.Id, result.Path, ctx.Template.(upload.Uploader).GetImageVariants())
+	if err != nil {
+		ctx.EchoContext.Logger().Warn("make image variants failed: ", err)
+	}
+
 	return ctx.JSON(200, message.Success("操作成功", "", result))
 }
 
@@ -339,6 +482,12 @@ func (p *Image) AfterHandle(ctx *builder.Context, result *storage.FileInfo) erro
 		return ctx.JSON(200, message.Error(err.Error()))
 	}
 
+	// 生成衍生版本，失败时不影响原图上传
+	err = p.makeVariants(ctx, id, result.Path, ctx.Template.(upload.Uploader).GetImageVariants())
+	if err != nil {
+		ctx.EchoContext.Logger().Warn("make image variants failed: ", err)
+	}
+
 	return ctx.JSON(200, message.Success("上传成功", "", map[string]interface{}{
 		"id":          id,
 		"contentType": result.ContentType,
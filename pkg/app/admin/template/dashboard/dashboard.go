@@ -2,6 +2,7 @@ package dashboard
 
 import (
 	"reflect"
+	"strconv"
 
 	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/card"
 	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/descriptions"
@@ -9,6 +10,8 @@ import (
 	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/message"
 	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/pagecontainer"
 	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/statistic"
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/tabs"
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/template/dashboard/metrics"
 	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
 	"github.com/quarkcloudio/quark-go/v2/pkg/dal/db"
 )
@@ -45,7 +48,8 @@ func (p *Template) TemplateInit(ctx *builder.Context) interface{} {
 
 // 初始化路由映射
 func (p *Template) RouteInit() interface{} {
-	p.GET("/api/admin/dashboard/:resource/index", p.Render) // 后台仪表盘路由
+	p.GET("/api/admin/dashboard/:resource/index", p.Render)          // 后台仪表盘路由
+	p.GET("/api/admin/dashboard/:resource/metric/:uriKey", p.Metric) // 指标数据路由
 
 	return p
 }
@@ -124,7 +128,9 @@ func (p *Template) Render(ctx *builder.Context) error {
 		// 断言statistic组件类型
 		statistic, ok := v.(interface{ Calculate() *statistic.Component })
 		item := (&card.Component{}).Init()
-		if ok {
+		if asyncer, isAsync := v.(metrics.Asyncer); isAsync {
+			item = template.MetricComponentRender(ctx, asyncer)
+		} else if ok {
 			item = item.SetBody(statistic.Calculate())
 		} else {
 			// 断言descriptions组件类型
@@ -166,3 +172,51 @@ func (p *Template) Render(ctx *builder.Context) error {
 
 	return ctx.JSON(200, component)
 }
+
+// 异步指标卡片渲染，设置了时间范围时每个范围为一个标签页
+func (p *Template) MetricComponentRender(ctx *builder.Context, metric metrics.Asyncer) *card.Component {
+	initMetric(metric)
+
+	api := "/api/admin/dashboard/" + ctx.Param("resource") + "/metric/" + metrics.GetUriKey(metric)
+	item := (&card.Component{}).Init().SetTitle(metric.GetTitle())
+
+	ranges := metric.GetRanges()
+	if len(ranges) == 0 {
+		return item.SetBody(metric.Component(api))
+	}
+
+	tabPanes := []interface{}{}
+	for _, v := range ranges {
+		tabPanes = append(tabPanes, (&tabs.TabPane{}).
+			Init().
+			SetTitle(v.Label).
+			SetBody(metric.Component(api+"?range="+strconv.Itoa(v.Value))))
+	}
+
+	return item.SetBody((&tabs.Component{}).Init().SetSize("small").SetTabPanes(tabPanes))
+}
+
+// 获取指标数据
+func (p *Template) Metric(ctx *builder.Context) error {
+	template := ctx.Template.(Dashboarder)
+
+	for _, v := range template.Cards(ctx) {
+		metric, ok := v.(metrics.Asyncer)
+		if !ok || metrics.GetUriKey(metric) != ctx.Param("uriKey") {
+			continue
+		}
+		initMetric(metric)
+
+		return ctx.JSON(200, metric.Calculate(ctx))
+	}
+
+	return ctx.JSON(200, message.Error("指标不存在"))
+}
+
+// 调用指标的Init方法，初始化标题、栅格及时间范围等属性
+func initMetric(metric interface{}) {
+	method := reflect.ValueOf(metric).MethodByName("Init")
+	if method.IsValid() && method.Type().NumIn() == 0 {
+		method.Call(nil)
+	}
+}
@@ -1,6 +1,10 @@
 package dashboard
 
-import "github.com/quarkcloudio/quark-go/v2/pkg/builder"
+import (
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/card"
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/template/dashboard/metrics"
+	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
+)
 
 type Dashboarder interface {
 
@@ -27,4 +31,10 @@ type Dashboarder interface {
 
 	// 组件渲染
 	Render(ctx *builder.Context) error
+
+	// 异步指标卡片渲染
+	MetricComponentRender(ctx *builder.Context, metric metrics.Asyncer) *card.Component
+
+	// 获取指标数据
+	Metric(ctx *builder.Context) error
 }
@@ -1,6 +1,112 @@
 package metrics
 
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gobeam/stringy"
+	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
+	"gorm.io/gorm"
+)
+
 type Metrics struct {
-	Title string
-	Col   int
+	Title      string
+	Col        int
+	Ranges     []*Range // 可选择的时间范围，为空时不按时间过滤
+	DateColumn string   // 按时间范围过滤的字段，默认created_at
+}
+
+// 时间范围
+type Range struct {
+	Value int    `json:"value"` // 天数
+	Label string `json:"label"` // 显示文字
+}
+
+// 通过独立接口加载数据的指标
+type Asyncer interface {
+
+	// 获取标题
+	GetTitle() string
+
+	// 获取可选择的时间范围
+	GetRanges() []*Range
+
+	// 指标组件，api为获取数据的接口
+	Component(api string) interface{}
+
+	// 计算数据
+	Calculate(ctx *builder.Context) interface{}
+}
+
+// 默认的时间范围
+func DefaultRanges() []*Range {
+	return []*Range{
+		{Value: 7, Label: "近7天"},
+		{Value: 30, Label: "近30天"},
+		{Value: 90, Label: "近90天"},
+	}
+}
+
+// 获取指标的唯一标识
+func GetUriKey(metric interface{}) string {
+	uriKey := reflect.TypeOf(metric).String()
+	uriKeys := strings.Split(uriKey, ".")
+
+	return stringy.New(uriKeys[len(uriKeys)-1]).KebabCase("?", "").ToLower()
+}
+
+// 获取标题
+func (p *Metrics) GetTitle() string {
+	return p.Title
+}
+
+// 获取可选择的时间范围
+func (p *Metrics) GetRanges() []*Range {
+	return p.Ranges
+}
+
+// 获取当前选择的天数，请求参数不在可选范围内时使用第一个范围，未设置范围时返回0
+func (p *Metrics) GetRange(ctx *builder.Context) int {
+	if len(p.Ranges) == 0 {
+		return 0
+	}
+
+	days, _ := strconv.Atoi(fmt.Sprint(ctx.Query("range", "")))
+	for _, v := range p.Ranges {
+		if v.Value == days {
+			return days
+		}
+	}
+
+	return p.Ranges[0].Value
+}
+
+// 获取时间范围的开始时间，从今天起向前推算
+func (p *Metrics) GetRangeStart(days int) time.Time {
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	return today.AddDate(0, 0, 1-days)
+}
+
+// 获取按时间过滤的字段
+func (p *Metrics) GetDateColumn() string {
+	if p.DateColumn == "" {
+		return "created_at"
+	}
+
+	return p.DateColumn
+}
+
+// 按当前选择的时间范围过滤查询
+func (p *Metrics) RangeQuery(ctx *builder.Context, DB *gorm.DB) *gorm.DB {
+	days := p.GetRange(ctx)
+	if days <= 0 {
+		return DB
+	}
+
+	return DB.Where(p.GetDateColumn()+" >= ?", p.GetRangeStart(days))
 }
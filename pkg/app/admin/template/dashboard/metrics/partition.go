@@ -0,0 +1,81 @@
+package metrics
+
+import (
+	"database/sql"
+
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/chart"
+	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
+	"gorm.io/gorm"
+)
+
+type Partition struct {
+	Metrics
+	Labels map[string]string // 分组值对应的显示文字，例如：{"1": "男", "2": "女"}
+}
+
+// 分组数据
+type PartitionValue struct {
+	Label string  `json:"label"`
+	Value float64 `json:"value"`
+}
+
+// 分组聚合的查询结果
+type partitionRow struct {
+	Label      sql.NullString
+	ValueCount int64
+	ValueSum   float64
+}
+
+// 按字段分组统计记录条数
+func (p *Partition) Count(ctx *builder.Context, DB *gorm.DB, groupColumn string) []*PartitionValue {
+	return p.aggregate(ctx, DB, groupColumn, "")
+}
+
+// 按字段分组对另一字段求和
+func (p *Partition) Sum(ctx *builder.Context, DB *gorm.DB, groupColumn string, column string) []*PartitionValue {
+	return p.aggregate(ctx, DB, groupColumn, column)
+}
+
+// 指标组件
+func (p *Partition) Component(api string) interface{} {
+	return chart.NewPie(nil).
+		SetApi(api).
+		SetAutoFit(true).
+		SetHeight(300).
+		SetAngleField("value").
+		SetColorField("label").
+		SetRadius(0.8)
+}
+
+// 分组聚合，column为空时统计记录条数
+func (p *Partition) aggregate(ctx *builder.Context, DB *gorm.DB, groupColumn string, column string) []*PartitionValue {
+	sumExpr := "0"
+	if column != "" {
+		sumExpr = "COALESCE(SUM(" + column + "), 0)"
+	}
+
+	rows := []partitionRow{}
+	p.RangeQuery(ctx, DB).
+		Select(groupColumn + " AS label, COUNT(*) AS value_count, " + sumExpr + " AS value_sum").
+		Group(groupColumn).
+		Scan(&rows)
+
+	result := []*PartitionValue{}
+	for _, row := range rows {
+		label := row.Label.String
+		if v, ok := p.Labels[label]; ok {
+			label = v
+		}
+		if label == "" {
+			label = "其他"
+		}
+
+		value := row.ValueSum
+		if column == "" {
+			value = float64(row.ValueCount)
+		}
+		result = append(result, &PartitionValue{Label: label, Value: value})
+	}
+
+	return result
+}
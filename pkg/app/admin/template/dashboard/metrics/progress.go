@@ -0,0 +1,55 @@
+package metrics
+
+import (
+	"math"
+
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/progress"
+	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
+	"gorm.io/gorm"
+)
+
+type Progress struct {
+	Metrics
+	Target float64 // 目标值
+}
+
+// 进度数据
+type ProgressValue struct {
+	Value   float64 `json:"value"`
+	Target  float64 `json:"target"`
+	Percent float64 `json:"percent"`
+}
+
+// 记录条数相对目标值的进度
+func (p *Progress) Count(ctx *builder.Context, DB *gorm.DB) *ProgressValue {
+	var count int64
+	p.RangeQuery(ctx, DB).Count(&count)
+
+	return p.Result(float64(count))
+}
+
+// 字段求和相对目标值的进度
+func (p *Progress) Sum(ctx *builder.Context, DB *gorm.DB, column string) *ProgressValue {
+	var sum float64
+	p.RangeQuery(ctx, DB).Select("COALESCE(SUM(" + column + "), 0)").Scan(&sum)
+
+	return p.Result(sum)
+}
+
+// 包含进度的结果
+func (p *Progress) Result(value float64) *ProgressValue {
+	percent := 0.0
+	if p.Target > 0 {
+		percent = math.Round(value/p.Target*10000) / 100
+	}
+
+	return &ProgressValue{Value: value, Target: p.Target, Percent: percent}
+}
+
+// 指标组件
+func (p *Progress) Component(api string) interface{} {
+	return progress.New().
+		SetApi(api).
+		SetTitle(p.Title).
+		SetTarget(p.Target)
+}
@@ -0,0 +1,38 @@
+package metrics
+
+import (
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/table"
+	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
+	"gorm.io/gorm"
+)
+
+type Table struct {
+	Metrics
+	Columns []*table.Column // 表格列
+	Limit   int             // 显示的记录条数，默认10条
+}
+
+// 查询记录列表
+func (p *Table) Rows(ctx *builder.Context, DB *gorm.DB) []map[string]interface{} {
+	limit := p.Limit
+	if limit <= 0 {
+		limit = 10
+	}
+
+	rows := []map[string]interface{}{}
+	p.RangeQuery(ctx, DB).Limit(limit).Find(&rows)
+
+	return rows
+}
+
+// 指标组件
+func (p *Table) Component(api string) interface{} {
+	component := table.New().
+		SetApi(api).
+		SetColumns(p.Columns).
+		SetOptions(map[string]bool{}).
+		SetSearches(false)
+	component.SetKey(api, true)
+
+	return component
+}
@@ -0,0 +1,168 @@
+package metrics
+
+import (
+	"math"
+	"time"
+
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/chart"
+	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
+	"gorm.io/gorm"
+)
+
+// 趋势统计周期
+const (
+	UnitDay   = "day"   // 按天
+	UnitWeek  = "week"  // 按周，以周一的日期表示
+	UnitMonth = "month" // 按月
+)
+
+type Trend struct {
+	Metrics
+	Unit string // 统计周期，day | week | month，默认按天
+}
+
+// 趋势数据
+type TrendValue struct {
+	Date  string  `json:"date"`
+	Value float64 `json:"value"`
+}
+
+// 按天聚合的查询结果
+type trendRow struct {
+	Date       string
+	ValueCount int64
+	ValueSum   float64
+}
+
+// 获取可选择的时间范围，未设置时使用默认范围
+func (p *Trend) GetRanges() []*Range {
+	if len(p.Ranges) == 0 {
+		p.Ranges = DefaultRanges()
+	}
+
+	return p.Ranges
+}
+
+// 记录条数趋势
+func (p *Trend) Count(ctx *builder.Context, DB *gorm.DB) []*TrendValue {
+	return p.aggregate(ctx, DB, "", func(count int64, sum float64) float64 {
+		return float64(count)
+	})
+}
+
+// 字段求和趋势
+func (p *Trend) Sum(ctx *builder.Context, DB *gorm.DB, column string) []*TrendValue {
+	return p.aggregate(ctx, DB, column, func(count int64, sum float64) float64 {
+		return sum
+	})
+}
+
+// 字段平均值趋势
+func (p *Trend) Average(ctx *builder.Context, DB *gorm.DB, column string) []*TrendValue {
+	return p.aggregate(ctx, DB, column, func(count int64, sum float64) float64 {
+		if count == 0 {
+			return 0
+		}
+
+		return math.Round(sum/float64(count)*100) / 100
+	})
+}
+
+// 指标组件
+func (p *Trend) Component(api string) interface{} {
+	return chart.NewLine(nil).
+		SetApi(api).
+		SetAutoFit(true).
+		SetHeight(300).
+		SetXField("date").
+		SetYField("value").
+		SetMeta(map[string]interface{}{
+			"value": map[string]interface{}{"alias": p.Title},
+		}).
+		SetSmooth(true)
+}
+
+// 先在数据库中按天聚合，再按统计周期合并，空缺的周期补0
+func (p *Trend) aggregate(ctx *builder.Context, DB *gorm.DB, column string, value func(count int64, sum float64) float64) []*TrendValue {
+	p.GetRanges()
+	start := p.GetRangeStart(p.GetRange(ctx))
+	dateColumn := p.GetDateColumn()
+	dateExpr := dateExpression(DB, dateColumn)
+
+	sumExpr := "0"
+	if column != "" {
+		sumExpr = "COALESCE(SUM(" + column + "), 0)"
+	}
+
+	rows := []trendRow{}
+	DB.
+		Select(dateExpr+" AS date, COUNT(*) AS value_count, "+sumExpr+" AS value_sum").
+		Where(dateColumn+" >= ?", start).
+		Group(dateExpr).
+		Scan(&rows)
+
+	counts := map[string]int64{}
+	sums := map[string]float64{}
+	for _, row := range rows {
+		if len(row.Date) > 10 {
+			row.Date = row.Date[:10]
+		}
+		date, err := time.ParseInLocation("2006-01-02", row.Date, start.Location())
+		if err != nil {
+			continue
+		}
+		key := p.periodKey(date)
+		counts[key] += row.ValueCount
+		sums[key] += row.ValueSum
+	}
+
+	result := []*TrendValue{}
+	for date := start; !date.After(time.Now()); date = p.nextPeriod(date) {
+		key := p.periodKey(date)
+		result = append(result, &TrendValue{Date: key, Value: value(counts[key], sums[key])})
+	}
+
+	return result
+}
+
+// 获取日期所在周期的标识
+func (p *Trend) periodKey(date time.Time) string {
+	switch p.Unit {
+	case UnitWeek:
+		weekday := (int(date.Weekday()) + 6) % 7
+		return date.AddDate(0, 0, -weekday).Format("2006-01-02")
+	case UnitMonth:
+		return date.Format("2006-01")
+	}
+
+	return date.Format("2006-01-02")
+}
+
+// 获取下一个周期内的日期
+func (p *Trend) nextPeriod(date time.Time) time.Time {
+	switch p.Unit {
+	case UnitWeek:
+		weekday := (int(date.Weekday()) + 6) % 7
+		return date.AddDate(0, 0, 7-weekday)
+	case UnitMonth:
+		return time.Date(date.Year(), date.Month()+1, 1, 0, 0, 0, 0, date.Location())
+	}
+
+	return date.AddDate(0, 0, 1)
+}
+
+// 获取各数据库中将时间字段格式化为日期的表达式
+func dateExpression(DB *gorm.DB, column string) string {
+	switch DB.Dialector.Name() {
+	case "mysql":
+		return "DATE_FORMAT(" + column + ", '%Y-%m-%d')"
+	case "postgres":
+		return "TO_CHAR(" + column + ", 'YYYY-MM-DD')"
+	case "sqlserver":
+		return "CONVERT(VARCHAR(10), " + column + ", 120)"
+	case "sqlite":
+		return "SUBSTR(" + column + ", 1, 10)"
+	}
+
+	return "DATE(" + column + ")"
+}
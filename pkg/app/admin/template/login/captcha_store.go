@@ -1,22 +1,29 @@
 package login
 
 import (
-	"context"
 	"time"
 
-	"github.com/redis/go-redis/v9"
+	"github.com/quarkcloudio/quark-go/v2/pkg/cache"
 )
 
+// 验证码存储，使用缓存保存，支持多实例部署
 type CaptchaStore struct {
-	RedisClient *redis.Client
-	Expiration  time.Duration
+	Cache      cache.Cache
+	Expiration time.Duration
 }
 
 func (store *CaptchaStore) Set(id string, digits []byte) {
-	store.RedisClient.Set(context.Background(), id, string(digits), store.Expiration)
+	store.Cache.Set("captcha:"+id, string(digits), store.Expiration)
 }
 
 func (store *CaptchaStore) Get(id string, clear bool) (digits []byte) {
-	bytes, _ := store.RedisClient.Get(context.Background(), id).Bytes()
-	return bytes
+	value, err := store.Cache.Get("captcha:" + id)
+	if err != nil {
+		return nil
+	}
+	if clear {
+		store.Cache.Delete("captcha:" + id)
+	}
+
+	return []byte(value)
 }
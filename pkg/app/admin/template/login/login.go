@@ -2,7 +2,11 @@ package login
 
 import (
 	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
 	"reflect"
+	"strconv"
 	"strings"
 	"time"
 
@@ -12,8 +16,14 @@ import (
 	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/message"
 	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/tabs"
 	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
+	"github.com/quarkcloudio/quark-go/v2/pkg/cache"
 	"github.com/quarkcloudio/quark-go/v2/pkg/dal/db"
-	redisclient "github.com/quarkcloudio/quark-go/v2/pkg/dal/redis"
+)
+
+// 两步验证凭证参数
+const (
+	PendingTokenTTL         = 5 * time.Minute // 账号密码校验通过后，完成两步验证的有效期
+	PendingTokenMaxAttempts = 5               // 两步验证最大尝试次数，超过后需重新登录
 )
 
 // 后台登录模板
@@ -50,13 +60,11 @@ func (p *Template) TemplateInit(ctx *builder.Context) interface{} {
 	// 子标题
 	p.SubTitle = "信息丰富的世界里，唯一稀缺的就是人类的注意力"
 
-	// 如果启动了redis缓存，验证码使用redis缓存
-	if redisclient.Client != nil {
-		captcha.SetCustomStore(&CaptchaStore{
-			RedisClient: redisclient.Client,
-			Expiration:  time.Second * 1000,
-		})
-	}
+	// 验证码使用缓存存储，配置了Redis时使用Redis缓存
+	captcha.SetCustomStore(&CaptchaStore{
+		Cache:      cache.Client,
+		Expiration: time.Second * 1000,
+	})
 
 	return p
 }
@@ -65,6 +73,8 @@ func (p *Template) TemplateInit(ctx *builder.Context) interface{} {
 func (p *Template) RouteInit() interface{} {
 	p.GET("/api/admin/login/:resource/index", p.Render)        // 渲染登录页面路由
 	p.POST("/api/admin/login/:resource/handle", p.Handle)      // 后台登录执行路由
+	p.POST("/api/admin/login/:resource/verify", p.Verify)      // 后台登录两步验证路由
+	p.POST("/api/admin/login/:resource/refresh", p.Refresh)    // 后台刷新令牌路由
 	p.GET("/api/admin/login/:resource/captchaId", p.CaptchaId) // 后台登录获取验证码ID路由
 	p.GET("/api/admin/login/:resource/captcha/:id", p.Captcha) // 后台登录验证码路由
 	p.GET("/api/admin/logout/:resource/handle", p.Logout)      // 后台退出执行路由
@@ -125,11 +135,80 @@ func (p *Template) Handle(ctx *builder.Context) error {
 	return ctx.JSON(200, message.Error("请实现登录方法"))
 }
 
+// 两步验证方法
+func (p *Template) Verify(ctx *builder.Context) error {
+	return ctx.JSON(200, message.Error("请实现两步验证方法"))
+}
+
+// 刷新令牌方法
+func (p *Template) Refresh(ctx *builder.Context) error {
+	return ctx.JSON(200, message.Error("请实现刷新令牌方法"))
+}
+
 // 退出方法
 func (p *Template) Logout(ctx *builder.Context) error {
 	return ctx.JSON(200, message.Success("退出成功", "/"))
 }
 
+// 两步验证凭证缓存键
+func pendingTokenCacheKey(token string) string {
+	return "admin_login_pending:" + token
+}
+
+// 两步验证失败次数缓存键
+func pendingFailCacheKey(token string) string {
+	return "admin_login_pending_fail:" + token
+}
+
+// 保存两步验证凭证，账号密码校验通过且管理员开启了两步验证时调用
+func (p *Template) SavePendingToken(adminId int) (string, error) {
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	token := hex.EncodeToString(buf)
+
+	return token, cache.Set(pendingTokenCacheKey(token), strconv.Itoa(adminId), PendingTokenTTL)
+}
+
+// 读取两步验证凭证对应的管理员id
+func (p *Template) GetPendingToken(token string) (adminId int, Error error) {
+	if token == "" {
+		return 0, errors.New("pendingToken不能为空")
+	}
+
+	value, err := cache.Get(pendingTokenCacheKey(token))
+	if err != nil {
+		if err == cache.ErrNotFound {
+			return 0, errors.New("登录已过期，请重新登录")
+		}
+		return 0, err
+	}
+
+	return strconv.Atoi(value)
+}
+
+// 记录一次两步验证失败，达到最大尝试次数时凭证失效，返回剩余次数
+func (p *Template) FailPendingToken(token string) int {
+	if _, err := cache.Get(pendingTokenCacheKey(token)); err != nil {
+		return 0
+	}
+
+	// 原子计数，避免并发请求绕过最大尝试次数
+	attempts, err := cache.Incr(pendingFailCacheKey(token), PendingTokenTTL)
+	if err != nil || attempts >= int64(PendingTokenMaxAttempts) {
+		p.DeletePendingToken(token)
+		return 0
+	}
+
+	return PendingTokenMaxAttempts - int(attempts)
+}
+
+// 删除两步验证凭证，验证通过后调用
+func (p *Template) DeletePendingToken(token string) error {
+	return cache.Delete(pendingTokenCacheKey(token), pendingFailCacheKey(token))
+}
+
 // 包裹在组件内的创建页字段
 func (p *Template) FieldsWithinComponents(ctx *builder.Context) interface{} {
 
@@ -34,6 +34,12 @@ type Loginer interface {
 	// 登录方法
 	Handle(ctx *builder.Context) error
 
+	// 两步验证方法
+	Verify(ctx *builder.Context) error
+
+	// 刷新令牌方法
+	Refresh(ctx *builder.Context) error
+
 	// 退出方法
 	Logout(ctx *builder.Context) error
 
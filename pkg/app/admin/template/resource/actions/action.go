@@ -1,11 +1,11 @@
 package actions
 
 import (
+	"errors"
 	"reflect"
 	"strings"
 
 	"github.com/gobeam/stringy"
-	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/message"
 	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
 	"gorm.io/gorm"
 )
@@ -106,10 +106,10 @@ func (p *Action) TemplateInit(ctx *builder.Context) interface{} {
 	return p
 }
 
-// 执行行为句柄
+// 执行行为句柄，在事务中执行，返回错误时回滚并输出错误消息
 func (p *Action) Handle(ctx *builder.Context, query *gorm.DB) error {
 
-	return ctx.JSON(200, message.Error("Method not implemented"))
+	return errors.New("Method not implemented")
 }
 
 // 行为key
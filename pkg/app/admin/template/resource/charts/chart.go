@@ -0,0 +1,44 @@
+package charts
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+
+	"github.com/gobeam/stringy"
+	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
+	"gorm.io/gorm"
+)
+
+type Chart struct {
+	Title string // 图表标题
+}
+
+// 初始化
+func (p *Chart) Init(ctx *builder.Context) interface{} {
+	return p
+}
+
+// 图表key
+func (p *Chart) GetUriKey(chart interface{}) string {
+	uriKey := reflect.TypeOf(chart).String()
+	uriKeys := strings.Split(uriKey, ".")
+	uriKey = stringy.New(uriKeys[1]).KebabCase("?", "").ToLower()
+
+	return uriKey
+}
+
+// 获取标题
+func (p *Chart) GetTitle() string {
+	return p.Title
+}
+
+// 图表组件，api为获取图表数据的接口
+func (p *Chart) Component(api string) interface{} {
+	return nil
+}
+
+// 获取图表数据，query为已执行数据权限及全局查询的资源查询
+func (p *Chart) Handle(ctx *builder.Context, query *gorm.DB) (interface{}, error) {
+	return nil, errors.New("Method not implemented")
+}
@@ -0,0 +1,34 @@
+package filters
+
+import (
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/form/fields/radio"
+	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
+)
+
+type Boolean struct {
+	Filter
+	TrueLabel  string
+	FalseLabel string
+}
+
+// 初始化模板
+func (p *Boolean) TemplateInit(ctx *builder.Context) interface{} {
+	p.Component = "booleanField"
+	if p.TrueLabel == "" {
+		p.TrueLabel = "是"
+	}
+	if p.FalseLabel == "" {
+		p.FalseLabel = "否"
+	}
+
+	return p
+}
+
+// 属性
+func (p *Boolean) Options(ctx *builder.Context) interface{} {
+
+	return []*radio.Option{
+		{Value: 1, Label: p.TrueLabel},
+		{Value: 0, Label: p.FalseLabel},
+	}
+}
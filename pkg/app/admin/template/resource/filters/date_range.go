@@ -0,0 +1,14 @@
+package filters
+
+import "github.com/quarkcloudio/quark-go/v2/pkg/builder"
+
+type DateRange struct {
+	Filter
+}
+
+// 初始化模板
+func (p *DateRange) TemplateInit(ctx *builder.Context) interface{} {
+	p.Component = "dateRangeField"
+
+	return p
+}
@@ -0,0 +1,72 @@
+package filters
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/gobeam/stringy"
+	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
+	"gorm.io/gorm"
+)
+
+type Filter struct {
+	Column    string      `json:"column"`
+	Name      string      `json:"name"`
+	Component string      `json:"component"`
+	Default   interface{} `json:"default"`
+}
+
+// 初始化
+func (p *Filter) Init(ctx *builder.Context) interface{} {
+	return p
+}
+
+// 初始化模板
+func (p *Filter) TemplateInit(ctx *builder.Context) interface{} {
+	p.Component = "selectField"
+
+	return p
+}
+
+// 获取字段名
+func (p *Filter) GetColumn(filter interface{}) string {
+	if p.Column == "" {
+		column := reflect.TypeOf(filter).String()
+		column = strings.Replace(column, "*filters.", "", -1)
+		return stringy.New(column).ToLower()
+	}
+
+	return p.Column
+}
+
+// 获取名称
+func (p *Filter) GetName() string {
+	return p.Name
+}
+
+// 获取组件名称
+func (p *Filter) GetComponent() string {
+	return p.Component
+}
+
+// 默认值
+func (p *Filter) GetDefault() interface{} {
+	return p.Default
+}
+
+// 设置默认值
+func (p *Filter) SetDefault(value interface{}) *Filter {
+	p.Default = value
+
+	return p
+}
+
+// 执行查询
+func (p *Filter) Apply(ctx *builder.Context, query *gorm.DB, value interface{}) *gorm.DB {
+	return query
+}
+
+// 属性
+func (p *Filter) Options(ctx *builder.Context) interface{} {
+	return nil
+}
@@ -0,0 +1,33 @@
+package filters
+
+import (
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/form/fields/selectfield"
+	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
+)
+
+type Select struct {
+	Filter
+	Multiple      bool
+	SelectOptions []*selectfield.Option
+}
+
+// 初始化模板
+func (p *Select) TemplateInit(ctx *builder.Context) interface{} {
+	p.Component = "selectField"
+
+	return p
+}
+
+// 设置Option
+func (p *Select) Option(value interface{}, label string) *selectfield.Option {
+
+	return &selectfield.Option{
+		Value: value,
+		Label: label,
+	}
+}
+
+// 是否多选
+func (p *Select) IsMultiple() bool {
+	return p.Multiple
+}
@@ -0,0 +1,143 @@
+package resource
+
+import (
+	"strings"
+
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/model"
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/template/resource/types"
+	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
+	"github.com/quarkcloudio/quark-go/v2/pkg/dal/db"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// 执行数据权限查询，资源未设置创建人字段及部门字段时不限制
+func (p *Template) applyDataScope(ctx *builder.Context, query *gorm.DB) *gorm.DB {
+	template := ctx.Template.(types.Resourcer)
+
+	ownerColumn := template.GetOwnerColumn()
+	departmentColumn := template.GetDepartmentColumn()
+	if ownerColumn == "" && departmentColumn == "" {
+		return query
+	}
+
+	adminId, departmentId, dataScope, err := p.getDataScope(ctx)
+	if err != nil {
+		return query.Where("1 = 0")
+	}
+
+	// 未分配部门时只能查看本人数据
+	if (dataScope == model.DataScopeDepartment || dataScope == model.DataScopeDepartmentAndChildren) && departmentId == 0 {
+		dataScope = model.DataScopeSelf
+	}
+
+	switch dataScope {
+	case model.DataScopeAll:
+		return query
+	case model.DataScopeDepartment, model.DataScopeDepartmentAndChildren:
+		departmentIds := []int{departmentId}
+		if dataScope == model.DataScopeDepartmentAndChildren {
+			departmentIds, err = (&model.Department{}).GetChildrenIds(departmentId)
+			if err != nil {
+				return query.Where("1 = 0")
+			}
+		}
+
+		if departmentColumn != "" {
+			return query.Where("? IN ?", clause.Column{Name: departmentColumn}, departmentIds)
+		}
+
+		// 未设置部门字段时，按创建人所在部门限制
+		admins := db.Client.Model(&model.Admin{}).Select("id").Where("department_id IN ?", departmentIds)
+
+		return query.Where("? IN (?)", clause.Column{Name: ownerColumn}, admins)
+	default:
+		if ownerColumn == "" {
+			return query.Where("1 = 0")
+		}
+
+		return query.Where("? = ?", clause.Column{Name: ownerColumn}, adminId)
+	}
+}
+
+// 创建数据时填充数据权限字段，不具有全部数据权限时使用当前管理员的信息覆盖提交的值
+func (p *Template) FillDataScope(ctx *builder.Context, data map[string]interface{}) map[string]interface{} {
+	template := ctx.Template.(types.Resourcer)
+
+	ownerColumn := template.GetOwnerColumn()
+	departmentColumn := template.GetDepartmentColumn()
+	if ownerColumn == "" && departmentColumn == "" {
+		return data
+	}
+
+	adminId, departmentId, dataScope, err := p.getDataScope(ctx)
+	if err != nil {
+		return data
+	}
+
+	// 去除表名前缀，例如articles.admin_id
+	if ownerColumn != "" {
+		ownerColumn = ownerColumn[strings.LastIndex(ownerColumn, ".")+1:]
+		if data[ownerColumn] == nil || dataScope != model.DataScopeAll {
+			data[ownerColumn] = adminId
+		}
+	}
+	if departmentColumn != "" {
+		departmentColumn = departmentColumn[strings.LastIndex(departmentColumn, ".")+1:]
+		if data[departmentColumn] == nil || dataScope != model.DataScopeAll {
+			data[departmentColumn] = departmentId
+		}
+	}
+
+	return data
+}
+
+// 更新数据时去除数据权限字段，不具有全部数据权限时不允许修改创建人及部门
+func (p *Template) StripDataScope(ctx *builder.Context, data map[string]interface{}) map[string]interface{} {
+	template := ctx.Template.(types.Resourcer)
+
+	ownerColumn := template.GetOwnerColumn()
+	departmentColumn := template.GetDepartmentColumn()
+	if ownerColumn == "" && departmentColumn == "" {
+		return data
+	}
+
+	_, _, dataScope, err := p.getDataScope(ctx)
+	if err == nil && dataScope == model.DataScopeAll {
+		return data
+	}
+
+	// 去除表名前缀，例如articles.admin_id
+	for _, column := range []string{ownerColumn, departmentColumn} {
+		if column != "" {
+			delete(data, column[strings.LastIndex(column, ".")+1:])
+		}
+	}
+
+	return data
+}
+
+// 获取当前管理员的数据权限，结果在当前请求内缓存
+func (p *Template) getDataScope(ctx *builder.Context) (adminId int, departmentId int, dataScope int, err error) {
+	if scope, ok := ctx.Get("data_scope").([]int); ok {
+		return scope[0], scope[1], scope[2], nil
+	}
+
+	adminInfo, err := (&model.Admin{}).GetAuthUser(ctx.Engine.GetConfig().AppKey, ctx.Token())
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	admin, err := (&model.Admin{}).GetInfoById(adminInfo.Id)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	dataScope, err = (&model.Role{}).GetDataScopeByAdminId(admin.Id)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	ctx.Set("data_scope", []int{admin.Id, admin.DepartmentId, dataScope})
+
+	return admin.Id, admin.DepartmentId, dataScope, nil
+}
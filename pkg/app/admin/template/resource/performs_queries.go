@@ -36,6 +36,19 @@ func (p *Template) BuildDetailQuery(ctx *builder.Context, query *gorm.DB) *gorm.
 	return query
 }
 
+// 创建图表查询
+func (p *Template) BuildChartQuery(ctx *builder.Context, query *gorm.DB) *gorm.DB {
+	template := ctx.Template.(types.Resourcer)
+
+	// 初始化查询
+	query = p.initializeQuery(ctx, query)
+
+	// 执行查询，这里使用的是透传的实例
+	query = template.ChartQuery(ctx, query)
+
+	return query
+}
+
 // 创建编辑页查询
 func (p *Template) BuildEditQuery(ctx *builder.Context, query *gorm.DB) *gorm.DB {
 	template := ctx.Template.(types.Resourcer)
@@ -63,7 +76,7 @@ func (p *Template) BuildEditableQuery(ctx *builder.Context, query *gorm.DB) *gor
 }
 
 // 创建导出查询
-func (p *Template) BuildExportQuery(ctx *builder.Context, query *gorm.DB, search []interface{}, filters []interface{}, columnFilters map[string]interface{}, orderings map[string]interface{}) *gorm.DB {
+func (p *Template) BuildExportQuery(ctx *builder.Context, query *gorm.DB, search []interface{}, filters []interface{}, filterValues map[string]interface{}, columnFilters map[string]interface{}, orderings map[string]interface{}) *gorm.DB {
 	template := ctx.Template.(types.Resourcer)
 
 	// 初始化查询
@@ -76,7 +89,7 @@ func (p *Template) BuildExportQuery(ctx *builder.Context, query *gorm.DB, search
 	query = p.applySearch(ctx, query, search)
 
 	// 执行过滤器查询
-	query = p.applyFilters(query, filters)
+	query = p.applyFilters(ctx, query, filters, filterValues)
 
 	// 执行表格列上过滤器查询
 	query = p.applyColumnFilters(query, columnFilters)
@@ -98,7 +111,7 @@ func (p *Template) BuildExportQuery(ctx *builder.Context, query *gorm.DB, search
 }
 
 // 创建列表查询
-func (p *Template) BuildIndexQuery(ctx *builder.Context, query *gorm.DB, search []interface{}, filters []interface{}, columnFilters map[string]interface{}, orderings map[string]interface{}) *gorm.DB {
+func (p *Template) BuildIndexQuery(ctx *builder.Context, query *gorm.DB, search []interface{}, filters []interface{}, filterValues map[string]interface{}, columnFilters map[string]interface{}, orderings map[string]interface{}) *gorm.DB {
 	template := ctx.Template.(types.Resourcer)
 
 	// 初始化查询
@@ -111,11 +124,16 @@ func (p *Template) BuildIndexQuery(ctx *builder.Context, query *gorm.DB, search
 	query = p.applySearch(ctx, query, search)
 
 	// 执行过滤器查询
-	query = p.applyFilters(query, filters)
+	query = p.applyFilters(ctx, query, filters, filterValues)
 
 	// 执行表格列上过滤器查询
 	query = p.applyColumnFilters(query, columnFilters)
 
+	// 游标分页时按游标字段排序，由分页查询处理
+	if template.GetPaginationMode() == "cursor" {
+		return query
+	}
+
 	// 获取排序规则
 	defaultOrder := template.GetQueryOrder()
 	if defaultOrder == "" {
@@ -149,6 +167,9 @@ func (p *Template) BuildUpdateQuery(ctx *builder.Context, query *gorm.DB) *gorm.
 func (p *Template) initializeQuery(ctx *builder.Context, query *gorm.DB) *gorm.DB {
 	template := ctx.Template.(types.Resourcer)
 
+	// 执行数据权限查询
+	query = p.applyDataScope(ctx, query)
+
 	return template.Query(ctx, query)
 }
 
@@ -194,11 +215,48 @@ func (p *Template) applyColumnFilters(query *gorm.DB, filters map[string]interfa
 }
 
 // 执行过滤器查询
-func (p *Template) applyFilters(query *gorm.DB, filters []interface{}) *gorm.DB {
-	// todo
+func (p *Template) applyFilters(ctx *builder.Context, query *gorm.DB, filters []interface{}, filterValues map[string]interface{}) *gorm.DB {
+	for _, v := range filters {
+		filterInstance, ok := v.(types.Filterer)
+		if !ok {
+			continue
+		}
+
+		// 获取字段
+		column := filterInstance.GetColumn(v)
+
+		// 未提交筛选值时使用默认值
+		value, ok := filterValues[column]
+		if !ok {
+			value, ok = p.rangeFilterValue(filterValues, column)
+		}
+		if !ok {
+			value = filterInstance.GetDefault()
+		}
+		if value == nil || value == "" {
+			continue
+		}
+		if values, ok := value.([]interface{}); ok && len(values) == 0 {
+			continue
+		}
+
+		query = filterInstance.Apply(ctx, query, value)
+	}
+
 	return query
 }
 
+// 数值范围筛选项的两端分别以column[0]、column[1]提交，合并为[min, max]
+func (p *Template) rangeFilterValue(filterValues map[string]interface{}, column string) (interface{}, bool) {
+	minValue, hasMin := filterValues[column+"[0]"]
+	maxValue, hasMax := filterValues[column+"[1]"]
+	if !hasMin && !hasMax {
+		return nil, false
+	}
+
+	return []interface{}{minValue, maxValue}, true
+}
+
 // 执行排序查询
 func (p *Template) applyOrderings(query *gorm.DB, orderings map[string]interface{}, defaultOrder string) *gorm.DB {
 	if len(orderings) == 0 || orderings == nil {
@@ -249,6 +307,12 @@ func (p *Template) DetailQuery(ctx *builder.Context, query *gorm.DB) *gorm.DB {
 	return query
 }
 
+// 图表查询
+func (p *Template) ChartQuery(ctx *builder.Context, query *gorm.DB) *gorm.DB {
+
+	return query
+}
+
 // 编辑查询
 func (p *Template) EditQuery(ctx *builder.Context, query *gorm.DB) *gorm.DB {
 	id := ctx.Query("id", "")
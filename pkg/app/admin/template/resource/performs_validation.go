@@ -11,6 +11,7 @@ import (
 	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/form/rule"
 	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
 	"github.com/quarkcloudio/quark-go/v2/pkg/dal/db"
+	"github.com/quarkcloudio/quark-go/v2/pkg/utils/hash"
 )
 
 // 创建请求的验证器
@@ -92,6 +93,41 @@ func (p *Template) Validator(rules []*rule.Rule, data map[string]interface{}) er
 					result = errors.New(errMsg)
 				}
 			}
+		case "password":
+			if fieldValue, ok := fieldValue.(string); ok && fieldValue != "" {
+				if !rule.CheckPassword(fieldValue) {
+					errMsg := rule.Message
+					if errMsg != "" {
+						result = errors.New(errMsg)
+					}
+				}
+			}
+		case "passwordHistory":
+			if fieldValue, ok := fieldValue.(string); ok && fieldValue != "" {
+				hashes := []string{}
+				historyField := strings.ReplaceAll(rule.HistoryValue, "{", "")
+				historyField = strings.ReplaceAll(historyField, "}", "")
+				historyValue := data[historyField]
+				if historyValue == nil {
+					continue
+				}
+
+				db.Client.
+					Table(rule.HistoryTable).
+					Where(rule.HistoryField+" = ?", historyValue).
+					Order("id desc").
+					Limit(rule.HistoryLimit).
+					Pluck("password", &hashes)
+				for _, v := range hashes {
+					if hash.Check(v, fieldValue) {
+						errMsg := rule.Message
+						if errMsg != "" {
+							result = errors.New(errMsg)
+						}
+						break
+					}
+				}
+			}
 		}
 	}
 
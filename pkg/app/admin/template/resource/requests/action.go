@@ -1,6 +1,8 @@
 package requests
 
 import (
+	"strings"
+
 	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/message"
 	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/template/resource/types"
 	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
@@ -12,7 +14,10 @@ type ActionRequest struct{}
 
 // 执行行为
 func (p *ActionRequest) Handle(ctx *builder.Context) error {
-	var result error
+	var (
+		handler interface{}
+		uriKey  string
+	)
 
 	// 模版实例
 	template := ctx.Template.(types.Resourcer)
@@ -20,12 +25,6 @@ func (p *ActionRequest) Handle(ctx *builder.Context) error {
 	// 模型结构体
 	modelInstance := template.GetModel()
 
-	// Gorm对象
-	model := db.Client.Model(modelInstance)
-
-	// 查询条件
-	model = template.BuildActionQuery(ctx, model)
-
 	actions := template.Actions(ctx)
 	for _, v := range actions {
 		actionInstance := v.(types.Actioner)
@@ -36,48 +35,81 @@ func (p *ActionRequest) Handle(ctx *builder.Context) error {
 		// 初始化
 		actionInstance.Init(ctx)
 
-		// uri唯一标识
-		uriKey := actionInstance.GetUriKey(v)
-
 		// 获取行为类型
 		actionType := actionInstance.GetActionType()
 
 		if actionType == "dropdown" {
 			dropdownActioner := v.(types.Dropdowner)
 			for _, dropdownAction := range dropdownActioner.GetActions() {
-				uriKey := dropdownActioner.GetUriKey(dropdownAction)
-				if ctx.Param("uriKey") == uriKey {
-					result = dropdownAction.(interface {
-						Handle(*builder.Context, *gorm.DB) error
-					}).Handle(ctx, model)
-
-					// 执行完后回调
-					err := template.AfterAction(ctx, uriKey, model)
-					if err != nil {
-						return err
-					}
-
-					return result
+				if ctx.Param("uriKey") == dropdownActioner.GetUriKey(dropdownAction) {
+					handler = dropdownAction
+					uriKey = dropdownActioner.GetUriKey(dropdownAction)
 				}
 			}
 		} else {
-			if ctx.Param("uriKey") == uriKey {
-				result = v.(interface {
-					Handle(*builder.Context, *gorm.DB) error
-				}).Handle(ctx, model)
-
-				// 执行完后回调
-				err := template.AfterAction(ctx, uriKey, model)
-				if err != nil {
-					return err
-				}
-
-				return result
+			if ctx.Param("uriKey") == actionInstance.GetUriKey(v) {
+				handler = v
+				uriKey = actionInstance.GetUriKey(v)
 			}
 		}
+
+		if handler != nil {
+			break
+		}
+	}
+
+	if handler == nil {
+		return nil
+	}
+
+	// 在事务中执行行为，行为或回调返回错误时回滚
+	err := ctx.BufferResponse(func() error {
+		return db.Transaction(func(tx *gorm.DB) error {
+
+			// 执行前的记录
+			ids := []string{}
+			if id, ok := ctx.Query("id", "").(string); ok && id != "" {
+				ids = strings.Split(id, ",")
+			}
+			before, err := auditSnapshot(tx.Model(modelInstance), ids)
+			if err != nil {
+				return err
+			}
+
+			// 查询条件
+			model := template.BuildActionQuery(ctx, tx.Model(modelInstance))
+
+			// 执行行为
+			err = handler.(interface {
+				Handle(*builder.Context, *gorm.DB) error
+			}).Handle(ctx, model)
+			if err != nil {
+				return err
+			}
+
+			// 记录审计日志
+			after, err := auditSnapshot(tx.Model(modelInstance), ids)
+			if err != nil {
+				return err
+			}
+			remark := ""
+			if getName, ok := handler.(interface{ GetName() string }); ok {
+				remark = getName.GetName()
+			}
+			err = auditLog(ctx, tx, uriKey, remark, ids, before, after)
+			if err != nil {
+				return err
+			}
+
+			// 执行完后回调
+			return template.AfterAction(ctx, tx, uriKey, model)
+		})
+	})
+	if err != nil {
+		return ctx.JSON(200, message.Error(err.Error()))
 	}
 
-	return result
+	return nil
 }
 
 // 行为表单值
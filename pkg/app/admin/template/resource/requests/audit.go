@@ -0,0 +1,80 @@
+package requests
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/model"
+	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
+	"gorm.io/gorm"
+)
+
+// 获取记录快照，以记录id为键，query为已限定数据范围的查询
+func auditSnapshot(query *gorm.DB, ids []string) (map[string]map[string]interface{}, error) {
+	snapshot := map[string]map[string]interface{}{}
+	if len(ids) == 0 {
+		return snapshot, nil
+	}
+
+	lists := []map[string]interface{}{}
+	err := query.Where("id IN ?", ids).Find(&lists).Error
+	if err != nil {
+		return snapshot, err
+	}
+
+	for _, v := range lists {
+		snapshot[fmt.Sprint(v["id"])] = v
+	}
+
+	return snapshot, nil
+}
+
+// 记录操作审计日志，更新中间件记录的操作日志，未记录时新增
+func auditLog(ctx *builder.Context, tx *gorm.DB, uriKey string, remark string, ids []string, before map[string]map[string]interface{}, after map[string]map[string]interface{}) error {
+	diff, err := json.Marshal((&model.ActionLog{}).Compare(before, after))
+	if err != nil {
+		return err
+	}
+
+	// 批量操作时记录id过长则截断
+	recordId := strings.Join(ids, ",")
+	if len(recordId) > 500 {
+		end := strings.LastIndex(recordId[:497], ",")
+		if end < 0 {
+			end = 497
+		}
+		recordId = recordId[:end] + "..."
+	}
+
+	actionLog := map[string]interface{}{
+		"method":    ctx.Method(),
+		"resource":  ctx.Param("resource"),
+		"uri_key":   uriKey,
+		"record_id": recordId,
+		"diff":      string(diff),
+		"remark":    remark,
+	}
+
+	if id, ok := ctx.Get("action_log_id").(int); ok && id > 0 {
+		return tx.Model(&model.ActionLog{}).Where("id = ?", id).Updates(actionLog).Error
+	}
+
+	adminInfo, err := (&model.Admin{}).GetAuthUser(ctx.Engine.GetConfig().AppKey, ctx.Token())
+	if err != nil {
+		return err
+	}
+
+	return tx.Create(&model.ActionLog{
+		ObjectId: adminInfo.Id,
+		Url:      ctx.Path(),
+		Ip:       ctx.ClientIP(),
+		Type:     "admin",
+		Method:   ctx.Method(),
+		Resource: ctx.Param("resource"),
+		UriKey:   uriKey,
+		RecordId: recordId,
+		Diff:     string(diff),
+		Remark:   remark,
+	}).Error
+}
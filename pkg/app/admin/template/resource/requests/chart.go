@@ -0,0 +1,42 @@
+package requests
+
+import (
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/message"
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/template/resource/types"
+	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
+	"github.com/quarkcloudio/quark-go/v2/pkg/dal/db"
+)
+
+type ChartRequest struct{}
+
+// 获取图表数据
+func (p *ChartRequest) Handle(ctx *builder.Context) error {
+
+	// 模版实例
+	template := ctx.Template.(types.Resourcer)
+
+	// 模型结构体
+	modelInstance := template.GetModel()
+
+	for _, v := range template.Charts(ctx) {
+		chartInstance := v.(types.Charter)
+		if ctx.Param("uriKey") != chartInstance.GetUriKey(v) {
+			continue
+		}
+
+		// 初始化
+		chartInstance.Init(ctx)
+
+		// 查询条件
+		query := template.BuildChartQuery(ctx, db.Client.Model(modelInstance))
+
+		data, err := chartInstance.Handle(ctx, query)
+		if err != nil {
+			return ctx.JSON(200, message.Error(err.Error()))
+		}
+
+		return ctx.JSON(200, data)
+	}
+
+	return ctx.JSON(200, message.Error("图表不存在"))
+}
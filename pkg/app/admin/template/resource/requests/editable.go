@@ -1,10 +1,14 @@
 package requests
 
 import (
+	"errors"
+	"fmt"
+
 	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/message"
 	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/template/resource/types"
 	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
 	"github.com/quarkcloudio/quark-go/v2/pkg/dal/db"
+	"gorm.io/gorm"
 )
 
 type EditableRequest struct{}
@@ -34,9 +38,6 @@ func (p *EditableRequest) Handle(ctx *builder.Context) error {
 	// 获取模型结构体
 	modelInstance := template.GetModel()
 
-	// 创建Gorm对象
-	model := db.Client.Model(&modelInstance)
-
 	// 解析数据
 	for k, v := range data {
 		if v == "true" {
@@ -59,19 +60,58 @@ func (p *EditableRequest) Handle(ctx *builder.Context) error {
 		return ctx.JSON(200, message.Error("参数错误！"))
 	}
 
-	// 创建表格行内编辑查询
-	query := template.BuildEditableQuery(ctx, model)
-
-	// 更新数据
-	err := query.Update(field, value).Error
+	// 验证字段权限
+	err := template.ValidatorForFields(ctx, map[string]interface{}{field: value})
 	if err != nil {
 		return ctx.JSON(200, message.Error(err.Error()))
 	}
 
-	// 行为执行后回调
-	result := template.AfterEditable(ctx, id, field, value)
-	if result != nil {
-		return result
+	// 不具有全部数据权限时不允许修改数据权限字段
+	if len(template.StripDataScope(ctx, map[string]interface{}{field: value})) == 0 {
+		return ctx.JSON(200, message.Error("无权修改该字段！"))
+	}
+
+	// 在事务中更新数据，回调返回错误时回滚
+	err = ctx.BufferResponse(func() error {
+		return db.Transaction(func(tx *gorm.DB) error {
+
+			// 修改前的记录
+			ids := []string{fmt.Sprint(id)}
+			before, err := auditSnapshot(template.BuildEditableQuery(ctx, tx.Model(modelInstance)), ids)
+			if err != nil {
+				return err
+			}
+
+			// 数据权限范围外的记录不允许修改
+			if len(before) == 0 {
+				return errors.New("数据不存在或无权操作")
+			}
+
+			// 创建表格行内编辑查询
+			query := template.BuildEditableQuery(ctx, tx.Model(modelInstance))
+
+			// 更新数据
+			err = query.Update(field, value).Error
+			if err != nil {
+				return err
+			}
+
+			// 记录审计日志
+			after, err := auditSnapshot(template.BuildEditableQuery(ctx, tx.Model(modelInstance)), ids)
+			if err != nil {
+				return err
+			}
+			err = auditLog(ctx, tx, "editable", "行内编辑", ids, before, after)
+			if err != nil {
+				return err
+			}
+
+			// 行为执行后回调
+			return template.AfterEditable(ctx, tx, id, field, value)
+		})
+	})
+	if err != nil {
+		return ctx.JSON(200, message.Error(err.Error()))
 	}
 
 	return ctx.JSON(200, message.Success("操作成功"))
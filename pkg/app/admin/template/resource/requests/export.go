@@ -1,108 +1,394 @@
 package requests
 
 import (
+	"context"
 	"encoding/json"
+	"io"
+	"os"
 	"reflect"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/message"
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/model"
 	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/template/resource/types"
 	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
 	"github.com/quarkcloudio/quark-go/v2/pkg/dal/db"
-	"github.com/xuri/excelize/v2"
+	"github.com/quarkcloudio/quark-go/v2/pkg/storage"
+	"github.com/quarkcloudio/quark-go/v2/pkg/utils/rand"
+	"gorm.io/gorm"
 )
 
 type ExportRequest struct{}
 
+// 导出时默认每批处理的数据条数
+const defaultExportBatchSize = 1000
+
+// 后台导出文件的默认保存目录，不在WEB根目录下，只能通过下载接口访问
+const exportSavePath = "./storage/exports/"
+
+// 导出行写入接口
+type exportRowWriter interface {
+
+	// 写入一行数据
+	WriteRow(values []interface{}) error
+
+	// 完成写入
+	Close() error
+}
+
 // 执行行为
 func (p *ExportRequest) Handle(ctx *builder.Context) error {
 	template := ctx.Template.(types.Resourcer)
 
-	data := p.QueryData(ctx)
+	format := p.format(ctx)
+	if template.GetExportAsync() || ctx.Query("async", "") == "1" {
+		return p.handleAsync(ctx, format)
+	}
 
-	// 获取列表字段
-	fields := template.ExportFields(ctx)
+	contentType := "application/octet-stream"
+	if format == "csv" {
+		contentType = "text/csv; charset=utf-8"
+	}
 
-	f := excelize.NewFile()
-	index, _ := f.NewSheet("Sheet1")
-	rowData := map[string]interface{}{}
+	ctx.Writer.Header().Set("Content-Disposition", "attachment; filename="+p.fileName(format))
+	ctx.Writer.Header().Set("Content-Type", contentType)
 
-	for fieldKey, fieldValue := range fields.([]interface{}) {
-		Label := reflect.
-			ValueOf(fieldValue).
-			Elem().
-			FieldByName("Label").
-			String()
-		f.SetCellValue("Sheet1", p.generateColumnLabel(fieldKey+1)+"1", Label)
+	fields, query := p.prepare(ctx)
+
+	return p.write(ctx.Writer, format, fields, query, p.batchSize(template), func(list []map[string]interface{}) []interface{} {
+		return template.BeforeExporting(ctx, p.formatList(template, fields, list))
+	})
+}
+
+// 后台导出，导出完成后生成附件记录
+func (p *ExportRequest) handleAsync(ctx *builder.Context, format string) error {
+	adminInfo, err := (&model.Admin{}).GetAuthUser(ctx.Engine.GetConfig().AppKey, ctx.Token())
+	if err != nil {
+		return ctx.JSON(200, message.Error(err.Error()))
 	}
 
-	for dataKey, dataValue := range data.([]interface{}) {
-		for filedKey, field := range fields.([]interface{}) {
+	// 在请求内准备导出所需的全部数据，后台任务使用上下文及模板实例的副本，字段回调不会读到其他请求的数据
+	taskCtx := p.detach(ctx)
+	template := taskCtx.Template.(types.Resourcer)
+	fields, query := p.prepare(taskCtx)
+	query = query.Session(&gorm.Session{Context: context.Background()})
+	batchSize := p.batchSize(template)
+	config := p.storageConfig(template)
+	config.SaveName = rand.MakeAlphanumeric(40) + "." + format
+	name := p.fileName(format)
+	downloadPath := "/api/admin/" + ctx.Param("resource") + "/export/download"
+	adminId := adminInfo.Id
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				builder.GetLogger().Errorf("export %s panic: %v", name, r)
+			}
+		}()
+
+		err := p.saveFile(config, format, name, downloadPath, adminId, func(w io.Writer) error {
+			return p.write(w, format, fields, query, batchSize, func(list []map[string]interface{}) []interface{} {
+				return template.BeforeExporting(taskCtx, p.formatList(template, fields, list))
+			})
+		})
+		if err != nil {
+			builder.GetLogger().Errorf("export %s failed: %v", name, err)
+		}
+	}()
 
-			name := reflect.
-				ValueOf(field).
-				Elem().
-				FieldByName("Name").
-				String()
+	return ctx.JSON(200, message.Success("导出任务已提交，完成后可在附件中下载"))
+}
 
-			component := reflect.
-				ValueOf(field).
-				Elem().
-				FieldByName("Component").
-				String()
+// 复制请求上下文及模板实例，供后台任务使用，副本不能写入响应
+func (p *ExportRequest) detach(ctx *builder.Context) *builder.Context {
+	template := reflect.ValueOf(ctx.Template)
+	instance := reflect.New(template.Elem().Type())
+	instance.Elem().Set(template.Elem())
+
+	taskCtx := *ctx
+	taskCtx.Template = instance.Interface()
+
+	return &taskCtx
+}
+
+// 下载后台导出的文件，只能下载当前管理员导出的文件
+func (p *ExportRequest) Download(ctx *builder.Context) error {
+	template := ctx.Template.(types.Resourcer)
+
+	adminInfo, err := (&model.Admin{}).GetAuthUser(ctx.Engine.GetConfig().AppKey, ctx.Token())
+	if err != nil {
+		return ctx.JSON(200, message.Error(err.Error()))
+	}
+
+	config := p.storageConfig(template)
+	fileInfo := model.File{}
+	err = db.Client.
+		Where("id = ?", ctx.Query("id", "")).
+		Where("obj_type = ?", "ADMINID").
+		Where("obj_id = ?", adminInfo.Id).
+		Where("status = ?", 1).
+		Limit(1).
+		Find(&fileInfo).Error
+	if err != nil {
+		return ctx.JSON(200, message.Error(err.Error()))
+	}
+	if fileInfo.Id == 0 || !strings.HasPrefix(fileInfo.Path, p.savePathPrefix(template)) {
+		return ctx.JSON(200, message.Error("文件不存在"))
+	}
+
+	reader, err := storage.New(config).Get(fileInfo.Path)
+	if err != nil {
+		return ctx.JSON(200, message.Error(err.Error()))
+	}
+	defer reader.Close()
+
+	contentType := "application/octet-stream"
+	if fileInfo.Ext == "csv" {
+		contentType = "text/csv; charset=utf-8"
+	}
+	ctx.Writer.Header().Set("Content-Disposition", "attachment; filename="+fileInfo.Name)
+
+	return ctx.Stream(200, contentType, reader)
+}
+
+// 获取后台导出文件的保存路径前缀
+func (p *ExportRequest) savePathPrefix(template types.Resourcer) string {
+	if exportStorage := template.GetExportStorage(); exportStorage != nil && exportStorage.SavePath != "" {
+		return exportStorage.SavePath
+	}
+
+	return exportSavePath
+}
+
+// 获取后台导出文件的存储配置，未设置保存路径时按日期保存到本地导出目录
+func (p *ExportRequest) storageConfig(template types.Resourcer) *storage.Config {
+	config := &storage.Config{}
+	if exportStorage := template.GetExportStorage(); exportStorage != nil {
+		*config = *exportStorage
+	}
+	if config.SavePath == "" {
+		config.SavePath = exportSavePath + time.Now().Format("20060102") + "/"
+	}
+
+	return config
+}
+
+// 导出到临时文件，保存到存储驱动后写入附件记录，附件地址为下载接口
+func (p *ExportRequest) saveFile(config *storage.Config, format string, name string, downloadPath string, adminId int, write func(w io.Writer) error) error {
+	f, err := os.CreateTemp("", "quark-export-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	err = write(f)
+	if err != nil {
+		return err
+	}
+
+	contentType := "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	if format == "csv" {
+		contentType = "text/csv"
+	}
+
+	fileSystem := storage.New(config).Reader(&storage.File{
+		Name:        name,
+		Content:     f,
+		ContentType: contentType,
+	})
+	defer fileSystem.Close()
+
+	fileInfo, err := fileSystem.Save()
+	if err != nil {
+		return err
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		file := &model.File{
+			ObjType: "ADMINID",
+			ObjId:   adminId,
+			Name:    fileInfo.Name,
+			Size:    fileInfo.Size,
+			Ext:     fileInfo.Ext,
+			Path:    fileInfo.Path,
+			Hash:    fileInfo.Hash,
+			Status:  1,
+		}
+		err := tx.Create(file).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Model(file).Update("url", downloadPath+"?id="+strconv.Itoa(file.Id)).Error
+	})
+}
+
+// 获取导出格式，优先使用format参数
+func (p *ExportRequest) format(ctx *builder.Context) string {
+	format := ctx.Query("format", "").(string)
+	if format == "" {
+		format = ctx.Template.(types.Resourcer).GetExportFormat()
+	}
+	if format != "csv" {
+		format = "xlsx"
+	}
+
+	return format
+}
+
+// 导出文件名称
+func (p *ExportRequest) fileName(format string) string {
+	return "data_" + time.Now().Format("20060102150405") + "." + format
+}
+
+// 解析导出字段及查询对象
+func (p *ExportRequest) prepare(ctx *builder.Context) ([]interface{}, *gorm.DB) {
+	template := ctx.Template.(types.Resourcer)
 
-			switch component {
-			case "inputNumberField":
-				rowData[name] = dataValue.(map[string]interface{})[name]
-			case "textField":
-				rowData[name] = dataValue.(map[string]interface{})[name]
-			case "selectField":
-				optionLabel := field.(interface {
-					GetOptionLabel(interface{}) string
-				}).GetOptionLabel(dataValue.(map[string]interface{})[name])
-
-				rowData[name] = optionLabel
-			case "checkboxField":
-				optionLabel := field.(interface {
-					GetOptionLabel(interface{}) string
-				}).GetOptionLabel(dataValue.(map[string]interface{})[name])
-
-				rowData[name] = optionLabel
-			case "radioField":
-				optionLabel := field.(interface {
-					GetOptionLabel(interface{}) string
-				}).GetOptionLabel(dataValue.(map[string]interface{})[name])
-
-				rowData[name] = optionLabel
-			case "switchField":
-				optionLabel := field.(interface {
-					GetOptionLabel(interface{}) interface{}
-				}).GetOptionLabel(dataValue.(map[string]interface{})[name])
-
-				rowData[name] = optionLabel
-			default:
-				rowData[name] = dataValue.(map[string]interface{})[name]
+	// 获取导出字段
+	fields, _ := template.ExportFields(ctx).([]interface{})
+
+	return fields, p.query(ctx)
+}
+
+// 分批读取数据并写入
+func (p *ExportRequest) write(w io.Writer, format string, fields []interface{}, query *gorm.DB, batchSize int, convert func(list []map[string]interface{}) []interface{}) error {
+	var rowWriter exportRowWriter
+
+	switch format {
+	case "csv":
+		rowWriter = newCsvRowWriter(w)
+	default:
+		xlsxWriter, err := newXlsxRowWriter(w)
+		if err != nil {
+			return err
+		}
+		rowWriter = xlsxWriter
+	}
+
+	// 表头
+	header := []interface{}{}
+	for _, field := range fields {
+		header = append(header, reflect.ValueOf(field).Elem().FieldByName("Label").String())
+	}
+	err := rowWriter.WriteRow(header)
+	if err != nil {
+		return err
+	}
+
+	// 写入一批数据
+	batch := []map[string]interface{}{}
+	flush := func() error {
+		for _, item := range convert(batch) {
+			data, ok := item.(map[string]interface{})
+			if !ok {
+				continue
 			}
+			err := rowWriter.WriteRow(p.rowValues(fields, data))
+			if err != nil {
+				return err
+			}
+		}
+		batch = batch[:0]
+
+		return nil
+	}
 
-			f.SetCellValue("Sheet1", p.generateColumnLabel(filedKey+1)+strconv.Itoa(dataKey+2), rowData[name])
+	// 使用游标逐行读取，避免一次性加载全部数据
+	rows, err := query.Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		item := map[string]interface{}{}
+		err = query.ScanRows(rows, &item)
+		if err != nil {
+			return err
 		}
+
+		batch = append(batch, item)
+		if len(batch) >= batchSize {
+			err = flush()
+			if err != nil {
+				return err
+			}
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return err
+	}
+
+	err = flush()
+	if err != nil {
+		return err
+	}
+
+	return rowWriter.Close()
+}
+
+// 获取每批处理的数据条数
+func (p *ExportRequest) batchSize(template types.Resourcer) int {
+	batchSize := template.GetExportBatchSize()
+	if batchSize <= 0 {
+		batchSize = defaultExportBatchSize
 	}
 
-	f.SetActiveSheet(index)
-	buf, _ := f.WriteToBuffer()
+	return batchSize
+}
+
+// 获取一行导出数据
+func (p *ExportRequest) rowValues(fields []interface{}, data map[string]interface{}) []interface{} {
+	values := []interface{}{}
+
+	for _, field := range fields {
+		name := reflect.
+			ValueOf(field).
+			Elem().
+			FieldByName("Name").
+			String()
+
+		component := reflect.
+			ValueOf(field).
+			Elem().
+			FieldByName("Component").
+			String()
 
-	ctx.Writer.Header().Set("Content-Disposition", "attachment; filename=data_"+time.Now().Format("20060102150405")+".xlsx")
-	ctx.Writer.Header().Set("Content-Type", "application/octet-stream")
-	ctx.Writer.Write(buf.Bytes())
+		switch component {
+		case "selectField", "checkboxField", "radioField":
+			values = append(values, field.(interface {
+				GetOptionLabel(interface{}) string
+			}).GetOptionLabel(data[name]))
+		case "switchField":
+			values = append(values, field.(interface {
+				GetOptionLabel(interface{}) interface{}
+			}).GetOptionLabel(data[name]))
+		default:
+			values = append(values, data[name])
+		}
+	}
 
-	return nil
+	return values
 }
 
 // 列表查询
 func (p *ExportRequest) QueryData(ctx *builder.Context) interface{} {
 	var lists []map[string]interface{}
 
+	// 查询数据
+	p.query(ctx).Find(&lists)
+
+	// 返回解析数据
+	return p.performsList(ctx, lists)
+}
+
+// 创建导出查询对象，包含当前的搜索、筛选及排序条件
+func (p *ExportRequest) query(ctx *builder.Context) *gorm.DB {
+
 	// 模版实例
 	template := ctx.Template.(types.Resourcer)
 
@@ -119,13 +405,22 @@ func (p *ExportRequest) QueryData(ctx *builder.Context) interface{} {
 	filters := template.Filters(ctx)
 
 	// 创建查询对象
-	query := template.BuildExportQuery(ctx, model, searches, filters, p.columnFilters(ctx), p.orderings(ctx))
+	return template.BuildExportQuery(ctx, model, searches, filters, p.filterValues(ctx), p.columnFilters(ctx), p.orderings(ctx))
+}
 
-	// 查询数据
-	query.Find(&lists)
+// Get the filter values for the request.
+func (p *ExportRequest) filterValues(ctx *builder.Context) map[string]interface{} {
+	querys := ctx.AllQuerys()
+	var data map[string]interface{}
+	if querys["filters"] == nil {
+		return data
+	}
+	err := json.Unmarshal([]byte(querys["filters"].(string)), &data)
+	if err != nil {
+		return data
+	}
 
-	// 返回解析数据
-	return p.performsList(ctx, lists)
+	return data
 }
 
 // Get the column filters for the request.
@@ -162,13 +457,20 @@ func (p *ExportRequest) orderings(ctx *builder.Context) map[string]interface{} {
 
 // 处理列表
 func (p *ExportRequest) performsList(ctx *builder.Context, lists []map[string]interface{}) []interface{} {
-	result := []map[string]interface{}{}
 
 	// 模版实例
 	template := ctx.Template.(types.Resourcer)
 
 	// 获取字段
-	exportFields := template.ExportFields(ctx)
+	exportFields, _ := template.ExportFields(ctx).([]interface{})
+
+	// 导出前回调
+	return template.BeforeExporting(ctx, p.formatList(template, exportFields, lists))
+}
+
+// 按导出字段格式化列表数据
+func (p *ExportRequest) formatList(template types.Resourcer, exportFields []interface{}, lists []map[string]interface{}) []map[string]interface{} {
+	result := []map[string]interface{}{}
 
 	// 解析字段
 	for _, v := range lists {
@@ -177,7 +479,7 @@ func (p *ExportRequest) performsList(ctx *builder.Context, lists []map[string]in
 		template.SetField(v)
 
 		fields := make(map[string]interface{})
-		for _, field := range exportFields.([]interface{}) {
+		for _, field := range exportFields {
 
 			// 字段名
 			name := reflect.
@@ -229,18 +531,5 @@ func (p *ExportRequest) performsList(ctx *builder.Context, lists []map[string]in
 		result = append(result, fields)
 	}
 
-	// 导出前回调
-	return template.BeforeExporting(ctx, result)
-}
-
-// 生成 Excel 中的列标签
-func (p *ExportRequest) generateColumnLabel(index int) (colLabel string) {
-
-	for index > 0 {
-		mod := (index - 1) % 26
-		colLabel = string(rune('A'+mod)) + colLabel
-		index = (index - 1) / 26
-	}
-
-	return colLabel
+	return result
 }
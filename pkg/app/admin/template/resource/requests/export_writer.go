@@ -0,0 +1,89 @@
+package requests
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// xlsx格式逐行写入，使用StreamWriter避免在内存中保存全部单元格
+type xlsxRowWriter struct {
+	file   *excelize.File
+	stream *excelize.StreamWriter
+	writer io.Writer
+	row    int
+}
+
+// 初始化xlsx写入
+func newXlsxRowWriter(w io.Writer) (*xlsxRowWriter, error) {
+	file := excelize.NewFile()
+	stream, err := file.NewStreamWriter("Sheet1")
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	return &xlsxRowWriter{file: file, stream: stream, writer: w}, nil
+}
+
+// 写入一行数据
+func (p *xlsxRowWriter) WriteRow(values []interface{}) error {
+	p.row++
+	cell, err := excelize.CoordinatesToCellName(1, p.row)
+	if err != nil {
+		return err
+	}
+
+	return p.stream.SetRow(cell, values)
+}
+
+// 完成写入
+func (p *xlsxRowWriter) Close() error {
+	defer p.file.Close()
+
+	err := p.stream.Flush()
+	if err != nil {
+		return err
+	}
+
+	return p.file.Write(p.writer)
+}
+
+// csv格式逐行写入
+type csvRowWriter struct {
+	writer *csv.Writer
+}
+
+// 初始化csv写入，写入UTF-8 BOM以便Excel正确识别中文
+func newCsvRowWriter(w io.Writer) *csvRowWriter {
+	w.Write([]byte("\xEF\xBB\xBF"))
+
+	return &csvRowWriter{writer: csv.NewWriter(w)}
+}
+
+// 写入一行数据
+func (p *csvRowWriter) WriteRow(values []interface{}) error {
+	record := []string{}
+	for _, value := range values {
+		switch v := value.(type) {
+		case nil:
+			record = append(record, "")
+		case time.Time:
+			record = append(record, v.Format("2006-01-02 15:04:05"))
+		default:
+			record = append(record, fmt.Sprint(v))
+		}
+	}
+
+	return p.writer.Write(record)
+}
+
+// 完成写入
+func (p *csvRowWriter) Close() error {
+	p.writer.Flush()
+
+	return p.writer.Error()
+}
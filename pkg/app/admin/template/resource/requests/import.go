@@ -17,6 +17,7 @@ import (
 	"github.com/quarkcloudio/quark-go/v2/pkg/utils/file"
 	"github.com/quarkcloudio/quark-go/v2/pkg/utils/rand"
 	"github.com/xuri/excelize/v2"
+	"gorm.io/gorm"
 )
 
 type ImportRequest struct{}
@@ -57,9 +58,6 @@ func (p *ImportRequest) Handle(ctx *builder.Context, indexRoute string) error {
 	// 获取模型结构体
 	modelInstance := template.GetModel()
 
-	// 创建Gorm对象
-	model := db.Client.Model(modelInstance)
-
 	// 获取导入数据
 	importData, err := (&models.File{}).GetExcelData(fileId)
 	if err != nil {
@@ -102,36 +100,31 @@ func (p *ImportRequest) Handle(ctx *builder.Context, indexRoute string) error {
 			continue
 		}
 
-		// 验证保存前回调条件
-		submitData, err := template.BeforeSaving(ctx, formValues)
-		if err != nil {
-			importResult = false
-			importFailedNum = importFailedNum + 1
-			item = append(item, err.Error())
-			importFailedData = append(importFailedData, item)
+		// 在事务中保存数据，任一回调返回错误时回滚
+		err := db.Transaction(func(tx *gorm.DB) error {
 
-			// 跳出本次循环
-			continue
-		}
+			// 保存前回调
+			submitData, err := template.BeforeSaving(ctx, tx, formValues)
+			if err != nil {
+				return err
+			}
 
-		// 插入数据库
-		data := p.getSubmitData(fields, submitData)
-		result := model.Create(data)
-		if result.Error != nil {
-			importResult = false
-			importFailedNum = importFailedNum + 1
-			item = append(item, result.Error.Error())
-			importFailedData = append(importFailedData, item)
+			// 插入数据库
+			data := p.getSubmitData(fields, submitData)
 
-			// 跳出本次循环
-			continue
-		}
+			// 填充数据权限字段
+			data = template.FillDataScope(ctx, data)
+			err = tx.Model(modelInstance).Create(data).Error
+			if err != nil {
+				return err
+			}
 
-		getLastData := map[string]interface{}{}
-		model.Order("id desc").First(&getLastData)
+			getLastData := map[string]interface{}{}
+			tx.Model(modelInstance).Order("id desc").First(&getLastData)
 
-		// 保存后回调
-		err = template.AfterSaved(ctx, getLastData["id"].(int), data, result)
+			// 保存后回调
+			return template.AfterSaved(ctx, tx, getLastData["id"].(int), data)
+		})
 		if err != nil {
 			importResult = false
 			importFailedNum = importFailedNum + 1
@@ -149,7 +142,7 @@ func (p *ImportRequest) Handle(ctx *builder.Context, indexRoute string) error {
 	if !importResult {
 		filePath := ctx.Engine.GetConfig().StaticPath + "/app/storage/failImports/"
 		fileName := rand.MakeAlphanumeric(40) + ".xlsx"
-		fileUrl := "//" + ctx.Host() + "/storage/failImports/" + fileName
+		fileUrl := "//" + ctx.Host() + ctx.Engine.RoutePath("/storage/failImports/"+fileName)
 
 		// 不存在路径，则创建
 		if !file.IsExist(filePath) {
@@ -26,10 +26,10 @@ func (p *IndexRequest) QueryData(ctx *builder.Context) interface{} {
 	// 搜索项
 	searches := template.Searches(ctx)
 
-	// 过滤项，预留
+	// 过滤项
 	filters := template.Filters(ctx)
 
-	query := template.BuildIndexQuery(ctx, model, searches, filters, p.columnFilters(ctx), p.orderings(ctx))
+	query := template.BuildIndexQuery(ctx, model, searches, filters, p.filterValues(ctx), p.columnFilters(ctx), p.orderings(ctx))
 
 	// 获取分页
 	perPage := template.GetPerPage()
@@ -48,9 +48,42 @@ func (p *IndexRequest) QueryData(ctx *builder.Context) interface{} {
 		return p.performsList(ctx, lists)
 	}
 
+	page, pageSize, cursor := p.pageParams(ctx, perPage.(int))
+
+	// 游标分页
+	if template.GetPaginationMode() == "cursor" {
+		return p.cursorPaginate(ctx, query, pageSize, cursor)
+	}
+
+	// 不统计精确总数时，多查询一条用于判断是否有下一页
+	if countMode := template.GetCountMode(); countMode == "none" || countMode == "estimate" {
+		return p.simplePaginate(ctx, query, page, pageSize)
+	}
+
 	var total int64
+
+	// 获取总数量
+	query.Count(&total)
+
+	// 获取列表
+	query.Limit(pageSize).Offset((page - 1) * pageSize).Find(&lists)
+
+	// 解析列表
+	result := p.performsList(ctx, lists)
+
+	return map[string]interface{}{
+		"currentPage": page,
+		"perPage":     pageSize,
+		"total":       total,
+		"items":       result,
+	}
+}
+
+// 获取请求中的页码、每页条数及游标
+func (p *IndexRequest) pageParams(ctx *builder.Context, perPage int) (int, int, string) {
 	var data map[string]interface{}
 	page := 1
+	cursor := ""
 	querys := ctx.AllQuerys()
 	if querys["search"] != nil {
 		err := json.Unmarshal([]byte(querys["search"].(string)), &data)
@@ -61,24 +94,31 @@ func (p *IndexRequest) QueryData(ctx *builder.Context) interface{} {
 			if data["pageSize"] != nil {
 				perPage = int(data["pageSize"].(float64))
 			}
+			if value, ok := data["cursor"].(string); ok {
+				cursor = value
+			}
 		}
 	}
+	if cursor == "" {
+		cursor = ctx.Query("cursor", "").(string)
+	}
 
-	// 获取总数量
-	query.Count(&total)
-
-	// 获取列表
-	query.Limit(perPage.(int)).Offset((page - 1) * perPage.(int)).Find(&lists)
-
-	// 解析列表
-	result := p.performsList(ctx, lists)
+	return page, perPage, cursor
+}
 
-	return map[string]interface{}{
-		"currentPage": page,
-		"perPage":     perPage,
-		"total":       total,
-		"items":       result,
+// Get the filter values for the request.
+func (p *IndexRequest) filterValues(ctx *builder.Context) map[string]interface{} {
+	querys := ctx.AllQuerys()
+	var data map[string]interface{}
+	if querys["filters"] == nil {
+		return data
 	}
+	err := json.Unmarshal([]byte(querys["filters"].(string)), &data)
+	if err != nil {
+		return data
+	}
+
+	return data
 }
 
 // Get the column filters for the request.
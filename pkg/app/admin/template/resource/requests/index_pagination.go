@@ -0,0 +1,232 @@
+package requests
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"time"
+
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/template/resource/types"
+	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
+	"github.com/quarkcloudio/quark-go/v2/pkg/dal/db"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// 游标分页的游标，按游标字段和主键定位一条记录
+type indexCursor struct {
+	Value     interface{} `json:"v"`
+	Id        interface{} `json:"i,omitempty"`
+	Direction string      `json:"d"`
+}
+
+// 不统计精确总数的页码分页
+func (p *IndexRequest) simplePaginate(ctx *builder.Context, query *gorm.DB, page int, pageSize int) interface{} {
+	var lists []map[string]interface{}
+
+	template := ctx.Template.(types.Resourcer)
+
+	// 多查询一条用于判断是否有下一页
+	query.Limit(pageSize + 1).Offset((page - 1) * pageSize).Find(&lists)
+
+	hasMore := len(lists) > pageSize
+	if hasMore {
+		lists = lists[:pageSize]
+	}
+
+	// 根据已查询的数据推算总数，有下一页时多算一条，以便前端显示下一页
+	total := int64((page-1)*pageSize + len(lists))
+	if hasMore {
+		total++
+
+		// 使用估算的总数，估算值只在未到最后一页时使用
+		if template.GetCountMode() == "estimate" {
+			if estimate := p.estimateTotal(ctx); estimate > total {
+				total = estimate
+			}
+		}
+	}
+
+	return map[string]interface{}{
+		"currentPage": page,
+		"perPage":     pageSize,
+		"total":       total,
+		"hasMore":     hasMore,
+		"items":       p.performsList(ctx, lists),
+	}
+}
+
+// 游标分页，按游标字段和主键倒序排列，返回上一页和下一页的游标
+func (p *IndexRequest) cursorPaginate(ctx *builder.Context, query *gorm.DB, pageSize int, cursor string) interface{} {
+	var lists []map[string]interface{}
+
+	template := ctx.Template.(types.Resourcer)
+
+	column := template.GetCursorColumn()
+	if column == "" {
+		column = "id"
+	}
+
+	// 获取总数量，不统计时为-1
+	total := int64(-1)
+	switch template.GetCountMode() {
+	case "none":
+	case "estimate":
+		total = p.estimateTotal(ctx)
+	default:
+		query.Session(&gorm.Session{}).Count(&total)
+	}
+
+	current := p.decodeCursor(cursor)
+	backward := current != nil && current.Direction == "prev"
+
+	// 按游标定位，向前翻页时正序查询后再反转
+	pageQuery := query.Session(&gorm.Session{})
+	if current != nil {
+		pageQuery = pageQuery.Where(p.cursorCondition(column, current, backward))
+	}
+	pageQuery = pageQuery.Order(clause.OrderByColumn{Column: clause.Column{Name: column}, Desc: !backward})
+	if column != "id" {
+		pageQuery = pageQuery.Order(clause.OrderByColumn{Column: clause.Column{Name: "id"}, Desc: !backward})
+	}
+	pageQuery.Limit(pageSize + 1).Find(&lists)
+
+	hasMore := len(lists) > pageSize
+	if hasMore {
+		lists = lists[:pageSize]
+	}
+
+	hasPrev, hasNext := current != nil, hasMore
+	if backward {
+		for i, j := 0, len(lists)-1; i < j; i, j = i+1, j-1 {
+			lists[i], lists[j] = lists[j], lists[i]
+		}
+		hasPrev, hasNext = hasMore, true
+	}
+
+	prevCursor, nextCursor := "", ""
+	if len(lists) > 0 {
+		if hasPrev {
+			prevCursor = p.encodeCursor(column, lists[0], "prev")
+		}
+		if hasNext {
+			nextCursor = p.encodeCursor(column, lists[len(lists)-1], "next")
+		}
+	}
+
+	return map[string]interface{}{
+		"perPage":    pageSize,
+		"total":      total,
+		"hasMore":    hasNext,
+		"prevCursor": prevCursor,
+		"nextCursor": nextCursor,
+		"items":      p.performsList(ctx, lists),
+	}
+}
+
+// 游标的查询条件，字段不唯一时使用主键区分值相同的记录
+func (p *IndexRequest) cursorCondition(column string, cursor *indexCursor, backward bool) clause.Expression {
+	compare := func(column string, value interface{}) clause.Expression {
+		if backward {
+			return clause.Gt{Column: column, Value: value}
+		}
+
+		return clause.Lt{Column: column, Value: value}
+	}
+
+	if column == "id" || cursor.Id == nil {
+		return compare(column, cursor.Value)
+	}
+
+	return clause.Or(
+		compare(column, cursor.Value),
+		clause.And(clause.Eq{Column: column, Value: cursor.Value}, compare("id", cursor.Id)),
+	)
+}
+
+// 根据记录生成游标
+func (p *IndexRequest) encodeCursor(column string, item map[string]interface{}, direction string) string {
+	cursor := indexCursor{Value: item[column], Direction: direction}
+	if column != "id" {
+		cursor.Id = item["id"]
+	}
+
+	data, err := json.Marshal(cursor)
+	if err != nil {
+		return ""
+	}
+
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// 解析游标，游标无效时返回nil，即从第一页开始查询
+func (p *IndexRequest) decodeCursor(cursor string) *indexCursor {
+	if cursor == "" {
+		return nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil
+	}
+
+	var result indexCursor
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&result); err != nil || result.Value == nil {
+		return nil
+	}
+
+	result.Value = p.cursorValue(result.Value)
+	result.Id = p.cursorValue(result.Id)
+
+	return &result
+}
+
+// 还原游标中的值，数字转为整数或浮点数，时间字符串转为时间
+func (p *IndexRequest) cursorValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		if f, err := v.Float64(); err == nil {
+			return f
+		}
+	case string:
+		if t, err := time.Parse(time.RFC3339Nano, v); err == nil {
+			return t
+		}
+	}
+
+	return value
+}
+
+// 按数据库的表统计信息估算总数，忽略查询条件，不支持时返回-1
+func (p *IndexRequest) estimateTotal(ctx *builder.Context) int64 {
+	template := ctx.Template.(types.Resourcer)
+
+	statement := &gorm.Statement{DB: db.Client}
+	if err := statement.Parse(template.GetModel()); err != nil {
+		return -1
+	}
+
+	var sql string
+	switch db.Client.Dialector.Name() {
+	case "mysql":
+		sql = "SELECT TABLE_ROWS FROM information_schema.TABLES WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ?"
+	case "postgres":
+		sql = "SELECT CAST(reltuples AS BIGINT) FROM pg_class WHERE relname = ?"
+	case "sqlserver":
+		sql = "SELECT SUM(rows) FROM sys.partitions WHERE object_id = OBJECT_ID(?) AND index_id IN (0, 1)"
+	default:
+		return -1
+	}
+
+	var estimate *int64
+	if err := db.Client.Raw(sql, statement.Schema.Table).Scan(&estimate).Error; err != nil || estimate == nil {
+		return -1
+	}
+
+	return *estimate
+}
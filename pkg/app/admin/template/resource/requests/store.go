@@ -2,7 +2,9 @@ package requests
 
 import (
 	"encoding/json"
+	"errors"
 	"reflect"
+	"strconv"
 
 	"github.com/gobeam/stringy"
 	"github.com/gookit/goutil/structs"
@@ -10,6 +12,7 @@ import (
 	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/template/resource/types"
 	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
 	"github.com/quarkcloudio/quark-go/v2/pkg/dal/db"
+	"gorm.io/gorm"
 )
 
 type StoreRequest struct{}
@@ -28,68 +31,106 @@ func (p *StoreRequest) Handle(ctx *builder.Context) error {
 	// 数据实例
 	dataInstance := template.GetModel()
 
+	// 验证字段权限
+	err := template.ValidatorForFields(ctx, data)
+	if err != nil {
+		return ctx.JSON(200, message.Error(err.Error()))
+	}
+
 	// 验证数据合法性
 	validator := template.ValidatorForCreation(ctx, data)
 	if validator != nil {
 		return ctx.JSON(200, message.Error(validator.Error()))
 	}
 
-	// 保存前回调
-	data, err := template.BeforeSaving(ctx, data)
+	// 在事务中保存数据，任一回调返回错误时回滚
+	err = ctx.BufferResponse(func() error {
+		return db.Transaction(func(tx *gorm.DB) error {
+
+			// 保存前回调
+			data, err := template.BeforeSaving(ctx, tx, data)
+			if err != nil {
+				return err
+			}
+
+			// 填充数据权限字段，在保存前回调之后执行，避免被回调覆盖
+			data = template.FillDataScope(ctx, data)
+
+			// 重组数据
+			newData := map[string]interface{}{}
+			for k, v := range data {
+				nv := v
+
+				// 将数组、map数据转换为字符串存储
+				if gv, ok := v.([]interface{}); ok {
+					nv, _ = json.Marshal(gv)
+				}
+				if gv, ok := v.([]map[string]interface{}); ok {
+					nv, _ = json.Marshal(gv)
+				}
+				if gv, ok := v.(map[string]interface{}); ok {
+					nv, _ = json.Marshal(gv)
+				}
+
+				camelCaseName := stringy.
+					New(k).
+					CamelCase("?", "")
+
+				fieldIsValid := reflect.
+					ValueOf(modelInstance).
+					Elem().
+					FieldByName(camelCaseName).
+					IsValid()
+				if fieldIsValid {
+					newData[k] = nv
+				}
+			}
+
+			// 结构体赋值
+			structs.SetValues(dataInstance, newData)
+
+			// 创建数据
+			err = tx.Model(modelInstance).Create(dataInstance).Error
+			if err != nil {
+				return err
+			}
+
+			// 因为gorm使用结构体，不更新零值，需要使用map更新零值
+			reflectId := reflect.
+				ValueOf(dataInstance).
+				Elem().
+				FieldByName("Id")
+			if !reflectId.IsValid() {
+				return errors.New("参数错误")
+			}
+
+			id := int(reflectId.Int())
+			err = tx.
+				Model(modelInstance).
+				Where("id = ?", id).
+				Updates(newData).Error
+			if err != nil {
+				return err
+			}
+
+			// 记录审计日志
+			ids := []string{strconv.Itoa(id)}
+			after, err := auditSnapshot(tx.Model(modelInstance), ids)
+			if err != nil {
+				return err
+			}
+			err = auditLog(ctx, tx, "store", "创建", ids, nil, after)
+			if err != nil {
+				return err
+			}
+
+			// 保存后回调
+			return template.AfterSaved(ctx, tx, id, data)
+		})
+	})
 	if err != nil {
 		return ctx.JSON(200, message.Error(err.Error()))
 	}
 
-	// 重组数据
-	newData := map[string]interface{}{}
-	for k, v := range data {
-		nv := v
-
-		// 将数组、map数据转换为字符串存储
-		if gv, ok := v.([]interface{}); ok {
-			nv, _ = json.Marshal(gv)
-		}
-		if gv, ok := v.([]map[string]interface{}); ok {
-			nv, _ = json.Marshal(gv)
-		}
-		if gv, ok := v.(map[string]interface{}); ok {
-			nv, _ = json.Marshal(gv)
-		}
-
-		camelCaseName := stringy.
-			New(k).
-			CamelCase("?", "")
-
-		fieldIsValid := reflect.
-			ValueOf(modelInstance).
-			Elem().
-			FieldByName(camelCaseName).
-			IsValid()
-		if fieldIsValid {
-			newData[k] = nv
-		}
-	}
-
-	// 结构体赋值
-	structs.SetValues(dataInstance, newData)
-
-	// 获取对象
-	model := db.Client.Model(modelInstance).Create(dataInstance)
-
-	// 因为gorm使用结构体，不更新零值，需要使用map更新零值
-	reflectId := reflect.
-		ValueOf(dataInstance).
-		Elem().
-		FieldByName("Id")
-	if !reflectId.IsValid() {
-		return ctx.JSON(200, message.Error("参数错误"))
-	}
-
-	id := int(reflectId.Int())
-	db.Client.
-		Model(&modelInstance).
-		Where("id = ?", id).
-		Updates(newData)
-
-	return template.AfterSaved(ctx, id, data, model)
+	return nil
 }
@@ -2,6 +2,8 @@ package requests
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"reflect"
 
 	"github.com/gobeam/stringy"
@@ -9,6 +11,7 @@ import (
 	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/template/resource/types"
 	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
 	"github.com/quarkcloudio/quark-go/v2/pkg/dal/db"
+	"gorm.io/gorm"
 )
 
 type UpdateRequest struct{}
@@ -34,56 +37,99 @@ func (p *UpdateRequest) Handle(ctx *builder.Context) error {
 	// 模型结构体
 	modelInstance := template.GetModel()
 
+	// 验证字段权限
+	err = template.ValidatorForFields(ctx, data)
+	if err != nil {
+		return ctx.JSON(200, message.Error(err.Error()))
+	}
+
 	// 验证数据合法性
 	validator := template.ValidatorForUpdate(ctx, data)
 	if validator != nil {
 		return ctx.JSON(200, message.Error(validator.Error()))
 	}
 
-	// 保存前回调
-	data, err = template.BeforeSaving(ctx, data)
+	// 在事务中保存数据，任一回调返回错误时回滚
+	err = ctx.BufferResponse(func() error {
+		return db.Transaction(func(tx *gorm.DB) error {
+
+			// 保存前回调
+			data, err := template.BeforeSaving(ctx, tx, data)
+			if err != nil {
+				return err
+			}
+
+			// 去除无权修改的数据权限字段，在保存前回调之后执行，避免被回调覆盖
+			data = template.StripDataScope(ctx, data)
+
+			// 重组数据
+			newData := map[string]interface{}{}
+			for k, v := range data {
+				nv := v
+
+				// 将数组、map数据转换为字符串存储
+				if gv, ok := v.([]interface{}); ok {
+					nv, _ = json.Marshal(gv)
+				}
+				if gv, ok := v.([]map[string]interface{}); ok {
+					nv, _ = json.Marshal(gv)
+				}
+				if gv, ok := v.(map[string]interface{}); ok {
+					nv, _ = json.Marshal(gv)
+				}
+
+				camelCaseName := stringy.
+					New(k).
+					CamelCase("?", "")
+
+				fieldIsValid := reflect.
+					ValueOf(modelInstance).
+					Elem().
+					FieldByName(camelCaseName).
+					IsValid()
+				if fieldIsValid {
+					newData[k] = nv
+				}
+			}
+
+			// 修改前的记录
+			ids := []string{fmt.Sprint(data["id"])}
+			before, err := auditSnapshot(template.BuildUpdateQuery(ctx, tx.Model(modelInstance)), ids)
+			if err != nil {
+				return err
+			}
+
+			// 数据权限范围外的记录不允许修改
+			if len(before) == 0 {
+				return errors.New("数据不存在或无权操作")
+			}
+
+			// 创建更新查询
+			query := template.BuildUpdateQuery(ctx, tx.Model(modelInstance))
+
+			// 更新数据
+			err = query.Updates(newData).Error
+			if err != nil {
+				return err
+			}
+
+			// 记录审计日志
+			after, err := auditSnapshot(template.BuildUpdateQuery(ctx, tx.Model(modelInstance)), ids)
+			if err != nil {
+				return err
+			}
+			err = auditLog(ctx, tx, "save", "编辑", ids, before, after)
+			if err != nil {
+				return err
+			}
+
+			// 保存后回调
+			return template.AfterSaved(ctx, tx, int(data["id"].(float64)), data)
+		})
+	})
 	if err != nil {
 		return ctx.JSON(200, message.Error(err.Error()))
 	}
 
-	// 重组数据
-	newData := map[string]interface{}{}
-	for k, v := range data {
-		nv := v
-
-		// 将数组、map数据转换为字符串存储
-		if gv, ok := v.([]interface{}); ok {
-			nv, _ = json.Marshal(gv)
-		}
-		if gv, ok := v.([]map[string]interface{}); ok {
-			nv, _ = json.Marshal(gv)
-		}
-		if gv, ok := v.(map[string]interface{}); ok {
-			nv, _ = json.Marshal(gv)
-		}
-
-		camelCaseName := stringy.
-			New(k).
-			CamelCase("?", "")
-
-		fieldIsValid := reflect.
-			ValueOf(modelInstance).
-			Elem().
-			FieldByName(camelCaseName).
-			IsValid()
-		if fieldIsValid {
-			newData[k] = nv
-		}
-	}
-
-	// 获取对象
-	model := db.Client.Model(modelInstance)
-
-	// 创建更新查询
-	query := template.BuildUpdateQuery(ctx, model)
-
-	// 更新数据
-	query = query.Updates(newData)
-
-	return template.AfterSaved(ctx, int(data["id"].(float64)), data, query)
+	return nil
 }
@@ -0,0 +1,31 @@
+package resource
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/template/resource/types"
+	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
+)
+
+// 图表组件渲染，图表数据通过独立的接口加载，可在列表页IndexTableExtraRender或详情页中使用
+func (p *Template) ChartComponentRender(ctx *builder.Context, item interface{}) interface{} {
+	chartInstance := item.(types.Charter)
+
+	// 初始化
+	chartInstance.Init(ctx)
+
+	return chartInstance.Component(p.BuildChartApi(ctx, chartInstance.GetUriKey(item)))
+}
+
+// 创建图表数据接口，在详情页中会携带当前记录的id
+func (p *Template) BuildChartApi(ctx *builder.Context, uriKey string) string {
+	api := strings.Replace(ChartPath, ":resource", ctx.Param("resource"), -1)
+	api = strings.Replace(api, ":uriKey", uriKey, -1)
+
+	if id, ok := ctx.Query("id", "").(string); ok && id != "" {
+		api = api + "?id=" + url.QueryEscape(id)
+	}
+
+	return api
+}
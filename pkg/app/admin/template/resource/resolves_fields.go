@@ -23,7 +23,7 @@ func (p *Template) IndexFields(ctx *builder.Context) interface{} {
 	fields := p.getFields(ctx)
 	for _, v := range fields.([]interface{}) {
 		if v, ok := v.(interface{ IsShownOnIndex() bool }); ok {
-			if v.IsShownOnIndex() {
+			if v.IsShownOnIndex() && p.FieldReadable(ctx, v) {
 				items = append(items, v)
 			}
 		}
@@ -289,7 +289,7 @@ func (p *Template) fieldToColumn(ctx *builder.Context, field interface{}) interf
 		column = column.SetValueType(component)
 	}
 
-	if editable {
+	if editable && p.FieldWritable(ctx, field) {
 
 		// 可编辑api地址
 		editableApi := strings.Replace(ctx.Path(), "/index", "/editable", -1)
@@ -308,7 +308,7 @@ func (p *Template) CreationFields(ctx *builder.Context) interface{} {
 	fields := p.getFields(ctx)
 	for _, v := range fields.([]interface{}) {
 		if v, ok := v.(interface{ IsShownOnCreation() bool }); ok {
-			if v.IsShownOnCreation() {
+			if v.IsShownOnCreation() && p.FieldWritable(ctx, v) {
 				items = append(items, v)
 			}
 		}
@@ -324,7 +324,7 @@ func (p *Template) CreationFieldsWithoutWhen(ctx *builder.Context) interface{} {
 	fields := p.getFieldsWithoutWhen(ctx)
 	for _, v := range fields.([]interface{}) {
 		if v, ok := v.(interface{ IsShownOnCreation() bool }); ok {
-			if v.IsShownOnCreation() {
+			if v.IsShownOnCreation() && p.FieldWritable(ctx, v) {
 				items = append(items, v)
 			}
 		}
@@ -390,7 +390,7 @@ func (p *Template) CreationFormFieldsParser(ctx *builder.Context, fields interfa
 
 					// 判断是否在创建页面
 					if v, ok := v.(interface{ IsShownOnCreation() bool }); ok {
-						if v.IsShownOnCreation() {
+						if v.IsShownOnCreation() && p.FieldWritable(ctx, v) {
 
 							// 生成前端验证规则
 							v.(interface{ BuildFrontendRules(string) interface{} }).BuildFrontendRules(ctx.Path())
@@ -416,7 +416,7 @@ func (p *Template) UpdateFields(ctx *builder.Context) interface{} {
 	fields := p.getFields(ctx)
 	for _, v := range fields.([]interface{}) {
 		if v, ok := v.(interface{ IsShownOnUpdate() bool }); ok {
-			if v.IsShownOnUpdate() {
+			if v.IsShownOnUpdate() && p.FieldReadable(ctx, v) && p.FieldWritable(ctx, v) {
 				items = append(items, v)
 			}
 		}
@@ -432,7 +432,7 @@ func (p *Template) UpdateFieldsWithoutWhen(ctx *builder.Context) interface{} {
 	fields := p.getFieldsWithoutWhen(ctx)
 	for _, v := range fields.([]interface{}) {
 		if v, ok := v.(interface{ IsShownOnUpdate() bool }); ok {
-			if v.IsShownOnUpdate() {
+			if v.IsShownOnUpdate() && p.FieldReadable(ctx, v) && p.FieldWritable(ctx, v) {
 				items = append(items, v)
 			}
 		}
@@ -498,7 +498,7 @@ func (p *Template) UpdateFormFieldsParser(ctx *builder.Context, fields interface
 
 					// 判断是否在编辑页面
 					if v, ok := v.(interface{ IsShownOnUpdate() bool }); ok {
-						if v.IsShownOnUpdate() {
+						if v.IsShownOnUpdate() && p.FieldReadable(ctx, v) && p.FieldWritable(ctx, v) {
 
 							// 生成前端验证规则
 							v.(interface{ BuildFrontendRules(string) interface{} }).BuildFrontendRules(ctx.Path())
@@ -571,7 +571,7 @@ func (p *Template) DetailFields(ctx *builder.Context) interface{} {
 	fields := p.getFields(ctx)
 	for _, v := range fields.([]interface{}) {
 		if v, ok := v.(interface{ IsShownOnDetail() bool }); ok {
-			if v.IsShownOnDetail() {
+			if v.IsShownOnDetail() && p.FieldReadable(ctx, v) {
 				items = append(items, v)
 			}
 		}
@@ -611,7 +611,7 @@ func (p *Template) DetailFieldsWithinComponents(ctx *builder.Context, data map[s
 			var subItems []interface{}
 			for _, sv := range body.([]interface{}) {
 				if sv, ok := sv.(interface{ IsShownOnDetail() bool }); ok {
-					if sv.IsShownOnDetail() {
+					if sv.IsShownOnDetail() && p.FieldReadable(ctx, sv) {
 						getColumn := p.fieldToColumn(ctx, sv)
 						subItems = append(subItems, getColumn)
 					}
@@ -633,7 +633,7 @@ func (p *Template) DetailFieldsWithinComponents(ctx *builder.Context, data map[s
 			items = append(items, v)
 		} else {
 			if v, ok := v.(interface{ IsShownOnDetail() bool }); ok {
-				if v.IsShownOnDetail() {
+				if v.IsShownOnDetail() && p.FieldReadable(ctx, v) {
 					getColumn := p.fieldToColumn(ctx, v)
 					if getColumn != nil {
 						items = append(items, getColumn)
@@ -666,7 +666,7 @@ func (p *Template) ExportFields(ctx *builder.Context) interface{} {
 	fields := p.getFields(ctx)
 	for _, v := range fields.([]interface{}) {
 		if v, ok := v.(interface{ IsShownOnExport() bool }); ok {
-			if v.IsShownOnExport() {
+			if v.IsShownOnExport() && p.FieldReadable(ctx, v) {
 				items = append(items, v)
 			}
 		}
@@ -682,7 +682,7 @@ func (p *Template) ImportFields(ctx *builder.Context) interface{} {
 	fields := p.getFields(ctx)
 	for _, v := range fields.([]interface{}) {
 		if v, ok := v.(interface{ IsShownOnImport() bool }); ok {
-			if v.IsShownOnImport() {
+			if v.IsShownOnImport() && p.FieldWritable(ctx, v) {
 				items = append(items, v)
 			}
 		}
@@ -698,7 +698,7 @@ func (p *Template) ImportFieldsWithoutWhen(ctx *builder.Context) interface{} {
 	fields := p.getFieldsWithoutWhen(ctx)
 	for _, v := range fields.([]interface{}) {
 		if v, ok := v.(interface{ IsShownOnImport() bool }); ok {
-			if v.IsShownOnImport() {
+			if v.IsShownOnImport() && p.FieldWritable(ctx, v) {
 				items = append(items, v)
 			}
 		}
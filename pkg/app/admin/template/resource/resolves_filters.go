@@ -1,8 +1,111 @@
 package resource
 
-import "github.com/quarkcloudio/quark-go/v2/pkg/builder"
+import (
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/form/fields/number"
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/form/fields/radio"
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/form/fields/selectfield"
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/table"
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/template/resource/types"
+	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
+)
 
 // 筛选表单
 func (p *Template) Filters(ctx *builder.Context) []interface{} {
 	return []interface{}{}
 }
+
+// 列表页侧边栏筛选面板
+func (p *Template) IndexFilters(ctx *builder.Context) interface{} {
+
+	// 模版实例
+	template := ctx.Template.(types.Resourcer)
+
+	// 筛选项
+	filters := template.Filters(ctx)
+	if len(filters) == 0 {
+		return nil
+	}
+
+	// 筛选组件
+	filter := (&table.Filter{}).Init()
+
+	// 解析筛选项
+	for _, v := range filters {
+
+		// 筛选面板表单项
+		var item interface{}
+		var field = &Field{}
+
+		// 筛选实例
+		filterInstance, ok := v.(types.Filterer)
+		if !ok {
+			continue
+		}
+
+		// 初始化模版
+		filterInstance.TemplateInit(ctx)
+
+		// 初始化
+		filterInstance.Init(ctx)
+
+		// 获取组件名称
+		component := filterInstance.GetComponent()
+
+		// label 标签的文本
+		label := filterInstance.GetName()
+
+		// 字段名
+		name := filterInstance.GetColumn(v)
+
+		// 默认值
+		defaultValue := filterInstance.GetDefault()
+
+		// 获取属性
+		options := filterInstance.Options(ctx)
+
+		// 构建组件
+		switch component {
+		case "selectField":
+			selectOptions, _ := options.([]*selectfield.Option)
+			selectItem := field.
+				Select(name, label).
+				SetWidth(nil).
+				SetOptions(selectOptions).
+				SetDefault(defaultValue)
+
+			multiple, ok := v.(interface{ IsMultiple() bool })
+			if ok && multiple.IsMultiple() {
+				selectItem.SetMode("multiple")
+			}
+
+			item = selectItem
+		case "booleanField":
+			radioOptions, _ := options.([]*radio.Option)
+			item = field.
+				Radio(name, label).
+				SetOptions(radioOptions).
+				SetOptionType("button").
+				SetButtonStyle("solid").
+				SetDefault(defaultValue)
+		case "dateRangeField":
+			item = field.
+				DateRange(name, label).
+				SetWidth(nil).
+				SetDefault(defaultValue)
+		case "numberRangeField":
+			item = field.Compact(label, []interface{}{
+				number.New().SetName(name + "[0]").SetPlaceholder("最小值").SetWidth(nil),
+				number.New().SetName(name + "[1]").SetPlaceholder("最大值").SetWidth(nil),
+			})
+		}
+
+		// 不支持的筛选组件不输出
+		if item == nil {
+			continue
+		}
+
+		filter = filter.AddItem(item)
+	}
+
+	return filter
+}
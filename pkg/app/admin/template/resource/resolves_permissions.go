@@ -0,0 +1,125 @@
+package resource
+
+import (
+	"errors"
+	"reflect"
+
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/model"
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/template/resource/types"
+	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
+)
+
+// 字段权限类型，作为权限的请求方法使用
+const (
+	FieldRead  = "READ"  // 查看字段
+	FieldWrite = "WRITE" // 编辑字段
+)
+
+// 字段权限
+type FieldPermission struct {
+	Name   string // 字段名
+	Label  string // 字段文字
+	Path   string // 权限路径，例如/api/admin/article/field/title
+	Method string // 权限类型，READ | WRITE
+}
+
+// 获取字段权限的路径
+func FieldPermissionPath(resource string, name string) string {
+	return "/api/admin/" + resource + "/field/" + name
+}
+
+// 获取需要授权的字段权限，用于同步权限
+func (p *Template) FieldPermissions(resource string, fields []interface{}) []*FieldPermission {
+	var permissions []*FieldPermission
+
+	items := p.findFields(fields, true)
+	for _, v := range items.([]interface{}) {
+		name := reflect.ValueOf(v).Elem().FieldByName("Name").String()
+		label := reflect.ValueOf(v).Elem().FieldByName("Label").String()
+		if name == "" {
+			continue
+		}
+
+		if field, ok := v.(interface{ GetReadPermission() bool }); ok && field.GetReadPermission() {
+			permissions = append(permissions, &FieldPermission{
+				Name:   name,
+				Label:  label,
+				Path:   FieldPermissionPath(resource, name),
+				Method: FieldRead,
+			})
+		}
+		if field, ok := v.(interface{ GetWritePermission() bool }); ok && field.GetWritePermission() {
+			permissions = append(permissions, &FieldPermission{
+				Name:   name,
+				Label:  label,
+				Path:   FieldPermissionPath(resource, name),
+				Method: FieldWrite,
+			})
+		}
+	}
+
+	return permissions
+}
+
+// 判断当前管理员能否查看字段，字段未设置权限时直接放行
+func (p *Template) FieldReadable(ctx *builder.Context, field interface{}) bool {
+	getField, ok := field.(interface{ GetReadPermission() bool })
+	if !ok || !getField.GetReadPermission() {
+		return true
+	}
+
+	return p.canAccessField(ctx, field, FieldRead)
+}
+
+// 判断当前管理员能否编辑字段，字段未设置权限时直接放行
+func (p *Template) FieldWritable(ctx *builder.Context, field interface{}) bool {
+	getField, ok := field.(interface{ GetWritePermission() bool })
+	if !ok || !getField.GetWritePermission() {
+		return true
+	}
+
+	return p.canAccessField(ctx, field, FieldWrite)
+}
+
+// 校验提交的数据，包含无权编辑的字段时返回错误
+func (p *Template) ValidatorForFields(ctx *builder.Context, data map[string]interface{}) error {
+
+	// 资源实例
+	template := ctx.Template.(types.Resourcer)
+
+	fields := p.findFields(template.Fields(ctx), true)
+	for _, v := range fields.([]interface{}) {
+		name := reflect.ValueOf(v).Elem().FieldByName("Name").String()
+		if _, ok := data[name]; !ok {
+			continue
+		}
+
+		if !p.FieldWritable(ctx, v) {
+			label := reflect.ValueOf(v).Elem().FieldByName("Label").String()
+			if label == "" {
+				label = name
+			}
+
+			return errors.New("无权编辑字段：" + label)
+		}
+	}
+
+	return nil
+}
+
+// 判断当前管理员是否拥有字段权限
+func (p *Template) canAccessField(ctx *builder.Context, field interface{}, method string) bool {
+	name := reflect.ValueOf(field).Elem().FieldByName("Name").String()
+
+	adminInfo, err := (&model.Admin{}).GetAuthUser(ctx.Engine.GetConfig().AppKey, ctx.Token())
+	if err != nil {
+		return false
+	}
+
+	result, err := (&model.CasbinRule{}).CanAccess(ctx, adminInfo.Id, FieldPermissionPath(ctx.Param("resource"), name), method)
+	if err != nil {
+		return false
+	}
+
+	return result
+}
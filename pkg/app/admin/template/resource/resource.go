@@ -8,6 +8,7 @@ import (
 	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/template/resource/types"
 	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
 	"github.com/quarkcloudio/quark-go/v2/pkg/dal/db"
+	"github.com/quarkcloudio/quark-go/v2/pkg/storage"
 	"gorm.io/gorm"
 )
 
@@ -24,9 +25,11 @@ const (
 	SavePath           = "/api/admin/:resource/save"                  // 保存编辑值路径
 	ImportPath         = "/api/admin/:resource/import"                // 详情页面路径
 	ExportPath         = "/api/admin/:resource/export"                // 导出数据路径
+	ExportDownloadPath = "/api/admin/:resource/export/download"       // 下载后台导出文件路径
 	DetailPath         = "/api/admin/:resource/detail"                // 导入数据路径
 	ImportTemplatePath = "/api/admin/:resource/import/template"       // 导入模板路径
 	FormPath           = "/api/admin/:resource/:uriKey/form"          // 通用表单资源路径
+	ChartPath          = "/api/admin/:resource/chart/:uriKey"         // 图表数据路径
 )
 
 // 增删改查模板
@@ -36,6 +39,9 @@ type Template struct {
 	SubTitle               string                 // 页面子标题
 	BackIcon               bool                   // 页面是否携带返回Icon
 	PerPage                interface{}            // 列表页分页配置
+	PaginationMode         string                 // 列表页分页方式，offset为页码分页，cursor为游标分页，默认为offset
+	CursorColumn           string                 // 游标分页使用的字段，需为有索引的字段，例如id或created_at，默认为id
+	CountMode              string                 // 列表页总数统计方式，exact为精确统计，estimate为按表统计信息估算，none为不统计，默认为exact
 	Form                   *form.Component        // 表单页Form实例
 	Table                  *table.Component       // 列表页Table实例
 	TableTitleSuffix       string                 // 列表页表格标题后缀
@@ -48,6 +54,12 @@ type Template struct {
 	Model                  interface{}            // 挂载模型
 	Field                  map[string]interface{} // 注入的字段数据
 	WithExport             bool                   // 是否具有导出功能
+	ExportFormat           string                 // 导出格式，xlsx或csv，默认为xlsx
+	ExportBatchSize        int                    // 导出时每批处理的数据条数，默认为1000
+	ExportAsync            bool                   // 是否后台导出，导出完成后生成附件记录，通过下载接口下载
+	ExportStorage          *storage.Config        // 后台导出文件的存储配置，为空时保存到本地./storage/exports目录
+	OwnerColumn            string                 // 数据权限的创建人字段，例如admin_id，为空时不按创建人限制
+	DepartmentColumn       string                 // 数据权限的部门字段，例如department_id，为空时按创建人所在部门限制
 }
 
 // 初始化
@@ -92,9 +104,11 @@ func (p *Template) RouteInit() interface{} {
 	p.POST(SavePath, p.SaveRender)                    // 保存编辑值
 	p.GET(DetailPath, p.DetailRender)                 // 详情页面
 	p.GET(ExportPath, p.ExportRender)                 // 导出数据
+	p.GET(ExportDownloadPath, p.ExportDownloadRender) // 下载后台导出文件
 	p.POST(ImportPath, p.ImportRender)                // 导入数据
 	p.GET(ImportTemplatePath, p.ImportTemplateRender) // 导入模板
 	p.GET(FormPath, p.FormRender)                     // 通用表单资源
+	p.GET(ChartPath, p.ChartRender)                   // 图表数据
 
 	return p
 }
@@ -124,6 +138,21 @@ func (p *Template) GetPerPage() interface{} {
 	return p.PerPage
 }
 
+// 获取分页方式
+func (p *Template) GetPaginationMode() string {
+	return p.PaginationMode
+}
+
+// 获取游标分页使用的字段
+func (p *Template) GetCursorColumn() string {
+	return p.CursorColumn
+}
+
+// 获取总数统计方式
+func (p *Template) GetCountMode() string {
+	return p.CountMode
+}
+
 // 获取表单页Form实例
 func (p *Template) GetForm() *form.Component {
 	return p.Form
@@ -179,6 +208,36 @@ func (p *Template) GetWithExport() bool {
 	return p.WithExport
 }
 
+// 获取导出格式
+func (p *Template) GetExportFormat() string {
+	return p.ExportFormat
+}
+
+// 获取导出时每批处理的数据条数
+func (p *Template) GetExportBatchSize() int {
+	return p.ExportBatchSize
+}
+
+// 获取是否后台导出
+func (p *Template) GetExportAsync() bool {
+	return p.ExportAsync
+}
+
+// 获取后台导出文件的存储配置
+func (p *Template) GetExportStorage() *storage.Config {
+	return p.ExportStorage
+}
+
+// 获取数据权限的创建人字段
+func (p *Template) GetOwnerColumn() string {
+	return p.OwnerColumn
+}
+
+// 获取数据权限的部门字段
+func (p *Template) GetDepartmentColumn() string {
+	return p.DepartmentColumn
+}
+
 // 设置单列字段
 func (p *Template) SetField(fieldData map[string]interface{}) interface{} {
 	p.Field = fieldData
@@ -201,12 +260,17 @@ func (p *Template) Actions(ctx *builder.Context) []interface{} {
 	return []interface{}{}
 }
 
+// 图表
+func (p *Template) Charts(ctx *builder.Context) []interface{} {
+	return []interface{}{}
+}
+
 // 菜单
 func (p *Template) Menus(ctx *builder.Context) interface{} {
 	return map[string]interface{}{}
 }
 
-// 数据导出前回调
+// 数据导出前回调，分批导出时每批执行一次，后台导出时ctx为请求的副本，不能写入响应
 func (p *Template) BeforeExporting(ctx *builder.Context, list []map[string]interface{}) []interface{} {
 	result := []interface{}{}
 	for _, v := range list {
@@ -222,12 +286,12 @@ func (p *Template) BeforeImporting(ctx *builder.Context, list [][]interface{}) [
 }
 
 // 表格行内编辑执行完之后回调
-func (p *Template) AfterEditable(ctx *builder.Context, id interface{}, field string, value interface{}) error {
+func (p *Template) AfterEditable(ctx *builder.Context, tx *gorm.DB, id interface{}, field string, value interface{}) error {
 	return nil
 }
 
 // 行为执行完之后回调
-func (p *Template) AfterAction(ctx *builder.Context, uriKey string, query *gorm.DB) error {
+func (p *Template) AfterAction(ctx *builder.Context, tx *gorm.DB, uriKey string, query *gorm.DB) error {
 	return nil
 }
 
@@ -257,6 +321,11 @@ func (p *Template) ActionRender(ctx *builder.Context) error {
 	return (&requests.ActionRequest{}).Handle(ctx)
 }
 
+// 图表数据
+func (p *Template) ChartRender(ctx *builder.Context) error {
+	return (&requests.ChartRequest{}).Handle(ctx)
+}
+
 // 行为表单值
 func (p *Template) ActionValuesRender(ctx *builder.Context) error {
 	return (&requests.ActionRequest{}).Values(ctx)
@@ -336,6 +405,11 @@ func (p *Template) ExportRender(ctx *builder.Context) error {
 	return (&requests.ExportRequest{}).Handle(ctx)
 }
 
+// 下载后台导出文件
+func (p *Template) ExportDownloadRender(ctx *builder.Context) error {
+	return (&requests.ExportRequest{}).Download(ctx)
+}
+
 // 导入数据
 func (p *Template) ImportRender(ctx *builder.Context) error {
 	return (&requests.ImportRequest{}).Handle(ctx, IndexPath)
@@ -125,21 +125,16 @@ func (p *Template) FormWithinTabs(
 }
 
 // 保存数据前回调
-func (p *Template) BeforeSaving(ctx *builder.Context, submitData map[string]interface{}) (map[string]interface{}, error) {
+func (p *Template) BeforeSaving(ctx *builder.Context, tx *gorm.DB, submitData map[string]interface{}) (map[string]interface{}, error) {
 	return submitData, nil
 }
 
 // 保存数据后回调
-func (p *Template) AfterSaved(ctx *builder.Context, id int, data map[string]interface{}, result *gorm.DB) error {
+func (p *Template) AfterSaved(ctx *builder.Context, tx *gorm.DB, id int, data map[string]interface{}) error {
 
 	// 导入操作直接返回
 	if ctx.IsImport() {
-		return result.Error
-	}
-
-	// 返回错误信息
-	if result.Error != nil {
-		return ctx.JSON(200, message.Error(result.Error.Error()))
+		return nil
 	}
 
 	return ctx.JSON(200, message.Success("操作成功！", strings.Replace("/layout/index?api="+IndexPath, ":resource", ctx.Param("resource"), -1)))
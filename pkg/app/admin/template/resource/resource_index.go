@@ -48,7 +48,7 @@ func (p *Template) IndexComponentRender(ctx *builder.Context, data interface{})
 	tablePolling := template.GetTablePolling()
 
 	// 列表页表格主体
-	tableExtraRender := p.IndexTableExtraRender(ctx)
+	tableExtraRender := template.IndexTableExtraRender(ctx)
 
 	// 列表页工具栏
 	tableToolBar := p.IndexTableToolBar(ctx)
@@ -62,6 +62,9 @@ func (p *Template) IndexComponentRender(ctx *builder.Context, data interface{})
 	// 列表页搜索栏
 	indexSearches := p.IndexSearches(ctx)
 
+	// 列表页侧边栏筛选面板
+	indexFilters := p.IndexFilters(ctx)
+
 	// 表格组件
 	table = table.
 		SetPolling(int(tablePolling)).
@@ -70,7 +73,8 @@ func (p *Template) IndexComponentRender(ctx *builder.Context, data interface{})
 		SetToolBar(tableToolBar).
 		SetColumns(tableColumns).
 		SetBatchActions(indexTableAlertActions).
-		SetSearches(indexSearches)
+		SetSearches(indexSearches).
+		SetFilters(indexFilters)
 
 	// 获取分页
 	perPage := template.GetPerPage()
@@ -87,7 +91,17 @@ func (p *Template) IndexComponentRender(ctx *builder.Context, data interface{})
 		total := data.(map[string]interface{})["total"]
 		items := data.(map[string]interface{})["items"]
 
-		component = table.SetPagination(current.(int), perPage.(int), int(total.(int64)), 1).SetDatasource(items)
+		switch {
+		case template.GetPaginationMode() == "cursor":
+			prevCursor := data.(map[string]interface{})["prevCursor"]
+			nextCursor := data.(map[string]interface{})["nextCursor"]
+			component = table.SetCursorPagination(perPage.(int), int(total.(int64)), prevCursor.(string), nextCursor.(string)).SetDatasource(items)
+		case template.GetCountMode() == "none" || template.GetCountMode() == "estimate":
+			hasMore := data.(map[string]interface{})["hasMore"]
+			component = table.SetSimplePagination(current.(int), perPage.(int), int(total.(int64)), hasMore.(bool)).SetDatasource(items)
+		default:
+			component = table.SetPagination(current.(int), perPage.(int), int(total.(int64)), 1).SetDatasource(items)
+		}
 	}
 
 	return component
@@ -0,0 +1,24 @@
+package types
+
+import (
+	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
+	"gorm.io/gorm"
+)
+
+type Charter interface {
+
+	// 初始化
+	Init(ctx *builder.Context) interface{}
+
+	// 图表key
+	GetUriKey(chart interface{}) string
+
+	// 获取标题
+	GetTitle() string
+
+	// 图表组件，api为获取图表数据的接口
+	Component(api string) interface{}
+
+	// 获取图表数据，query为已执行数据权限及全局查询的资源查询
+	Handle(ctx *builder.Context, query *gorm.DB) (interface{}, error)
+}
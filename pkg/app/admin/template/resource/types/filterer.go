@@ -0,0 +1,33 @@
+package types
+
+import (
+	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
+	"gorm.io/gorm"
+)
+
+type Filterer interface {
+
+	// 初始化
+	Init(ctx *builder.Context) interface{}
+
+	// 初始化模板
+	TemplateInit(ctx *builder.Context) interface{}
+
+	// 获取字段名
+	GetColumn(filter interface{}) string
+
+	// 获取名称
+	GetName() string
+
+	// 获取组件名称
+	GetComponent() string
+
+	// 默认值
+	GetDefault() interface{}
+
+	// 执行查询
+	Apply(ctx *builder.Context, query *gorm.DB, value interface{}) *gorm.DB
+
+	// 属性
+	Options(ctx *builder.Context) interface{}
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/form"
 	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/table"
 	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
+	"github.com/quarkcloudio/quark-go/v2/pkg/storage"
 	"gorm.io/gorm"
 )
 
@@ -27,6 +28,15 @@ type Resourcer interface {
 	// 获取分页配置
 	GetPerPage() interface{}
 
+	// 获取分页方式
+	GetPaginationMode() string
+
+	// 获取游标分页使用的字段
+	GetCursorColumn() string
+
+	// 获取总数统计方式
+	GetCountMode() string
+
 	// 获取表单页Form实例
 	GetForm() *form.Component
 
@@ -60,20 +70,44 @@ type Resourcer interface {
 	// 获取是否具有导出功能
 	GetWithExport() bool
 
+	// 获取导出格式
+	GetExportFormat() string
+
+	// 获取导出时每批处理的数据条数
+	GetExportBatchSize() int
+
+	// 获取是否后台导出
+	GetExportAsync() bool
+
+	// 获取后台导出文件的存储配置
+	GetExportStorage() *storage.Config
+
+	// 获取数据权限的创建人字段
+	GetOwnerColumn() string
+
+	// 获取数据权限的部门字段
+	GetDepartmentColumn() string
+
+	// 创建数据时填充数据权限字段
+	FillDataScope(ctx *builder.Context, data map[string]interface{}) map[string]interface{}
+
+	// 更新数据时去除数据权限字段，不具有全部数据权限时不允许修改
+	StripDataScope(ctx *builder.Context, data map[string]interface{}) map[string]interface{}
+
 	// 设置单列字段
 	SetField(fieldData map[string]interface{}) interface{}
 
-	// 数据导出前回调
+	// 数据导出前回调，分批导出时每批执行一次，后台导出时ctx为请求的副本，不能写入响应
 	BeforeExporting(ctx *builder.Context, list []map[string]interface{}) []interface{}
 
 	// 数据导入前回调
 	BeforeImporting(ctx *builder.Context, list [][]interface{}) [][]interface{}
 
-	// 表格行内编辑执行完之后回调
-	AfterEditable(ctx *builder.Context, id interface{}, field string, value interface{}) error
+	// 表格行内编辑执行完之后回调，tx为当前请求的事务，返回错误时回滚
+	AfterEditable(ctx *builder.Context, tx *gorm.DB, id interface{}, field string, value interface{}) error
 
-	// 行为执行完之后回调
-	AfterAction(ctx *builder.Context, uriKey string, query *gorm.DB) error
+	// 行为执行完之后回调，tx为当前请求的事务，返回错误时回滚
+	AfterAction(ctx *builder.Context, tx *gorm.DB, uriKey string, query *gorm.DB) error
 
 	// 列表页渲染
 	IndexRender(ctx *builder.Context) error
@@ -84,6 +118,9 @@ type Resourcer interface {
 	// 执行行为
 	ActionRender(ctx *builder.Context) error
 
+	// 图表数据
+	ChartRender(ctx *builder.Context) error
+
 	// 创建页面渲染
 	CreationRender(ctx *builder.Context) error
 
@@ -129,6 +166,9 @@ type Resourcer interface {
 	// 详情查询
 	DetailQuery(ctx *builder.Context, query *gorm.DB) *gorm.DB
 
+	// 图表查询
+	ChartQuery(ctx *builder.Context, query *gorm.DB) *gorm.DB
+
 	// 编辑查询
 	EditQuery(ctx *builder.Context, query *gorm.DB) *gorm.DB
 
@@ -153,6 +193,15 @@ type Resourcer interface {
 	// 行为
 	Actions(ctx *builder.Context) []interface{}
 
+	// 图表
+	Charts(ctx *builder.Context) []interface{}
+
+	// 图表组件渲染
+	ChartComponentRender(ctx *builder.Context, item interface{}) interface{}
+
+	// 创建图表数据接口
+	BuildChartApi(ctx *builder.Context, uriKey string) string
+
 	// 菜单
 	Menus(ctx *builder.Context) interface{}
 
@@ -219,11 +268,11 @@ type Resourcer interface {
 	// 表单标题
 	FormTitle(ctx *builder.Context) string
 
-	// 保存数据前回调
-	BeforeSaving(ctx *builder.Context, submitData map[string]interface{}) (map[string]interface{}, error)
+	// 保存数据前回调，tx为当前请求的事务，返回错误时回滚
+	BeforeSaving(ctx *builder.Context, tx *gorm.DB, submitData map[string]interface{}) (map[string]interface{}, error)
 
-	// 保存数据后回调
-	AfterSaved(ctx *builder.Context, id int, data map[string]interface{}, result *gorm.DB) error
+	// 保存数据后回调，tx为当前请求的事务，返回错误时回滚
+	AfterSaved(ctx *builder.Context, tx *gorm.DB, id int, data map[string]interface{}) error
 
 	// 列表页表格主体
 	IndexTableExtraRender(ctx *builder.Context) interface{}
@@ -285,14 +334,17 @@ type Resourcer interface {
 	// 创建编辑查询
 	BuildEditQuery(ctx *builder.Context, query *gorm.DB) *gorm.DB
 
+	// 创建图表查询
+	BuildChartQuery(ctx *builder.Context, query *gorm.DB) *gorm.DB
+
 	// 创建表格行内编辑查询
 	BuildEditableQuery(ctx *builder.Context, query *gorm.DB) *gorm.DB
 
 	// 创建导出查询
-	BuildExportQuery(ctx *builder.Context, query *gorm.DB, search []interface{}, filters []interface{}, columnFilters map[string]interface{}, orderings map[string]interface{}) *gorm.DB
+	BuildExportQuery(ctx *builder.Context, query *gorm.DB, search []interface{}, filters []interface{}, filterValues map[string]interface{}, columnFilters map[string]interface{}, orderings map[string]interface{}) *gorm.DB
 
 	// 创建列表查询
-	BuildIndexQuery(ctx *builder.Context, query *gorm.DB, search []interface{}, filters []interface{}, columnFilters map[string]interface{}, orderings map[string]interface{}) *gorm.DB
+	BuildIndexQuery(ctx *builder.Context, query *gorm.DB, search []interface{}, filters []interface{}, filterValues map[string]interface{}, columnFilters map[string]interface{}, orderings map[string]interface{}) *gorm.DB
 
 	// 创建更新查询
 	BuildUpdateQuery(ctx *builder.Context, query *gorm.DB) *gorm.DB
@@ -305,4 +357,7 @@ type Resourcer interface {
 
 	// 导入请求的验证器
 	ValidatorForImport(ctx *builder.Context, data map[string]interface{}) error
+
+	// 字段权限的验证器，提交无权编辑的字段时返回错误
+	ValidatorForFields(ctx *builder.Context, data map[string]interface{}) error
 }
@@ -0,0 +1,257 @@
+package upload
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/message"
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/model"
+	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
+	"github.com/quarkcloudio/quark-go/v2/pkg/cache"
+	"github.com/quarkcloudio/quark-go/v2/pkg/storage"
+)
+
+// 分片上传会话有效期
+const multipartTTL = 24 * time.Hour
+
+// 分片上传请求
+type MultipartRequest struct {
+	UploadId    string `json:"uploadId" form:"uploadId"`       // 上传ID，续传时传入
+	Name        string `json:"name" form:"name"`               // 文件名称
+	Size        int64  `json:"size" form:"size"`               // 文件大小
+	ContentType string `json:"contentType" form:"contentType"` // 文件类型
+	Sha256      string `json:"sha256" form:"sha256"`           // 文件内容的SHA256，用于合并后校验及断点续传
+}
+
+// 分片上传会话缓存键
+func multipartCacheKey(uploadId string) string {
+	return "upload_multipart:" + uploadId
+}
+
+// 根据文件哈希值查找会话的缓存键，用于刷新页面后续传
+func multipartHashCacheKey(ctx *builder.Context, owner string, sha256 string, size int64) string {
+	return "upload_multipart_hash:" + ctx.Param("resource") + ":" + owner + ":" + sha256 + ":" + strconv.FormatInt(size, 10)
+}
+
+// 当前上传者
+func multipartOwner(ctx *builder.Context) (string, error) {
+	adminInfo, err := (&model.Admin{}).GetAuthUser(ctx.Engine.GetConfig().AppKey, ctx.Token())
+	if err != nil {
+		return "", err
+	}
+
+	return "admin|" + strconv.Itoa(adminInfo.Id), nil
+}
+
+// 获取分片上传使用的文件系统
+func (p *Template) multipartFileSystem(ctx *builder.Context) *storage.FileSystem {
+	template := ctx.Template.(Uploader)
+
+	return storage.New(&storage.Config{
+		LimitSize:        template.GetLimitSize(),
+		LimitType:        template.GetLimitType(),
+		LimitImageWidth:  template.GetLimitImageWidth(),
+		LimitImageHeight: template.GetLimitImageHeight(),
+		Driver:           template.GetDriver(),
+		OSSConfig:        template.GetOSSConfig(),
+		MinioConfig:      template.GetMinioConfig(),
+		SavePath:         template.GetSavePath(),
+	})
+}
+
+// 读取分片上传会话，仅允许上传者在同一资源下操作
+func (p *Template) getMultipart(ctx *builder.Context, owner string, uploadId string) (*storage.MultipartUpload, error) {
+	if uploadId == "" {
+		return nil, errors.New("参数错误")
+	}
+
+	value, err := cache.Get(multipartCacheKey(uploadId))
+	if err != nil {
+		if err == cache.ErrNotFound {
+			return nil, errors.New("分片上传不存在或已过期")
+		}
+		return nil, err
+	}
+
+	upload := &storage.MultipartUpload{}
+	err = json.Unmarshal([]byte(value), upload)
+	if err != nil {
+		return nil, err
+	}
+	if upload.Owner != owner || upload.Resource != ctx.Param("resource") {
+		return nil, errors.New("分片上传不存在或已过期")
+	}
+
+	return upload, nil
+}
+
+// 删除分片上传会话
+func (p *Template) deleteMultipart(ctx *builder.Context, upload *storage.MultipartUpload) error {
+	keys := []string{multipartCacheKey(upload.UploadId)}
+	if upload.Sha256 != "" {
+		keys = append(keys, multipartHashCacheKey(ctx, upload.Owner, upload.Sha256, upload.Size))
+	}
+
+	return cache.Delete(keys...)
+}
+
+// 返回分片上传会话及已上传的分片
+func (p *Template) multipartResult(ctx *builder.Context, upload *storage.MultipartUpload, parts []*storage.Part) error {
+	return ctx.JSON(200, message.Success("操作成功", "", map[string]interface{}{
+		"uploadId":  upload.UploadId,
+		"chunkSize": upload.ChunkSize,
+		"partCount": upload.PartCount(),
+		"parts":     parts,
+	}))
+}
+
+// 初始化分片上传，传入uploadId或相同的sha256时返回已上传的分片用于续传
+func (p *Template) MultipartInit(ctx *builder.Context) error {
+	request := &MultipartRequest{}
+	if err := ctx.Bind(request); err != nil {
+		return ctx.JSON(200, message.Error(err.Error()))
+	}
+
+	owner, err := multipartOwner(ctx)
+	if err != nil {
+		return ctx.JSON(200, message.Error(err.Error()))
+	}
+
+	uploadId := request.UploadId
+	if uploadId == "" && request.Sha256 != "" {
+		uploadId, _ = cache.Get(multipartHashCacheKey(ctx, owner, request.Sha256, request.Size))
+	}
+
+	fileSystem := p.multipartFileSystem(ctx)
+
+	// 续传
+	if uploadId != "" {
+		upload, err := p.getMultipart(ctx, owner, uploadId)
+		if err == nil {
+			parts, err := fileSystem.ListParts(upload)
+			if err != nil {
+				return ctx.JSON(200, message.Error(err.Error()))
+			}
+
+			return p.multipartResult(ctx, upload, parts)
+		}
+		if request.UploadId != "" {
+			return ctx.JSON(200, message.Error(err.Error()))
+		}
+	}
+
+	upload, err := fileSystem.
+		Reader(&storage.File{
+			Name:        request.Name,
+			Size:        request.Size,
+			ContentType: request.ContentType,
+		}).
+		InitMultipart(request.Sha256, ctx.Template.(Uploader).GetChunkSize())
+	if err != nil {
+		return ctx.JSON(200, message.Error(err.Error()))
+	}
+	upload.Owner = owner
+	upload.Resource = ctx.Param("resource")
+
+	value, err := json.Marshal(upload)
+	if err != nil {
+		return ctx.JSON(200, message.Error(err.Error()))
+	}
+
+	err = cache.Set(multipartCacheKey(upload.UploadId), string(value), multipartTTL)
+	if err != nil {
+		return ctx.JSON(200, message.Error(err.Error()))
+	}
+	if upload.Sha256 != "" {
+		cache.Set(multipartHashCacheKey(ctx, owner, upload.Sha256, upload.Size), upload.UploadId, multipartTTL)
+	}
+
+	return p.multipartResult(ctx, upload, []*storage.Part{})
+}
+
+// 上传分片，请求体为分片的二进制内容，uploadId、partNumber通过Query传入
+func (p *Template) MultipartPart(ctx *builder.Context) error {
+	owner, err := multipartOwner(ctx)
+	if err != nil {
+		return ctx.JSON(200, message.Error(err.Error()))
+	}
+
+	upload, err := p.getMultipart(ctx, owner, ctx.QueryParam("uploadId"))
+	if err != nil {
+		return ctx.JSON(200, message.Error(err.Error()))
+	}
+
+	partNumber, err := strconv.Atoi(ctx.QueryParam("partNumber"))
+	if err != nil {
+		return ctx.JSON(200, message.Error("分片序号错误"))
+	}
+
+	part, err := p.multipartFileSystem(ctx).UploadPart(upload, partNumber, ctx.Request.Body)
+	if err != nil {
+		return ctx.JSON(200, message.Error(err.Error()))
+	}
+
+	return ctx.JSON(200, message.Success("上传成功", "", part))
+}
+
+// 合并分片，校验通过后执行上传后回调
+func (p *Template) MultipartComplete(ctx *builder.Context) error {
+	request := &MultipartRequest{}
+	if err := ctx.Bind(request); err != nil {
+		return ctx.JSON(200, message.Error(err.Error()))
+	}
+
+	owner, err := multipartOwner(ctx)
+	if err != nil {
+		return ctx.JSON(200, message.Error(err.Error()))
+	}
+
+	upload, err := p.getMultipart(ctx, owner, request.UploadId)
+	if err != nil {
+		return ctx.JSON(200, message.Error(err.Error()))
+	}
+
+	result, err := p.multipartFileSystem(ctx).CompleteMultipart(upload)
+	if err != nil {
+		return ctx.JSON(200, message.Error(err.Error()))
+	}
+
+	err = p.deleteMultipart(ctx, upload)
+	if err != nil {
+		return ctx.JSON(200, message.Error(err.Error()))
+	}
+
+	return ctx.Template.(Uploader).AfterHandle(ctx, result)
+}
+
+// 取消分片上传
+func (p *Template) MultipartAbort(ctx *builder.Context) error {
+	request := &MultipartRequest{}
+	if err := ctx.Bind(request); err != nil {
+		return ctx.JSON(200, message.Error(err.Error()))
+	}
+
+	owner, err := multipartOwner(ctx)
+	if err != nil {
+		return ctx.JSON(200, message.Error(err.Error()))
+	}
+
+	upload, err := p.getMultipart(ctx, owner, request.UploadId)
+	if err != nil {
+		return ctx.JSON(200, message.Error(err.Error()))
+	}
+
+	err = p.multipartFileSystem(ctx).AbortMultipart(upload)
+	if err != nil {
+		return ctx.JSON(200, message.Error(err.Error()))
+	}
+
+	err = p.deleteMultipart(ctx, upload)
+	if err != nil {
+		return ctx.JSON(200, message.Error(err.Error()))
+	}
+
+	return ctx.JSON(200, message.Success("操作成功"))
+}
@@ -4,7 +4,6 @@ import (
 	"bytes"
 	"encoding/base64"
 	"io"
-	"mime/multipart"
 	"strconv"
 	"strings"
 
@@ -17,14 +16,16 @@ import (
 // 文件上传
 type Template struct {
 	builder.Template
-	LimitSize        int64                // 限制文件大小
-	LimitType        []string             // 限制文件类型
-	LimitImageWidth  int                  // 限制图片宽度
-	LimitImageHeight int                  // 限制图片高度
-	Driver           string               // 存储驱动
-	SavePath         string               // 保存路径
-	OSSConfig        *storage.OSSConfig   // OSS配置
-	MinioConfig      *storage.MinioConfig // Minio配置
+	LimitSize        int64                   // 限制文件大小
+	LimitType        []string                // 限制文件类型
+	LimitImageWidth  int                     // 限制图片宽度
+	LimitImageHeight int                     // 限制图片高度
+	Driver           string                  // 存储驱动
+	SavePath         string                  // 保存路径
+	ChunkSize        int64                   // 分片上传时的分片大小
+	ImageVariants    []*storage.ImageVariant // 图片衍生版本
+	OSSConfig        *storage.OSSConfig      // OSS配置
+	MinioConfig      *storage.MinioConfig    // Minio配置
 }
 
 // 初始化
@@ -41,6 +42,9 @@ func (p *Template) TemplateInit(ctx *builder.Context) interface{} {
 	// 默认本地上传
 	p.Driver = storage.LocalDriver
 
+	// 默认分片大小
+	p.ChunkSize = storage.DefaultChunkSize
+
 	return p
 }
 
@@ -48,6 +52,10 @@ func (p *Template) TemplateInit(ctx *builder.Context) interface{} {
 func (p *Template) RouteInit() interface{} {
 	p.POST("/api/admin/upload/:resource/handle", p.Handle)
 	p.POST("/api/admin/upload/:resource/base64Handle", p.HandleFromBase64)
+	p.POST("/api/admin/upload/:resource/multipart/init", p.MultipartInit)
+	p.POST("/api/admin/upload/:resource/multipart/part", p.MultipartPart)
+	p.POST("/api/admin/upload/:resource/multipart/complete", p.MultipartComplete)
+	p.POST("/api/admin/upload/:resource/multipart/abort", p.MultipartAbort)
 
 	return p
 }
@@ -82,6 +90,16 @@ func (p *Template) GetSavePath() string {
 	return p.SavePath
 }
 
+// 获取分片大小
+func (p *Template) GetChunkSize() int64 {
+	return p.ChunkSize
+}
+
+// 获取图片衍生版本配置
+func (p *Template) GetImageVariants() []*storage.ImageVariant {
+	return p.ImageVariants
+}
+
 // 获取OSS配置
 func (p *Template) GetOSSConfig() *storage.OSSConfig {
 	return p.OSSConfig
@@ -92,6 +110,15 @@ func (p *Template) GetMinioConfig() *storage.MinioConfig {
 	return p.MinioConfig
 }
 
+// 获取存储驱动实例，用于读取、删除已上传的文件
+func (p *Template) GetStorage() (storage.Driver, error) {
+	return storage.NewDriver(&storage.Config{
+		Driver:      p.Driver,
+		OSSConfig:   p.OSSConfig,
+		MinioConfig: p.MinioConfig,
+	})
+}
+
 // 执行上传
 func (p *Template) Handle(ctx *builder.Context) error {
 	var (
@@ -137,11 +164,16 @@ func (p *Template) Handle(ctx *builder.Context) error {
 	minioConfig := template.GetMinioConfig()
 	savePath := template.GetSavePath()
 
-	byteReader := bytes.NewReader(ctx.Body())
-	multipartReader := multipart.NewReader(byteReader, strings.TrimLeft(contentTypes[1], "boundary="))
+	// 以流的方式读取表单，避免大文件整体加载到内存中
+	multipartReader, err := ctx.Request.MultipartReader()
+	if err != nil {
+		return ctx.JSON(200, message.Error(err.Error()))
+	}
 	for p, err := multipartReader.NextPart(); err != io.EOF; p, err = multipartReader.NextPart() {
+		if err != nil {
+			return ctx.JSON(200, message.Error(err.Error()))
+		}
 		if p.FormName() == "file" {
-			fileData, _ := io.ReadAll(p)
 			fileSystem := storage.
 				New(&storage.Config{
 					LimitSize:        limitSize,
@@ -156,8 +188,9 @@ func (p *Template) Handle(ctx *builder.Context) error {
 				Reader(&storage.File{
 					Header:  p.Header,
 					Name:    p.FileName(),
-					Content: fileData,
+					Content: p,
 				})
+			defer fileSystem.Close()
 
 			// 上传前回调
 			getFileSystem, fileInfo, err := template.BeforeHandle(ctx, fileSystem)
@@ -252,7 +285,7 @@ func (p *Template) HandleFromBase64(ctx *builder.Context) error {
 			MinioConfig:      minioConfig,
 		}).
 		Reader(&storage.File{
-			Content: fileData,
+			Content: bytes.NewReader(fileData),
 		})
 
 	// 上传前回调
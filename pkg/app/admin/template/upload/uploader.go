@@ -28,18 +28,39 @@ type Uploader interface {
 	// 获取保存路径
 	GetSavePath() string
 
+	// 获取分片大小
+	GetChunkSize() int64
+
+	// 获取图片衍生版本配置
+	GetImageVariants() []*storage.ImageVariant
+
 	// 获取OSS配置
 	GetOSSConfig() *storage.OSSConfig
 
 	// 获取Minio配置
 	GetMinioConfig() *storage.MinioConfig
 
+	// 获取存储驱动实例
+	GetStorage() (storage.Driver, error)
+
 	// 执行上传
 	Handle(ctx *builder.Context) error
 
 	// 通过Base64执行上传
 	HandleFromBase64(ctx *builder.Context) error
 
+	// 初始化分片上传
+	MultipartInit(ctx *builder.Context) error
+
+	// 上传分片
+	MultipartPart(ctx *builder.Context) error
+
+	// 合并分片
+	MultipartComplete(ctx *builder.Context) error
+
+	// 取消分片上传
+	MultipartAbort(ctx *builder.Context) error
+
 	// 上传前回调
 	BeforeHandle(ctx *builder.Context, fileSystem *storage.FileSystem) (*storage.FileSystem, *storage.FileInfo, error)
 
@@ -3,24 +3,30 @@ package install
 import (
 	"github.com/quarkcloudio/quark-go/v2/pkg/app/miniapp/model"
 	"github.com/quarkcloudio/quark-go/v2/pkg/dal/db"
-	"gorm.io/gorm"
+	"github.com/quarkcloudio/quark-go/v2/pkg/dal/migration"
 )
 
 // 执行安装操作
 func Handle() {
 
-	// 迁移数据
-	db.Client.AutoMigrate(
-		&model.User{},
-	)
+	// 注册内置迁移
+	migration.Register(Migrations...)
 
-	// 如果用户不存在，初始化数据库数据
-	userInfo, err := (&model.User{}).GetInfoById(1)
-	if err != nil && err != gorm.ErrRecordNotFound {
+	// 迁移功能之前安装的数据库已存在用户表，将初始迁移标记为已执行，避免重新填充默认用户和菜单
+	ran, err := migration.Ran(db.Client, baselineVersions[0])
+	if err != nil {
 		panic(err)
 	}
-	if userInfo.Id == 0 {
-		// 数据填充
-		(&model.User{}).Seeder()
+	if !ran && db.Client.Migrator().HasTable(&model.User{}) {
+		err = migration.MarkRan(db.Client, baselineVersions...)
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	// 执行未完成的迁移，包括应用注册的迁移
+	err = migration.Up(db.Client)
+	if err != nil {
+		panic(err)
 	}
 }
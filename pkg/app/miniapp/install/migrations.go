@@ -0,0 +1,154 @@
+package install
+
+import (
+	"time"
+
+	adminmodel "github.com/quarkcloudio/quark-go/v2/pkg/app/admin/model"
+	"github.com/quarkcloudio/quark-go/v2/pkg/dal/db"
+	"github.com/quarkcloudio/quark-go/v2/pkg/dal/migration"
+	"github.com/quarkcloudio/quark-go/v2/pkg/utils/hash"
+	"gorm.io/gorm"
+)
+
+// 迁移功能之前的安装方式已创建的表结构及填充的数据，已有安装时标记为已执行
+var baselineVersions = []string{
+	"2023_01_01_100000_create_miniapp_tables",
+	"2023_01_01_100001_seed_miniapp_data",
+}
+
+// MiniApp内置迁移，新增表结构变更时在末尾追加新的版本
+//
+// 每个迁移使用在迁移内定义的结构体，不引用model包中的模型，模型字段变更后已有的迁移保持不变
+var Migrations = []*migration.Migration{
+	{
+		Version: "2023_01_01_100000_create_miniapp_tables",
+		Up: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(miniappTables()...)
+		},
+		Down: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(miniappTables()...)
+		},
+	},
+	{
+		Version: "2023_01_01_100001_seed_miniapp_data",
+		Up:      seedMiniappData,
+	},
+	{
+		Version: "2023_07_04_100000_seed_miniapp_wechat_config",
+		Up: func(tx *gorm.DB) error {
+			type Config struct {
+				Id        int
+				Title     string
+				Type      string
+				Name      string
+				Sort      int
+				GroupName string
+				Value     string
+				Remark    string
+				Status    int
+				CreatedAt time.Time
+				UpdatedAt time.Time
+			}
+			configs := []Config{
+				{Title: "AppID", Type: "text", Name: "WECHAT_MINIAPP_APPID", Sort: 0, GroupName: "微信小程序", Value: "", Remark: "小程序AppID", Status: 1},
+				{Title: "AppSecret", Type: "text", Name: "WECHAT_MINIAPP_SECRET", Sort: 0, GroupName: "微信小程序", Value: "", Remark: "小程序AppSecret", Status: 1},
+			}
+			for _, config := range configs {
+				err := tx.Where(Config{Name: config.Name}).FirstOrCreate(&config).Error
+				if err != nil {
+					return err
+				}
+			}
+			db.AfterCommit(tx, func() {
+				(&adminmodel.Config{}).ClearCache()
+			})
+
+			return nil
+		},
+	},
+}
+
+// 初始表结构
+func miniappTables() []interface{} {
+	type User struct {
+		Id            int    `gorm:"autoIncrement"`
+		Username      string `gorm:"size:20;index:users_username_unique,unique;not null"`
+		Nickname      string `gorm:"size:200;not null"`
+		Sex           int    `gorm:"size:4;not null;default:1"`
+		Email         string `gorm:"size:50;index:users_email_unique,unique;not null"`
+		Phone         string `gorm:"size:11;index:users_phone_unique,unique;not null"`
+		Password      string `gorm:"size:255;not null"`
+		Avatar        string `gorm:"size:1000"`
+		LastLoginIp   string `gorm:"size:255"`
+		LastLoginTime time.Time
+		WxOpenid      string `gorm:"size:255"`
+		WxUnionid     string `gorm:"size:255"`
+		Status        int    `gorm:"size:1;not null;default:1"`
+		CreatedAt     time.Time
+		UpdatedAt     time.Time
+		DeletedAt     gorm.DeletedAt
+	}
+
+	return []interface{}{
+		&User{},
+	}
+}
+
+// 填充用户菜单及默认用户
+func seedMiniappData(tx *gorm.DB) error {
+	type Menu struct {
+		Id        int
+		Name      string
+		GuardName string
+		Icon      string
+		Type      int
+		Pid       int
+		Sort      int
+		Path      string
+		Show      int
+		IsEngine  int
+		IsLink    int
+		Status    int
+		CreatedAt time.Time
+		UpdatedAt time.Time
+	}
+	menus := []Menu{
+		{Id: 18, Name: "用户管理", GuardName: "admin", Icon: "icon-user", Type: 1, Pid: 0, Sort: 0, Path: "/user", Show: 1, IsEngine: 0, IsLink: 0, Status: 1},
+		{Id: 19, Name: "用户列表", GuardName: "admin", Icon: "", Type: 2, Pid: 18, Sort: 0, Path: "/api/admin/user/index", Show: 1, IsEngine: 1, IsLink: 0, Status: 1},
+	}
+	for _, menu := range menus {
+		err := tx.Where(Menu{Id: menu.Id}).FirstOrCreate(&menu).Error
+		if err != nil {
+			return err
+		}
+	}
+	db.AfterCommit(tx, func() {
+		(&adminmodel.Menu{}).ClearCache()
+	})
+
+	type User struct {
+		Id            int
+		Username      string
+		Nickname      string
+		Sex           int
+		Email         string
+		Phone         string
+		Password      string
+		Status        int
+		LastLoginTime time.Time
+		CreatedAt     time.Time
+		UpdatedAt     time.Time
+		DeletedAt     gorm.DeletedAt
+	}
+	users := []User{
+		{Username: "tangtanglove", Nickname: "默认用户", Email: "tangtanglove@yourweb.com", Phone: "10086", Password: hash.Make("123456"), Sex: 1, Status: 1, LastLoginTime: time.Now()},
+	}
+	for _, user := range users {
+		err := tx.Unscoped().Where(User{Username: user.Username}).FirstOrCreate(&user).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -8,6 +8,7 @@ import (
 	adminmodel "github.com/quarkcloudio/quark-go/v2/pkg/app/admin/model"
 	"github.com/quarkcloudio/quark-go/v2/pkg/dal/db"
 	"github.com/quarkcloudio/quark-go/v2/pkg/utils/hash"
+	"github.com/quarkcloudio/quark-go/v2/pkg/utils/rand"
 	"gorm.io/gorm"
 )
 
@@ -44,28 +45,6 @@ type UserClaims struct {
 	jwt.RegisteredClaims
 }
 
-// 用户Seeder
-func (model *User) Seeder() {
-
-	// 如果菜单已存在，不执行Seeder操作
-	if (&adminmodel.Menu{}).IsExist(18) {
-		return
-	}
-
-	// 创建菜单
-	menuSeeders := []*adminmodel.Menu{
-		{Id: 18, Name: "用户管理", GuardName: "admin", Icon: "icon-user", Type: 1, Pid: 0, Sort: 0, Path: "/user", Show: 1, IsEngine: 0, IsLink: 0, Status: 1},
-		{Id: 19, Name: "用户列表", GuardName: "admin", Icon: "", Type: 2, Pid: 18, Sort: 0, Path: "/api/admin/user/index", Show: 1, IsEngine: 1, IsLink: 0, Status: 1},
-	}
-	db.Client.Create(&menuSeeders)
-
-	seeders := []User{
-		{Username: "tangtanglove", Nickname: "默认用户", Email: "tangtanglove@yourweb.com", Phone: "10086", Password: hash.Make("123456"), Sex: 1, Status: 1, LastLoginTime: time.Now()},
-	}
-
-	db.Client.Create(&seeders)
-}
-
 // 获取用户JWT信息
 func (model *User) GetClaims(UserInfo *User) (userClaims *UserClaims) {
 	userClaims = &UserClaims{
@@ -143,3 +122,48 @@ func (model *User) UpdateLastLogin(uid int, lastLoginIp string, lastLoginTime ti
 		Where("id = ?", uid).
 		Updates(&data).Error
 }
+
+// 通过微信openid获取用户信息，包含已禁用的用户
+func (model *User) GetInfoByWxOpenid(openid string) (User *User, Error error) {
+	err := db.Client.Where("wx_openid = ?", openid).First(&User).Error
+
+	return User, err
+}
+
+// 通过手机号获取用户信息，包含已禁用的用户
+func (model *User) GetInfoByPhone(phone string) (User *User, Error error) {
+	err := db.Client.Where("phone = ?", phone).First(&User).Error
+
+	return User, err
+}
+
+// 绑定微信账号
+func (model *User) BindWechat(uid int, openid string, unionid string) error {
+	data := User{
+		WxOpenid:  openid,
+		WxUnionid: unionid,
+	}
+
+	return db.Client.
+		Where("id = ?", uid).
+		Updates(&data).Error
+}
+
+// 通过微信注册用户，用户名、邮箱为唯一索引，使用随机值占位，密码为随机值，需要通过绑定或修改密码后才能使用密码登录
+func (model *User) RegisterByWechat(openid string, unionid string, phone string) (user *User, Error error) {
+	username := "wx_" + rand.MakeAlphanumeric(16)
+	user = &User{
+		Username:      username,
+		Nickname:      "微信用户",
+		Email:         username + "@wechat.local",
+		Phone:         phone,
+		Password:      hash.Make(rand.MakeAlphanumeric(32)),
+		WxOpenid:      openid,
+		WxUnionid:     unionid,
+		Status:        1,
+		LastLoginTime: time.Now(),
+	}
+	err := db.Client.Create(user).Error
+
+	return user, err
+}
@@ -0,0 +1,23 @@
+package logins
+
+import (
+	adminmodel "github.com/quarkcloudio/quark-go/v2/pkg/app/admin/model"
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/miniapp/template/login"
+	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
+)
+
+type Index struct {
+	login.Template
+}
+
+// 初始化
+func (p *Index) Init(ctx *builder.Context) interface{} {
+
+	// 小程序AppID，在后台网站配置中设置
+	p.AppId = (&adminmodel.Config{}).GetValue("WECHAT_MINIAPP_APPID")
+
+	// 小程序AppSecret
+	p.AppSecret = (&adminmodel.Config{}).GetValue("WECHAT_MINIAPP_SECRET")
+
+	return p
+}
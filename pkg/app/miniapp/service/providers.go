@@ -2,6 +2,7 @@ package service
 
 import (
 	"github.com/quarkcloudio/quark-go/v2/pkg/app/miniapp/service/forms"
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/miniapp/service/logins"
 	"github.com/quarkcloudio/quark-go/v2/pkg/app/miniapp/service/pages"
 )
 
@@ -10,4 +11,5 @@ var Providers = []interface{}{
 	&pages.Index{},
 	&pages.My{},
 	&forms.Demo{},
+	&logins.Index{},
 }
@@ -1,16 +1,47 @@
 package login
 
 import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/miniapp/model"
 	"github.com/quarkcloudio/quark-go/v2/pkg/app/miniapp/template/page"
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/miniapp/wechat"
 	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
+	"github.com/quarkcloudio/quark-go/v2/pkg/cache"
 	"github.com/quarkcloudio/quark-go/v2/pkg/dal/db"
+	"github.com/quarkcloudio/quark-go/v2/pkg/utils/hash"
+	"github.com/quarkcloudio/quark-go/v2/pkg/utils/rand"
+	"gorm.io/gorm"
 )
 
+// 登录凭证有效期，用于手机号授权及账号绑定
+const ticketTTL = 10 * time.Minute
+
 // 后台登录模板
 type Template struct {
 	page.Template
-	FromStyle string
-	Api       string
+	FromStyle  string
+	Api        string
+	AppId      string            // 小程序AppID
+	AppSecret  string            // 小程序AppSecret
+	HTTPClient wechat.HTTPClient // 请求微信接口的HTTP客户端，为空时使用默认客户端
+}
+
+// 微信登录请求
+type WechatRequest struct {
+	Code          string `json:"code" form:"code"`                   // wx.login获取的code
+	Ticket        string `json:"ticket" form:"ticket"`               // 登录凭证
+	EncryptedData string `json:"encryptedData" form:"encryptedData"` // 手机号加密数据
+	Iv            string `json:"iv" form:"iv"`                       // 加密算法的初始向量
+}
+
+// 账号绑定请求
+type BindRequest struct {
+	Ticket   string `json:"ticket" form:"ticket"`     // 登录凭证
+	Username string `json:"username" form:"username"` // 用户名
+	Password string `json:"password" form:"password"` // 密码
 }
 
 // 初始化
@@ -33,7 +64,9 @@ func (p *Template) TemplateInit(ctx *builder.Context) interface{} {
 // 初始化路由映射
 func (p *Template) RouteInit() interface{} {
 	p.GET("/api/miniapp/login/:resource/index", p.Render)   // 渲染登录页面路由
-	p.POST("/api/miniapp/login/:resource/handle", p.Handle) // 后台登录执行路由
+	p.POST("/api/miniapp/login/:resource/handle", p.Handle) // 微信登录执行路由
+	p.POST("/api/miniapp/login/:resource/phone", p.Phone)   // 手机号授权登录路由
+	p.POST("/api/miniapp/login/:resource/bind", p.Bind)     // 绑定已有账号路由
 
 	return p
 }
@@ -44,7 +77,199 @@ func (p *Template) Content(ctx *builder.Context) interface{} {
 	return "登录页面"
 }
 
-// 执行表单
+// 获取小程序AppID
+func (p *Template) GetAppId() string {
+	return p.AppId
+}
+
+// 获取小程序AppSecret
+func (p *Template) GetAppSecret() string {
+	return p.AppSecret
+}
+
+// 获取HTTP客户端
+func (p *Template) GetHTTPClient() wechat.HTTPClient {
+	return p.HTTPClient
+}
+
+// 获取小程序客户端
+func (p *Template) GetClient(ctx *builder.Context) *wechat.Client {
+	template := ctx.Template.(Loginer)
+
+	client := wechat.New(template.GetAppId(), template.GetAppSecret())
+	if template.GetHTTPClient() != nil {
+		client.SetHTTPClient(template.GetHTTPClient())
+	}
+
+	return client
+}
+
+// 执行微信登录，已绑定的用户直接返回token；未绑定时如传入手机号加密数据则自动注册，否则返回ticket用于手机号授权或绑定已有账号
 func (p *Template) Handle(ctx *builder.Context) error {
-	return ctx.JSONError("请自行处理表单逻辑")
+	request := &WechatRequest{}
+	if err := ctx.Bind(request); err != nil {
+		return ctx.JSONError(err.Error())
+	}
+
+	session, err := p.GetClient(ctx).Code2Session(request.Code)
+	if err != nil {
+		return ctx.JSONError(err.Error())
+	}
+
+	user, err := (&model.User{}).GetInfoByWxOpenid(session.Openid)
+	if err != nil && err != gorm.ErrRecordNotFound {
+		return ctx.JSONError(err.Error())
+	}
+	if err == nil {
+		return p.loginResult(ctx, user)
+	}
+
+	ticket, err := p.saveTicket(session)
+	if err != nil {
+		return ctx.JSONError(err.Error())
+	}
+
+	// 同时传入手机号加密数据时直接注册
+	if request.EncryptedData != "" && request.Iv != "" {
+		request.Ticket = ticket
+		return p.phoneLogin(ctx, request)
+	}
+
+	return ctx.JSONOk("请授权手机号或绑定已有账号", map[string]interface{}{
+		"bindRequired": true,
+		"ticket":       ticket,
+	})
+}
+
+// 手机号授权登录，手机号已注册时绑定微信，未注册时自动注册
+func (p *Template) Phone(ctx *builder.Context) error {
+	request := &WechatRequest{}
+	if err := ctx.Bind(request); err != nil {
+		return ctx.JSONError(err.Error())
+	}
+
+	return p.phoneLogin(ctx, request)
+}
+
+// 绑定已有的用户名密码账号
+func (p *Template) Bind(ctx *builder.Context) error {
+	request := &BindRequest{}
+	if err := ctx.Bind(request); err != nil {
+		return ctx.JSONError(err.Error())
+	}
+	if request.Username == "" || request.Password == "" {
+		return ctx.JSONError("用户名或密码不能为空")
+	}
+
+	session, err := p.getTicket(request.Ticket)
+	if err != nil {
+		return ctx.JSONError(err.Error())
+	}
+
+	user, err := (&model.User{}).GetInfoByUsername(request.Username)
+	if err != nil || !hash.Check(user.Password, request.Password) {
+		return ctx.JSONError("用户名或密码错误")
+	}
+	if user.WxOpenid != "" && user.WxOpenid != session.Openid {
+		return ctx.JSONError("该账号已绑定其他微信")
+	}
+
+	err = (&model.User{}).BindWechat(user.Id, session.Openid, session.Unionid)
+	if err != nil {
+		return ctx.JSONError(err.Error())
+	}
+	cache.Delete(ticketCacheKey(request.Ticket))
+
+	return p.loginResult(ctx, user)
+}
+
+// 解密手机号并登录
+func (p *Template) phoneLogin(ctx *builder.Context, request *WechatRequest) error {
+	session, err := p.getTicket(request.Ticket)
+	if err != nil {
+		return ctx.JSONError(err.Error())
+	}
+
+	phoneInfo, err := p.GetClient(ctx).DecryptPhoneNumber(session.SessionKey, request.EncryptedData, request.Iv)
+	if err != nil {
+		return ctx.JSONError(err.Error())
+	}
+	if phoneInfo.PurePhoneNumber == "" {
+		return ctx.JSONError("获取手机号失败")
+	}
+
+	user, err := (&model.User{}).GetInfoByPhone(phoneInfo.PurePhoneNumber)
+	if err != nil && err != gorm.ErrRecordNotFound {
+		return ctx.JSONError(err.Error())
+	}
+
+	if err == gorm.ErrRecordNotFound {
+		user, err = (&model.User{}).RegisterByWechat(session.Openid, session.Unionid, phoneInfo.PurePhoneNumber)
+	} else if user.WxOpenid != "" && user.WxOpenid != session.Openid {
+		err = errors.New("该手机号已绑定其他微信")
+	} else {
+		err = (&model.User{}).BindWechat(user.Id, session.Openid, session.Unionid)
+	}
+	if err != nil {
+		return ctx.JSONError(err.Error())
+	}
+	cache.Delete(ticketCacheKey(request.Ticket))
+
+	return p.loginResult(ctx, user)
+}
+
+// 签发token
+func (p *Template) loginResult(ctx *builder.Context, user *model.User) error {
+	if user.Status != 1 {
+		return ctx.JSONError("用户已被禁用")
+	}
+
+	// 更新登录信息
+	(&model.User{}).UpdateLastLogin(user.Id, ctx.ClientIP(), time.Now())
+
+	token, err := ctx.JwtToken((&model.User{}).GetClaims(user))
+	if err != nil {
+		return ctx.JSONError(err.Error())
+	}
+
+	return ctx.JSONOk("登录成功", map[string]interface{}{
+		"token": token,
+	})
+}
+
+// 登录凭证缓存键
+func ticketCacheKey(ticket string) string {
+	return "miniapp_login_ticket:" + ticket
+}
+
+// 保存微信会话，返回登录凭证，session_key不会返回给客户端
+func (p *Template) saveTicket(session *wechat.Session) (string, error) {
+	value, err := json.Marshal(session)
+	if err != nil {
+		return "", err
+	}
+
+	ticket := rand.MakeAlphanumeric(32)
+
+	return ticket, cache.Set(ticketCacheKey(ticket), string(value), ticketTTL)
+}
+
+// 读取登录凭证对应的微信会话
+func (p *Template) getTicket(ticket string) (*wechat.Session, error) {
+	if ticket == "" {
+		return nil, errors.New("ticket不能为空")
+	}
+
+	value, err := cache.Get(ticketCacheKey(ticket))
+	if err != nil {
+		if err == cache.ErrNotFound {
+			return nil, errors.New("登录已过期，请重新登录")
+		}
+		return nil, err
+	}
+
+	session := &wechat.Session{}
+	err = json.Unmarshal([]byte(value), session)
+
+	return session, err
 }
@@ -0,0 +1,33 @@
+package login
+
+import (
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/miniapp/wechat"
+	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
+)
+
+type Loginer interface {
+
+	// 模版接口
+	builder.Templater
+
+	// 获取小程序AppID
+	GetAppId() string
+
+	// 获取小程序AppSecret
+	GetAppSecret() string
+
+	// 获取HTTP客户端
+	GetHTTPClient() wechat.HTTPClient
+
+	// 获取小程序客户端
+	GetClient(ctx *builder.Context) *wechat.Client
+
+	// 微信登录
+	Handle(ctx *builder.Context) error
+
+	// 手机号授权登录
+	Phone(ctx *builder.Context) error
+
+	// 绑定已有账号
+	Bind(ctx *builder.Context) error
+}
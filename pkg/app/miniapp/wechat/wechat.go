@@ -0,0 +1,169 @@
+package wechat
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// 微信接口地址
+const DefaultBaseURL = "https://api.weixin.qq.com"
+
+// HTTP客户端接口，测试时可替换为本地桩
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// 小程序客户端
+type Client struct {
+	AppId      string     // 小程序AppID
+	AppSecret  string     // 小程序AppSecret
+	BaseURL    string     // 接口地址
+	HTTPClient HTTPClient // HTTP客户端
+}
+
+// 登录凭证校验结果
+type Session struct {
+	Openid     string `json:"openid"`
+	SessionKey string `json:"session_key"`
+	Unionid    string `json:"unionid"`
+	Errcode    int    `json:"errcode"`
+	Errmsg     string `json:"errmsg"`
+}
+
+// 手机号信息
+type PhoneInfo struct {
+	PhoneNumber     string `json:"phoneNumber"`     // 用户绑定的手机号，国外手机号会有区号
+	PurePhoneNumber string `json:"purePhoneNumber"` // 没有区号的手机号
+	CountryCode     string `json:"countryCode"`     // 区号
+	Watermark       struct {
+		Appid     string `json:"appid"`
+		Timestamp int64  `json:"timestamp"`
+	} `json:"watermark"`
+}
+
+// 初始化客户端
+func New(appId string, appSecret string) *Client {
+	return &Client{
+		AppId:      appId,
+		AppSecret:  appSecret,
+		BaseURL:    DefaultBaseURL,
+		HTTPClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// 设置HTTP客户端
+func (p *Client) SetHTTPClient(httpClient HTTPClient) *Client {
+	p.HTTPClient = httpClient
+
+	return p
+}
+
+// 设置接口地址
+func (p *Client) SetBaseURL(baseURL string) *Client {
+	p.BaseURL = baseURL
+
+	return p
+}
+
+// 登录凭证校验，通过wx.login获取的code换取openid及session_key
+func (p *Client) Code2Session(code string) (*Session, error) {
+	if p.AppId == "" || p.AppSecret == "" {
+		return nil, errors.New("请配置小程序AppID及AppSecret")
+	}
+	if code == "" {
+		return nil, errors.New("code不能为空")
+	}
+
+	query := url.Values{}
+	query.Set("appid", p.AppId)
+	query.Set("secret", p.AppSecret)
+	query.Set("js_code", code)
+	query.Set("grant_type", "authorization_code")
+
+	req, err := http.NewRequest(http.MethodGet, p.BaseURL+"/sns/jscode2session?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := p.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	session := &Session{}
+	err = json.NewDecoder(resp.Body).Decode(session)
+	if err != nil {
+		return nil, err
+	}
+	if session.Errcode != 0 {
+		return nil, errors.New("微信登录失败：" + strconv.Itoa(session.Errcode) + " " + session.Errmsg)
+	}
+	if session.Openid == "" || session.SessionKey == "" {
+		return nil, errors.New("微信登录失败：返回数据错误")
+	}
+
+	return session, nil
+}
+
+// 解密手机号，并校验数据是否属于当前小程序
+func (p *Client) DecryptPhoneNumber(sessionKey string, encryptedData string, iv string) (*PhoneInfo, error) {
+	data, err := Decrypt(sessionKey, encryptedData, iv)
+	if err != nil {
+		return nil, err
+	}
+
+	phoneInfo := &PhoneInfo{}
+	err = json.Unmarshal(data, phoneInfo)
+	if err != nil {
+		return nil, err
+	}
+	if phoneInfo.Watermark.Appid != p.AppId {
+		return nil, errors.New("手机号数据不属于当前小程序")
+	}
+
+	return phoneInfo, nil
+}
+
+// 解密开放数据，算法为AES-128-CBC，数据采用PKCS#7填充
+func Decrypt(sessionKey string, encryptedData string, iv string) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(sessionKey)
+	if err != nil {
+		return nil, err
+	}
+	ciphertext, err := base64.StdEncoding.DecodeString(encryptedData)
+	if err != nil {
+		return nil, err
+	}
+	ivBytes, err := base64.StdEncoding.DecodeString(iv)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(ivBytes) != block.BlockSize() || len(ciphertext) == 0 || len(ciphertext)%block.BlockSize() != 0 {
+		return nil, errors.New("加密数据格式错误")
+	}
+
+	plaintext := make([]byte, len(ciphertext))
+	cipher.NewCBCDecrypter(block, ivBytes).CryptBlocks(plaintext, ciphertext)
+
+	// 去除填充
+	padding := int(plaintext[len(plaintext)-1])
+	if padding < 1 || padding > block.BlockSize() || !bytes.HasSuffix(plaintext, bytes.Repeat([]byte{byte(padding)}, padding)) {
+		return nil, errors.New("加密数据格式错误")
+	}
+
+	return plaintext[:len(plaintext)-padding], nil
+}
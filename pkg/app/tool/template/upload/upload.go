@@ -4,7 +4,6 @@ import (
 	"bytes"
 	"encoding/base64"
 	"io"
-	"mime/multipart"
 	"reflect"
 	"strconv"
 	"strings"
@@ -126,11 +125,16 @@ func (p *Template) Handle(ctx *builder.Context) error {
 		Elem().
 		FieldByName("SavePath").String()
 
-	byteReader := bytes.NewReader(ctx.Body())
-	multipartReader := multipart.NewReader(byteReader, strings.TrimLeft(contentTypes[1], "boundary="))
+	// 以流的方式读取表单，避免大文件整体加载到内存中
+	multipartReader, err := ctx.Request.MultipartReader()
+	if err != nil {
+		return ctx.JSONError(err.Error())
+	}
 	for p, err := multipartReader.NextPart(); err != io.EOF; p, err = multipartReader.NextPart() {
+		if err != nil {
+			return ctx.JSONError(err.Error())
+		}
 		if p.FormName() == "file" {
-			fileData, _ := io.ReadAll(p)
 			fileSystem := storage.
 				New(&storage.Config{
 					LimitSize:        limitSize,
@@ -145,8 +149,9 @@ func (p *Template) Handle(ctx *builder.Context) error {
 				Reader(&storage.File{
 					Header:  p.Header,
 					Name:    p.FileName(),
-					Content: fileData,
+					Content: p,
 				})
+			defer fileSystem.Close()
 
 			// 上传前回调
 			getFileSystem, fileInfo, err := ctx.Template.(interface {
@@ -275,7 +280,7 @@ func (p *Template) HandleFromBase64(ctx *builder.Context) error {
 			MinioConfig:      minioConfig.(*storage.MinioConfig),
 		}).
 		Reader(&storage.File{
-			Content: fileData,
+			Content: bytes.NewReader(fileData),
 		})
 
 	// 上传前回调
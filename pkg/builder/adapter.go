@@ -0,0 +1,66 @@
+package builder
+
+import (
+	"net/http"
+	"strings"
+)
+
+// Any路由展开后的请求方法
+var AnyMethods = []string{
+	http.MethodGet,
+	http.MethodHead,
+	http.MethodOptions,
+	http.MethodPost,
+	http.MethodPut,
+	http.MethodPatch,
+	http.MethodDelete,
+}
+
+// 遍历模版上注册的路由，Any路由按AnyMethods展开，用于适配其他框架的路由，path包含路由前缀
+func (p *Engine) WalkRoutes(fn func(method string, path string)) {
+	for _, v := range p.routePaths {
+		if v.Method != "Any" {
+			fn(v.Method, p.RoutePath(v.Path))
+			continue
+		}
+		for _, method := range AnyMethods {
+			fn(method, p.RoutePath(v.Path))
+		}
+	}
+}
+
+// 处理已匹配路由的请求，routePath为WalkRoutes返回的路由路径，例如/api/admin/:resource/index
+func (p *Engine) ServeRoute(routePath string, w http.ResponseWriter, r *http.Request) error {
+
+	// 创建上下文
+	ctx := p.NewContext(w, r)
+
+	// 设置路由
+	ctx.SetFullPath(p.TrimRoutePrefix(routePath))
+
+	return p.Render(ctx)
+}
+
+// 创建单个路由的http.HandlerFunc，出错时按Echo框架的方式返回错误
+func (p *Engine) RouteHandler(routePath string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := p.NewContext(w, r)
+		ctx.SetFullPath(p.TrimRoutePrefix(routePath))
+
+		if err := p.Render(ctx); err != nil {
+			p.echo.HTTPErrorHandler(err, ctx.EchoContext)
+		}
+	}
+}
+
+// 将"/api/admin/:resource/index"转换成"/api/admin/{resource}/index"格式的路由，用于chi、gorilla/mux等框架
+func BracePath(path string) string {
+	paths := strings.Split(path, "/")
+	for k, v := range paths {
+		if strings.HasPrefix(v, ":") {
+			paths[k] = "{" + strings.TrimPrefix(v, ":") + "}"
+		}
+	}
+
+	return strings.Join(paths, "/")
+}
@@ -30,6 +30,8 @@ type Context struct {
 	fullPath    string                 // 路由
 	Params      map[string]string      // URL param
 	Querys      map[string]interface{} // URL querys
+	handlers    []Handle               // 当前请求的处理方法链
+	index       int                    // 当前执行的处理方法下标
 }
 
 type ParamValue struct {
@@ -267,6 +269,42 @@ func (p *Context) SaveFile(file *multipart.FileHeader, path string) error {
 	return err
 }
 
+// BufferResponse 缓存fn执行期间输出的响应数据，fn返回nil时写入响应，返回错误时丢弃
+func (p *Context) BufferResponse(fn func() error) error {
+	response := p.EchoContext.Response()
+	if response.Committed {
+		return fn()
+	}
+
+	writer := response.Writer
+	buffer := newBufferedWriter()
+
+	// 替换Writer
+	response.Writer = buffer
+	p.Writer = buffer
+
+	err := fn()
+
+	// 还原Writer
+	response.Writer = writer
+	response.Committed = false
+	response.Status = 0
+	response.Size = 0
+	p.Writer = writer
+
+	if err != nil || !buffer.written {
+		return err
+	}
+
+	for k, v := range buffer.Header() {
+		writer.Header()[k] = v
+	}
+	response.WriteHeader(buffer.StatusCode())
+	_, err = response.Write(buffer.body.Bytes())
+
+	return err
+}
+
 // Attachment sends a response as attachment, prompting client to save the
 // file.
 func (p *Context) Attachment(file string, name string) error {
@@ -328,11 +366,15 @@ func (p *Context) Host() string {
 	return p.Request.Host
 }
 
-// Path returns requested path.
+// Path returns requested path without the route prefix.
 //
 // The path is valid until returning from RequestHandler.
 func (p *Context) Path() string {
-	return p.Request.URL.Path
+	if p.Engine == nil {
+		return p.Request.URL.Path
+	}
+
+	return p.Engine.TrimRoutePrefix(p.Request.URL.Path)
 }
 
 // OriginalURL returns url query data
@@ -556,20 +598,34 @@ func (p *Context) isCurrentTemplate(provider interface{}) bool {
 	return strings.EqualFold(strings.ToLower(structName), strings.ToLower(ResourceName))
 }
 
-// 解析UseHandler方法
-func (p *Context) useHandlerParser() error {
-	var err error
-	for _, Handler := range p.Engine.UseHandlers() {
-		err = Handler(p)
-		if err == nil {
-			return nil
-		}
-		if err.Error() != p.Next().Error() {
-			return err
+// 获取当前模板路由上挂载的中间件
+func (p *Context) routeMiddlewares() []Handle {
+	if p.Template == nil {
+		return nil
+	}
+
+	template, ok := p.Template.(interface {
+		GetRouteMapping() []*RouteMapping
+	})
+	if !ok {
+		return nil
+	}
+
+	for _, v := range template.GetRouteMapping() {
+		if v.Path == p.FullPath() && (v.Method == "Any" || v.Method == p.Method()) {
+			return v.Middlewares
 		}
 	}
 
-	return err
+	return nil
+}
+
+// 依次执行处理方法链
+func (p *Context) run(handlers []Handle) error {
+	p.handlers = handlers
+	p.index = -1
+
+	return p.Next()
 }
 
 // 初始化模板实例
@@ -710,7 +766,13 @@ func (p *Context) JSONError(message string) error {
 	return p.JSON(200, Error(message))
 }
 
-// 执行下一个Use方法，TODO
+// Next 执行处理方法链中剩余的方法，返回其执行结果；只能在中间件内调用。
+// 中间件可以在调用Next之后继续执行代码，例如统计耗时、改写响应或捕获panic
 func (p *Context) Next() error {
-	return errors.New("NextUseHandler")
+	p.index++
+	if p.index >= len(p.handlers) {
+		return nil
+	}
+
+	return p.handlers[p.index](p)
 }
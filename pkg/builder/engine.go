@@ -2,16 +2,23 @@ package builder
 
 import (
 	"io"
+	"io/fs"
 	"net/http"
 	"reflect"
 	"runtime"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/gorilla/sessions"
 	"github.com/labstack/echo/v4"
+	"github.com/quarkcloudio/quark-go/v2/pkg/cache"
 	"github.com/quarkcloudio/quark-go/v2/pkg/dal"
+	"github.com/quarkcloudio/quark-go/v2/pkg/dal/migration"
+	redisclient "github.com/quarkcloudio/quark-go/v2/pkg/dal/redis"
 	"github.com/quarkcloudio/quark-go/v2/pkg/gopkg"
 	"github.com/quarkcloudio/quark-go/v2/pkg/utils/file"
+	"github.com/quarkcloudio/quark-go/v2/web"
 	"github.com/redis/go-redis/v9"
 	"gorm.io/gorm"
 )
@@ -36,12 +43,16 @@ type Engine struct {
 	providers   []interface{}              // 服务列表
 	urlPaths    []*UrlPath                 // 请求路径列表
 	routePaths  []*RouteMapping            // 路由路径列表
+	routeOnce   sync.Once                  // 保证模版上的路由只注册一次
+	staticOnce  sync.Once                  // 保证作为http.Handler时静态文件只加载一次
+	hasStatic   bool                       // 是否已加载静态文件
 }
 
 type RouteMapping struct {
-	Method  string
-	Path    string
-	Handler func(ctx *Context) error
+	Method      string
+	Path        string
+	Handler     func(ctx *Context) error
+	Middlewares []Handle // 路由中间件，在全局中间件之后、路由方法之前执行
 }
 
 type UrlPath struct {
@@ -59,21 +70,30 @@ type RedisConfig struct {
 	Password string // 密码
 	Port     string // 端口
 	Database int    // 数据库
+	Prefix   string // 缓存键前缀，默认为quark:
 }
 
 type Config struct {
-	AppKey      string                // 应用加密Key，用于JWT认证
-	DBConfig    *DBConfig             // 数据库配置
-	RedisConfig *RedisConfig          // Redis配置
-	CookieStore *sessions.CookieStore // Cookie存储，用于保存Session
-	StaticPath  string                // 静态文件目录
-	Providers   []interface{}         // 服务列表
+	AppKey             string                 // 应用加密Key，用于JWT认证
+	TokenExpire        time.Duration          // 访问令牌有效期，默认24小时
+	RefreshTokenExpire time.Duration          // 刷新令牌有效期，默认7天
+	DBConfig           *DBConfig              // 数据库配置
+	RedisConfig        *RedisConfig           // Redis配置
+	CookieStore        *sessions.CookieStore  // Cookie存储，用于保存Session
+	StaticPath         string                 // 静态文件目录，其中的文件优先于内嵌的前端文件，默认为./web
+	StaticDownload     bool                   // 静态文件目录不存在时是否下载前端文件，默认不下载，直接使用内嵌的前端文件
+	StaticChecksum     string                 // 下载前端文件时校验的模块哈希，格式与go.sum相同，为空时从校验和数据库获取
+	Providers          []interface{}          // 服务列表
+	Migrations         []*migration.Migration // 应用的数据库迁移，与内置迁移一起按版本号执行
+	RoutePrefix        string                 // 路由前缀，挂载在前缀下时设置，例如/admin-panel，路由、静态文件及前端接口地址均添加该前缀
 }
 
 // 定义路由组
 type Group struct {
 	engine    *Engine
 	echoGroup *echo.Group
+	prefix    string   // 路由组前缀
+	handlers  []Handle // 路由组中间件
 }
 
 // 定义路由方法类型
@@ -82,6 +102,9 @@ type Handle func(ctx *Context) error
 // 全局配置
 var AppConfig *Config
 
+// 全局日志，使用Echo框架的日志
+var AppLogger echo.Logger
+
 // 初始化对象
 func New(config *Config) *Engine {
 
@@ -91,21 +114,44 @@ func New(config *Config) *Engine {
 	// 初始化echo引擎
 	e := echo.New()
 
+	// 初始化全局日志
+	AppLogger = e.Logger
+
 	// 隐藏banner
 	e.HideBanner = true
 
+	// 令牌有效期
+	if config.TokenExpire == 0 {
+		config.TokenExpire = 24 * time.Hour
+	}
+	if config.RefreshTokenExpire == 0 {
+		config.RefreshTokenExpire = 7 * 24 * time.Hour
+	}
+
 	// 初始化数据库
 	if config.DBConfig != nil {
 		dal.InitDB(config.DBConfig.Dialector, config.DBConfig.Opts)
 	}
 
-	// 初始化Redis
+	// 注册应用的数据库迁移
+	migration.Register(config.Migrations...)
+
+	// 初始化Redis，连接失败时使用进程内缓存
 	if config.RedisConfig != nil {
-		dal.InitRedis(&redis.Options{
+		err := dal.InitRedis(&redis.Options{
 			Addr:     config.RedisConfig.Host + ":" + config.RedisConfig.Port,
 			Password: config.RedisConfig.Password,
 			DB:       config.RedisConfig.Database,
 		})
+		if err != nil {
+			e.Logger.Warn("redis unavailable, fallback to in-memory cache: ", err)
+		} else {
+			prefix := config.RedisConfig.Prefix
+			if prefix == "" {
+				prefix = "quark:"
+			}
+			cache.Init(cache.NewRedis(redisclient.Client, prefix))
+		}
 	}
 
 	cookieStore := sessions.NewCookieStore([]byte(config.AppKey))
@@ -123,14 +169,22 @@ func New(config *Config) *Engine {
 		cookieStore: cookieStore,
 	}
 
+	// 路由前缀统一为以/开头、不以/结尾的格式
+	if config.RoutePrefix != "" {
+		config.RoutePrefix = "/" + strings.Trim(config.RoutePrefix, "/")
+		if config.RoutePrefix == "/" {
+			config.RoutePrefix = ""
+		}
+	}
+
 	// 默认WEB资源目录
 	if config.StaticPath == "" {
 		config.StaticPath = "./web"
 	}
 
-	// 下载静态文件
-	if !file.IsExist(config.StaticPath) {
-		err := gopkg.New(PkgName, Version).Save("web", config.StaticPath)
+	// 下载静态文件，未开启时使用内嵌的前端文件，启动时不访问网络
+	if config.StaticDownload && !file.IsExist(config.StaticPath) {
+		err := gopkg.New(PkgName, Version).SetChecksum(config.StaticChecksum).Save("web", config.StaticPath)
 		if err != nil {
 			panic(err)
 		}
@@ -148,6 +202,15 @@ func GetConfig() *Config {
 	return AppConfig
 }
 
+// 获取全局日志，引擎未初始化时使用Echo框架的默认日志
+func GetLogger() echo.Logger {
+	if AppLogger == nil {
+		AppLogger = echo.New().Logger
+	}
+
+	return AppLogger
+}
+
 // 获取当前配置
 func (p *Engine) GetConfig() *Config {
 	return p.config
@@ -184,8 +247,8 @@ func (p *Engine) TransformContext(fullPath string, header map[string][]string, m
 	// 创建上下文
 	ctx := p.NewContext(w, r)
 
-	// 设置当前路由
-	ctx.SetFullPath(fullPath)
+	// 设置当前路由，fullPath为其他框架匹配到的路由，包含路由前缀
+	ctx.SetFullPath(p.TrimRoutePrefix(fullPath))
 
 	// 返回对象
 	return ctx
@@ -280,7 +343,7 @@ func (p *Engine) initPaths() {
 			}
 
 			if !hasRoutePath(routePaths, v.Method, v.Path) {
-				routePaths = append(routePaths, &RouteMapping{v.Method, v.Path, v.Handler})
+				routePaths = append(routePaths, &RouteMapping{Method: v.Method, Path: v.Path, Handler: v.Handler, Middlewares: v.Middlewares})
 			}
 		}
 	}
@@ -318,6 +381,8 @@ func (p *Engine) Use(args interface{}) {
 	switch argsName {
 	case "func(*builder.Context) error":
 		p.useHandlers = append(p.useHandlers, args.(func(ctx *Context) error))
+	case "builder.Handle":
+		p.useHandlers = append(p.useHandlers, args.(Handle))
 	default:
 		panic(argsName + " arguments was not found")
 	}
@@ -399,17 +464,22 @@ func (p *Engine) Render(ctx *Context) error {
 		return err
 	}
 
-	// 解析UseHandler方法
-	err = ctx.useHandlerParser()
-	if err != nil {
-		if err.Error() == ctx.Next().Error() {
-			// 解析模版方法
-			return p.handleParser(ctx)
-		}
+	// 执行中间件及模版方法
+	return p.handle(ctx, nil, p.handleParser)
+}
+
+// 按全局中间件、路由组中间件、路由中间件、路由方法的顺序组装处理链并执行
+func (p *Engine) handle(ctx *Context, middlewares []Handle, handle Handle) error {
+	routeMiddlewares := ctx.routeMiddlewares()
+	handlers := make([]Handle, 0, len(p.useHandlers)+len(middlewares)+len(routeMiddlewares)+1)
+	for _, v := range p.useHandlers {
+		handlers = append(handlers, v)
 	}
+	handlers = append(handlers, middlewares...)
+	handlers = append(handlers, routeMiddlewares...)
+	handlers = append(handlers, handle)
 
-	// 解析模版方法
-	return err
+	return ctx.run(handlers)
 }
 
 // 处理模版上的路由映射关系
@@ -458,7 +528,7 @@ func (p *Engine) Echo() *echo.Echo {
 }
 
 // 适配Echo框架方法
-func (p *Engine) echoHandle(path string, handle Handle, c echo.Context) error {
+func (p *Engine) echoHandle(path string, middlewares []Handle, handle Handle, c echo.Context) error {
 	// 创建上下文
 	ctx := p.NewContext(c.Response().Writer, c.Request())
 
@@ -468,27 +538,55 @@ func (p *Engine) echoHandle(path string, handle Handle, c echo.Context) error {
 	// 初始化模板
 	ctx.InitTemplate(ctx)
 
-	// 解析UseHandler方法
-	err := ctx.useHandlerParser()
-	if err != nil {
-		if err.Error() == ctx.Next().Error() {
-			// 执行方法
-			return handle(ctx)
-		}
+	// 执行中间件及路由方法
+	return p.handle(ctx, middlewares, handle)
+}
+
+// 加载静态文件，pathPrefix自动添加路由前缀
+func (p *Engine) Static(pathPrefix string, fsRoot string) {
+	p.hasStatic = true
+	p.echo.Static(p.RoutePath(pathPrefix), fsRoot)
+}
+
+// 从文件系统加载静态文件，pathPrefix自动添加路由前缀
+func (p *Engine) StaticFS(pathPrefix string, filesystem fs.FS) {
+	p.hasStatic = true
+	p.echo.StaticFS(p.RoutePath(pathPrefix), filesystem)
+}
+
+// 获取WEB根目录的文件系统，优先读取静态文件目录下的app目录，不存在的文件使用内嵌的前端文件；
+// 设置了路由前缀时，前端的接口地址自动添加路由前缀
+func (p *Engine) WebFS() fs.FS {
+	filesystem := web.Overlay(p.config.StaticPath+"/app", web.Assets())
+	if p.config.RoutePrefix != "" {
+		filesystem = web.WithBase(filesystem, p.config.RoutePrefix)
 	}
 
-	return err
+	return filesystem
 }
 
-// 加载静态文件
-func (p *Engine) Static(pathPrefix string, fsRoot string) {
-	p.echo.Static(pathPrefix, fsRoot)
+// 获取添加路由前缀后的路径
+func (p *Engine) RoutePath(path string) string {
+	return p.config.RoutePrefix + path
+}
+
+// 去除路径中的路由前缀，用于其他框架匹配到的路由及请求路径
+func (p *Engine) TrimRoutePrefix(path string) string {
+	prefix := p.config.RoutePrefix
+	if prefix == "" || !strings.HasPrefix(path, prefix) {
+		return path
+	}
+	if len(path) > len(prefix) && path[len(prefix)] != '/' {
+		return path
+	}
+
+	return path[len(prefix):]
 }
 
 // GET请求
 func (p *Engine) GET(path string, handle Handle) error {
-	p.echo.GET(path, func(c echo.Context) error {
-		return p.echoHandle(path, handle, c)
+	p.echo.GET(p.RoutePath(path), func(c echo.Context) error {
+		return p.echoHandle(path, nil, handle, c)
 	})
 
 	return nil
@@ -496,8 +594,8 @@ func (p *Engine) GET(path string, handle Handle) error {
 
 // HEAD请求
 func (p *Engine) HEAD(path string, handle Handle) error {
-	p.echo.HEAD(path, func(c echo.Context) error {
-		return p.echoHandle(path, handle, c)
+	p.echo.HEAD(p.RoutePath(path), func(c echo.Context) error {
+		return p.echoHandle(path, nil, handle, c)
 	})
 
 	return nil
@@ -505,8 +603,8 @@ func (p *Engine) HEAD(path string, handle Handle) error {
 
 // OPTIONS请求
 func (p *Engine) OPTIONS(path string, handle Handle) error {
-	p.echo.OPTIONS(path, func(c echo.Context) error {
-		return p.echoHandle(path, handle, c)
+	p.echo.OPTIONS(p.RoutePath(path), func(c echo.Context) error {
+		return p.echoHandle(path, nil, handle, c)
 	})
 
 	return nil
@@ -514,8 +612,8 @@ func (p *Engine) OPTIONS(path string, handle Handle) error {
 
 // POST请求
 func (p *Engine) POST(path string, handle Handle) error {
-	p.echo.POST(path, func(c echo.Context) error {
-		return p.echoHandle(path, handle, c)
+	p.echo.POST(p.RoutePath(path), func(c echo.Context) error {
+		return p.echoHandle(path, nil, handle, c)
 	})
 
 	return nil
@@ -523,8 +621,8 @@ func (p *Engine) POST(path string, handle Handle) error {
 
 // PUT请求
 func (p *Engine) PUT(path string, handle Handle) error {
-	p.echo.PUT(path, func(c echo.Context) error {
-		return p.echoHandle(path, handle, c)
+	p.echo.PUT(p.RoutePath(path), func(c echo.Context) error {
+		return p.echoHandle(path, nil, handle, c)
 	})
 
 	return nil
@@ -532,8 +630,8 @@ func (p *Engine) PUT(path string, handle Handle) error {
 
 // PATCH请求
 func (p *Engine) PATCH(path string, handle Handle) error {
-	p.echo.PATCH(path, func(c echo.Context) error {
-		return p.echoHandle(path, handle, c)
+	p.echo.PATCH(p.RoutePath(path), func(c echo.Context) error {
+		return p.echoHandle(path, nil, handle, c)
 	})
 
 	return nil
@@ -541,8 +639,8 @@ func (p *Engine) PATCH(path string, handle Handle) error {
 
 // DELETE请求
 func (p *Engine) DELETE(path string, handle Handle) error {
-	p.echo.DELETE(path, func(c echo.Context) error {
-		return p.echoHandle(path, handle, c)
+	p.echo.DELETE(p.RoutePath(path), func(c echo.Context) error {
+		return p.echoHandle(path, nil, handle, c)
 	})
 
 	return nil
@@ -550,41 +648,27 @@ func (p *Engine) DELETE(path string, handle Handle) error {
 
 // Any请求
 func (p *Engine) Any(path string, handle Handle) error {
-	p.echo.Any(path, func(c echo.Context) error {
-		return p.echoHandle(path, handle, c)
+	p.echo.Any(p.RoutePath(path), func(c echo.Context) error {
+		return p.echoHandle(path, nil, handle, c)
 	})
 
 	return nil
 }
 
-// 路由组
+// 路由组，handlers为路由组中间件，按传入顺序执行
 func (p *Engine) Group(path string, handlers ...Handle) *Group {
-	echoGroup := p.echo.Group(path, func(next echo.HandlerFunc) echo.HandlerFunc {
-		if len(handlers) > 0 {
-			for _, handle := range handlers {
-				newHandle := func(c echo.Context) error {
-					err := p.echoHandle(path, handle, c)
-					if err != nil {
-						// 执行下一步操作，这里应该放到数组里面执行，暂时用NextUseHandler
-						if err.Error() == "NextUseHandler" {
-							return next(c)
-						}
-					}
-					return err
-				}
-				return newHandle
-			}
-		}
-		return next
-	})
-
-	return &Group{engine: p, echoGroup: echoGroup}
+	return &Group{
+		engine:    p,
+		echoGroup: p.echo.Group(p.RoutePath(path)),
+		prefix:    path,
+		handlers:  handlers,
+	}
 }
 
 // GET请求
 func (p *Group) GET(path string, handle Handle) error {
 	p.echoGroup.GET(path, func(c echo.Context) error {
-		return p.engine.echoHandle(path, handle, c)
+		return p.engine.echoHandle(p.prefix+path, p.handlers, handle, c)
 	})
 
 	return nil
@@ -593,7 +677,7 @@ func (p *Group) GET(path string, handle Handle) error {
 // HEAD请求
 func (p *Group) HEAD(path string, handle Handle) error {
 	p.echoGroup.HEAD(path, func(c echo.Context) error {
-		return p.engine.echoHandle(path, handle, c)
+		return p.engine.echoHandle(p.prefix+path, p.handlers, handle, c)
 	})
 
 	return nil
@@ -602,7 +686,7 @@ func (p *Group) HEAD(path string, handle Handle) error {
 // OPTIONS请求
 func (p *Group) OPTIONS(path string, handle Handle) error {
 	p.echoGroup.OPTIONS(path, func(c echo.Context) error {
-		return p.engine.echoHandle(path, handle, c)
+		return p.engine.echoHandle(p.prefix+path, p.handlers, handle, c)
 	})
 
 	return nil
@@ -611,7 +695,7 @@ func (p *Group) OPTIONS(path string, handle Handle) error {
 // POST请求
 func (p *Group) POST(path string, handle Handle) error {
 	p.echoGroup.POST(path, func(c echo.Context) error {
-		return p.engine.echoHandle(path, handle, c)
+		return p.engine.echoHandle(p.prefix+path, p.handlers, handle, c)
 	})
 
 	return nil
@@ -620,7 +704,7 @@ func (p *Group) POST(path string, handle Handle) error {
 // PUT请求
 func (p *Group) PUT(path string, handle Handle) error {
 	p.echoGroup.PUT(path, func(c echo.Context) error {
-		return p.engine.echoHandle(path, handle, c)
+		return p.engine.echoHandle(p.prefix+path, p.handlers, handle, c)
 	})
 
 	return nil
@@ -629,7 +713,7 @@ func (p *Group) PUT(path string, handle Handle) error {
 // PATCH请求
 func (p *Group) PATCH(path string, handle Handle) error {
 	p.echoGroup.PATCH(path, func(c echo.Context) error {
-		return p.engine.echoHandle(path, handle, c)
+		return p.engine.echoHandle(p.prefix+path, p.handlers, handle, c)
 	})
 
 	return nil
@@ -638,7 +722,7 @@ func (p *Group) PATCH(path string, handle Handle) error {
 // DELETE请求
 func (p *Group) DELETE(path string, handle Handle) error {
 	p.echoGroup.DELETE(path, func(c echo.Context) error {
-		return p.engine.echoHandle(path, handle, c)
+		return p.engine.echoHandle(p.prefix+path, p.handlers, handle, c)
 	})
 
 	return nil
@@ -647,40 +731,48 @@ func (p *Group) DELETE(path string, handle Handle) error {
 // Any请求
 func (p *Group) Any(path string, handle Handle) error {
 	p.echoGroup.Any(path, func(c echo.Context) error {
-		return p.engine.echoHandle(path, handle, c)
+		return p.engine.echoHandle(p.prefix+path, p.handlers, handle, c)
 	})
 
 	return nil
 }
 
-// 路由组
+// 路由组，继承上级路由组的前缀及中间件
 func (p *Group) Group(path string, handlers ...Handle) *Group {
-	echoGroup := p.engine.echo.Group(path, func(next echo.HandlerFunc) echo.HandlerFunc {
-		if len(handlers) > 0 {
-			for _, handle := range handlers {
-				newHandle := func(c echo.Context) error {
-					err := p.engine.echoHandle(path, handle, c)
-					if err != nil {
-						// 执行下一步操作，这里应该放到数组里面执行，暂时用NextUseHandler
-						if err.Error() == "NextUseHandler" {
-							return next(c)
-						}
-					}
-					return err
-				}
-				return newHandle
-			}
+	groupHandlers := make([]Handle, 0, len(p.handlers)+len(handlers))
+	groupHandlers = append(groupHandlers, p.handlers...)
+	groupHandlers = append(groupHandlers, handlers...)
+
+	return &Group{
+		engine:    p.engine,
+		echoGroup: p.echoGroup.Group(path),
+		prefix:    p.prefix + path,
+		handlers:  groupHandlers,
+	}
+}
+
+// 注册模版上的路由，多次调用时只注册一次
+func (p *Engine) mountRoutes() {
+	p.routeOnce.Do(p.routeMappingParser)
+}
+
+// 实现http.Handler接口，处理模版路由、自定义路由及静态文件，未加载静态文件时使用WEB根目录的文件系统；
+// 挂载在前缀下时需设置Config.RoutePrefix，并且不能使用http.StripPrefix去除前缀
+func (p *Engine) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	p.mountRoutes()
+	p.staticOnce.Do(func() {
+		if !p.hasStatic {
+			p.StaticFS("/", p.WebFS())
 		}
-		return next
 	})
 
-	return &Group{engine: p.engine, echoGroup: echoGroup}
+	p.echo.ServeHTTP(w, r)
 }
 
 // Run Server
 func (p *Engine) Run(addr string) {
 	// 处理模版上的路由映射关系
-	p.routeMappingParser()
+	p.mountRoutes()
 
 	// 启动服务
 	p.echo.Logger.Fatal(p.echo.Start(addr))
@@ -0,0 +1,62 @@
+package builder
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// 挂载在/admin-panel前缀下
+func TestEngineRoutePrefix(t *testing.T) {
+	b := New(&Config{AppKey: "123456", RoutePrefix: "/admin-panel/"})
+	b.GET("/api/admin/ping", func(ctx *Context) error {
+		return ctx.String(200, ctx.FullPath()+" "+ctx.Path())
+	})
+	b.Group("/api/admin/group").GET("/ping", func(ctx *Context) error {
+		return ctx.String(200, ctx.FullPath()+" "+ctx.Path())
+	})
+
+	mux := http.NewServeMux()
+	mux.Handle("/admin-panel/", b)
+
+	tests := []struct {
+		path   string
+		status int
+		body   string
+	}{
+		{"/admin-panel/api/admin/ping", 200, "/api/admin/ping /api/admin/ping"},
+		{"/admin-panel/api/admin/group/ping", 200, "/api/admin/group/ping /api/admin/group/ping"},
+		{"/admin-panel/admin/", 200, `XMLHttpRequest.prototype.open`},
+		{"/admin-panel/admin/config.json", 200, `"/api/admin/layout/index/index"`},
+		{"/api/admin/ping", 404, ""},
+	}
+	for _, test := range tests {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, test.path, nil))
+		if w.Code != test.status {
+			t.Fatalf("%s: status %d, want %d", test.path, w.Code, test.status)
+		}
+		if !strings.Contains(w.Body.String(), test.body) {
+			t.Fatalf("%s: body %q does not contain %q", test.path, w.Body.String(), test.body)
+		}
+	}
+
+	// 首页注入的接口前缀
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin-panel/admin/index.html", nil))
+	if !strings.Contains(w.Body.String(), `"/admin-panel"`) {
+		t.Fatalf("index.html does not contain the route prefix: %s", w.Body.String())
+	}
+
+	// 适配其他框架时的路由路径包含前缀，处理请求时去除前缀
+	if got := b.RoutePath("/api/admin/:resource/index"); got != "/admin-panel/api/admin/:resource/index" {
+		t.Fatalf("RoutePath = %q", got)
+	}
+	if got := b.TrimRoutePrefix("/admin-panel/api/admin/:resource/index"); got != "/api/admin/:resource/index" {
+		t.Fatalf("TrimRoutePrefix = %q", got)
+	}
+	if got := b.TrimRoutePrefix("/admin-panelx/api"); got != "/admin-panelx/api" {
+		t.Fatalf("TrimRoutePrefix = %q", got)
+	}
+}
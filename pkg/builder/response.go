@@ -1,6 +1,7 @@
 package builder
 
 import (
+	"bytes"
 	"io"
 	"net/http"
 )
@@ -39,3 +40,38 @@ func (w *Response) Write(p []byte) (int, error) {
 }
 
 func (w *Response) Flush() {}
+
+// 缓存响应数据的Writer
+type bufferedWriter struct {
+	statusCode int
+	h          http.Header
+	body       bytes.Buffer
+	written    bool
+}
+
+func newBufferedWriter() *bufferedWriter {
+	return &bufferedWriter{h: make(http.Header)}
+}
+
+func (w *bufferedWriter) StatusCode() int {
+	if w.statusCode == 0 {
+		return http.StatusOK
+	}
+	return w.statusCode
+}
+
+func (w *bufferedWriter) Header() http.Header {
+	return w.h
+}
+
+func (w *bufferedWriter) WriteHeader(statusCode int) {
+	w.statusCode = statusCode
+	w.written = true
+}
+
+func (w *bufferedWriter) Write(p []byte) (int, error) {
+	w.written = true
+	return w.body.Write(p)
+}
+
+func (w *bufferedWriter) Flush() {}
@@ -25,31 +25,31 @@ type Templater interface {
 	GetRouteMapping() []*RouteMapping
 
 	// 添加路由
-	AddRouteMapping(method string, path string, handler func(ctx *Context) error) *Template
+	AddRouteMapping(method string, path string, handler func(ctx *Context) error, middlewares ...Handle) *Template
 
 	// ANY请求
-	Any(path string, handler func(ctx *Context) error)
+	Any(path string, handler func(ctx *Context) error, middlewares ...Handle)
 
 	// GET请求
-	GET(path string, handler func(ctx *Context) error)
+	GET(path string, handler func(ctx *Context) error, middlewares ...Handle)
 
 	// HEAD请求
-	HEAD(path string, handler func(ctx *Context) error)
+	HEAD(path string, handler func(ctx *Context) error, middlewares ...Handle)
 
 	// OPTIONS请求
-	OPTIONS(path string, handler func(ctx *Context) error)
+	OPTIONS(path string, handler func(ctx *Context) error, middlewares ...Handle)
 
 	// POST请求
-	POST(path string, handler func(ctx *Context) error)
+	POST(path string, handler func(ctx *Context) error, middlewares ...Handle)
 
 	// PUT请求
-	PUT(path string, handler func(ctx *Context) error)
+	PUT(path string, handler func(ctx *Context) error, middlewares ...Handle)
 
 	// PATCH请求
-	PATCH(path string, handler func(ctx *Context) error)
+	PATCH(path string, handler func(ctx *Context) error, middlewares ...Handle)
 
 	// DELETE请求
-	DELETE(path string, handler func(ctx *Context) error)
+	DELETE(path string, handler func(ctx *Context) error, middlewares ...Handle)
 }
 
 // 模板
@@ -79,13 +79,14 @@ func (p *Template) hasRouteMapping(method string, path string) bool {
 	return has
 }
 
-// 注册路由
-func (p *Template) AddRouteMapping(method string, path string, handler func(ctx *Context) error) *Template {
+// 注册路由，middlewares为仅作用于当前模板路由的中间件
+func (p *Template) AddRouteMapping(method string, path string, handler func(ctx *Context) error, middlewares ...Handle) *Template {
 	if !p.hasRouteMapping(method, path) {
 		getRoute := &RouteMapping{
-			Method:  method,
-			Path:    path,
-			Handler: handler,
+			Method:      method,
+			Path:        path,
+			Handler:     handler,
+			Middlewares: middlewares,
 		}
 
 		p.RouteMapping = append(p.RouteMapping, getRoute)
@@ -94,41 +95,41 @@ func (p *Template) AddRouteMapping(method string, path string, handler func(ctx
 }
 
 // ANY请求
-func (p *Template) Any(path string, handler func(ctx *Context) error) {
-	p.AddRouteMapping("Any", path, handler)
+func (p *Template) Any(path string, handler func(ctx *Context) error, middlewares ...Handle) {
+	p.AddRouteMapping("Any", path, handler, middlewares...)
 }
 
 // GET请求
-func (p *Template) GET(path string, handler func(ctx *Context) error) {
-	p.AddRouteMapping(http.MethodGet, path, handler)
+func (p *Template) GET(path string, handler func(ctx *Context) error, middlewares ...Handle) {
+	p.AddRouteMapping(http.MethodGet, path, handler, middlewares...)
 }
 
 // HEAD请求
-func (p *Template) HEAD(path string, handler func(ctx *Context) error) {
-	p.AddRouteMapping(http.MethodHead, path, handler)
+func (p *Template) HEAD(path string, handler func(ctx *Context) error, middlewares ...Handle) {
+	p.AddRouteMapping(http.MethodHead, path, handler, middlewares...)
 }
 
 // OPTIONS请求
-func (p *Template) OPTIONS(path string, handler func(ctx *Context) error) {
-	p.AddRouteMapping(http.MethodOptions, path, handler)
+func (p *Template) OPTIONS(path string, handler func(ctx *Context) error, middlewares ...Handle) {
+	p.AddRouteMapping(http.MethodOptions, path, handler, middlewares...)
 }
 
 // POST请求
-func (p *Template) POST(path string, handler func(ctx *Context) error) {
-	p.AddRouteMapping(http.MethodPost, path, handler)
+func (p *Template) POST(path string, handler func(ctx *Context) error, middlewares ...Handle) {
+	p.AddRouteMapping(http.MethodPost, path, handler, middlewares...)
 }
 
 // PUT请求
-func (p *Template) PUT(path string, handler func(ctx *Context) error) {
-	p.AddRouteMapping(http.MethodPut, path, handler)
+func (p *Template) PUT(path string, handler func(ctx *Context) error, middlewares ...Handle) {
+	p.AddRouteMapping(http.MethodPut, path, handler, middlewares...)
 }
 
 // PATCH请求
-func (p *Template) PATCH(path string, handler func(ctx *Context) error) {
-	p.AddRouteMapping(http.MethodPatch, path, handler)
+func (p *Template) PATCH(path string, handler func(ctx *Context) error, middlewares ...Handle) {
+	p.AddRouteMapping(http.MethodPatch, path, handler, middlewares...)
 }
 
 // DELETE请求
-func (p *Template) DELETE(path string, handler func(ctx *Context) error) {
-	p.AddRouteMapping(http.MethodDelete, path, handler)
+func (p *Template) DELETE(path string, handler func(ctx *Context) error, middlewares ...Handle) {
+	p.AddRouteMapping(http.MethodDelete, path, handler, middlewares...)
 }
@@ -0,0 +1,118 @@
+package cache
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// 缓存不存在
+var ErrNotFound = errors.New("cache: key not found")
+
+// 缓存接口
+type Cache interface {
+
+	// 获取缓存，不存在时返回ErrNotFound
+	Get(key string) (string, error)
+
+	// 设置缓存，ttl为0时永不过期
+	Set(key string, value string, ttl time.Duration) error
+
+	// 删除缓存
+	Delete(keys ...string) error
+
+	// 删除指定前缀的缓存
+	DeletePrefix(prefix string) error
+
+	// 获取缓存剩余有效期，永不过期时返回0，不存在时返回ErrNotFound
+	TTL(key string) (time.Duration, error)
+
+	// 获取缓存，不存在时执行fn并将结果写入缓存
+	Remember(key string, ttl time.Duration, fn func() (string, error)) (string, error)
+
+	// 原子地将计数加1并返回加1后的值，缓存不存在时从0开始计数并设置有效期，已存在时不改变有效期
+	Incr(key string, ttl time.Duration) (int64, error)
+}
+
+// 默认使用进程内LRU缓存，配置Redis后替换为Redis缓存
+var Client Cache = NewMemory(10000)
+
+// 设置默认缓存
+func Init(cache Cache) {
+	Client = cache
+}
+
+// 获取缓存
+func Get(key string) (string, error) {
+	return Client.Get(key)
+}
+
+// 设置缓存
+func Set(key string, value string, ttl time.Duration) error {
+	return Client.Set(key, value, ttl)
+}
+
+// 删除缓存
+func Delete(keys ...string) error {
+	return Client.Delete(keys...)
+}
+
+// 删除指定前缀的缓存
+func DeletePrefix(prefix string) error {
+	return Client.DeletePrefix(prefix)
+}
+
+// 获取缓存剩余有效期
+func TTL(key string) (time.Duration, error) {
+	return Client.TTL(key)
+}
+
+// 获取缓存，不存在时执行fn并将结果写入缓存
+func Remember(key string, ttl time.Duration, fn func() (string, error)) (string, error) {
+	return Client.Remember(key, ttl, fn)
+}
+
+// 原子地将计数加1并返回加1后的值
+func Incr(key string, ttl time.Duration) (int64, error) {
+	return Client.Incr(key, ttl)
+}
+
+// 获取JSON格式的缓存并解析到dest，不存在时执行fn并将结果编码后写入缓存
+func RememberJSON(key string, ttl time.Duration, dest interface{}, fn func() (interface{}, error)) error {
+	value, err := Client.Remember(key, ttl, func() (string, error) {
+		data, err := fn()
+		if err != nil {
+			return "", err
+		}
+
+		bytes, err := json.Marshal(data)
+		if err != nil {
+			return "", err
+		}
+
+		return string(bytes), nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal([]byte(value), dest)
+}
+
+// Remember的通用实现
+func remember(cache Cache, key string, ttl time.Duration, fn func() (string, error)) (string, error) {
+	value, err := cache.Get(key)
+	if err == nil {
+		return value, nil
+	}
+	if !errors.Is(err, ErrNotFound) {
+		return "", err
+	}
+
+	value, err = fn()
+	if err != nil {
+		return "", err
+	}
+
+	return value, cache.Set(key, value, ttl)
+}
@@ -0,0 +1,180 @@
+package cache
+
+import (
+	"container/list"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// 进程内LRU缓存
+type Memory struct {
+	mu       sync.Mutex
+	capacity int
+	items    map[string]*list.Element
+	lru      *list.List
+}
+
+// 缓存项
+type memoryItem struct {
+	key       string
+	value     string
+	expiredAt time.Time
+}
+
+// 初始化进程内LRU缓存，capacity为最大缓存条数
+func NewMemory(capacity int) *Memory {
+	return &Memory{
+		capacity: capacity,
+		items:    map[string]*list.Element{},
+		lru:      list.New(),
+	}
+}
+
+// 判断是否过期
+func (p *memoryItem) expired(now time.Time) bool {
+	return !p.expiredAt.IsZero() && now.After(p.expiredAt)
+}
+
+// 获取未过期的缓存项，调用方需持有锁
+func (p *Memory) get(key string) (*memoryItem, bool) {
+	element, ok := p.items[key]
+	if !ok {
+		return nil, false
+	}
+
+	item := element.Value.(*memoryItem)
+	if item.expired(time.Now()) {
+		p.lru.Remove(element)
+		delete(p.items, key)
+		return nil, false
+	}
+
+	return item, true
+}
+
+// 获取缓存
+func (p *Memory) Get(key string) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	item, ok := p.get(key)
+	if !ok {
+		return "", ErrNotFound
+	}
+	p.lru.MoveToFront(p.items[key])
+
+	return item.value, nil
+}
+
+// 设置缓存
+func (p *Memory) Set(key string, value string, ttl time.Duration) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	expiredAt := time.Time{}
+	if ttl > 0 {
+		expiredAt = time.Now().Add(ttl)
+	}
+
+	if element, ok := p.items[key]; ok {
+		item := element.Value.(*memoryItem)
+		item.value = value
+		item.expiredAt = expiredAt
+		p.lru.MoveToFront(element)
+		return nil
+	}
+
+	p.items[key] = p.lru.PushFront(&memoryItem{key: key, value: value, expiredAt: expiredAt})
+	p.evict()
+
+	return nil
+}
+
+// 超出容量时淘汰最久未使用的缓存，调用方需持有锁
+func (p *Memory) evict() {
+	for p.capacity > 0 && p.lru.Len() > p.capacity {
+		element := p.lru.Back()
+		p.lru.Remove(element)
+		delete(p.items, element.Value.(*memoryItem).key)
+	}
+}
+
+// 计数加1，在同一把锁内完成读取及写入
+func (p *Memory) Incr(key string, ttl time.Duration) (int64, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if item, ok := p.get(key); ok {
+		value, err := strconv.ParseInt(item.value, 10, 64)
+		if err != nil {
+			return 0, err
+		}
+		value++
+		item.value = strconv.FormatInt(value, 10)
+		p.lru.MoveToFront(p.items[key])
+
+		return value, nil
+	}
+
+	expiredAt := time.Time{}
+	if ttl > 0 {
+		expiredAt = time.Now().Add(ttl)
+	}
+	p.items[key] = p.lru.PushFront(&memoryItem{key: key, value: "1", expiredAt: expiredAt})
+	p.evict()
+
+	return 1, nil
+}
+
+// 删除缓存
+func (p *Memory) Delete(keys ...string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, key := range keys {
+		if element, ok := p.items[key]; ok {
+			p.lru.Remove(element)
+			delete(p.items, key)
+		}
+	}
+
+	return nil
+}
+
+// 删除指定前缀的缓存
+func (p *Memory) DeletePrefix(prefix string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for key, element := range p.items {
+		if strings.HasPrefix(key, prefix) {
+			p.lru.Remove(element)
+			delete(p.items, key)
+		}
+	}
+
+	return nil
+}
+
+// 获取缓存剩余有效期
+func (p *Memory) TTL(key string) (time.Duration, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	item, ok := p.get(key)
+	if !ok {
+		return 0, ErrNotFound
+	}
+	if item.expiredAt.IsZero() {
+		return 0, nil
+	}
+
+	return time.Until(item.expiredAt), nil
+}
+
+// 获取缓存，不存在时执行fn并将结果写入缓存
+func (p *Memory) Remember(key string, ttl time.Duration, fn func() (string, error)) (string, error) {
+	return remember(p, key, ttl, fn)
+}
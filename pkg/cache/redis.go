@@ -0,0 +1,107 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Redis缓存
+type Redis struct {
+	client *redis.Client
+	prefix string
+}
+
+// 初始化Redis缓存，prefix用于隔离不同应用的缓存键
+func NewRedis(client *redis.Client, prefix string) *Redis {
+	return &Redis{
+		client: client,
+		prefix: prefix,
+	}
+}
+
+// 获取缓存
+func (p *Redis) Get(key string) (string, error) {
+	value, err := p.client.Get(context.Background(), p.prefix+key).Result()
+	if err == redis.Nil {
+		return "", ErrNotFound
+	}
+
+	return value, err
+}
+
+// 设置缓存
+func (p *Redis) Set(key string, value string, ttl time.Duration) error {
+	return p.client.Set(context.Background(), p.prefix+key, value, ttl).Err()
+}
+
+// 计数加1并在首次计数时设置有效期，使用脚本保证两步操作的原子性
+var incrScript = redis.NewScript(`
+local value = redis.call("INCR", KEYS[1])
+if value == 1 and tonumber(ARGV[1]) > 0 then
+	redis.call("PEXPIRE", KEYS[1], ARGV[1])
+end
+return value
+`)
+
+// 计数加1
+func (p *Redis) Incr(key string, ttl time.Duration) (int64, error) {
+	return incrScript.Run(context.Background(), p.client, []string{p.prefix + key}, ttl.Milliseconds()).Int64()
+}
+
+// 删除缓存
+func (p *Redis) Delete(keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	prefixedKeys := []string{}
+	for _, key := range keys {
+		prefixedKeys = append(prefixedKeys, p.prefix+key)
+	}
+
+	return p.client.Del(context.Background(), prefixedKeys...).Err()
+}
+
+// 删除指定前缀的缓存
+func (p *Redis) DeletePrefix(prefix string) error {
+	ctx := context.Background()
+	iter := p.client.Scan(ctx, 0, p.prefix+prefix+"*", 100).Iterator()
+
+	keys := []string{}
+	for iter.Next(ctx) {
+		keys = append(keys, iter.Val())
+	}
+	if err := iter.Err(); err != nil {
+		return err
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+
+	return p.client.Del(ctx, keys...).Err()
+}
+
+// 获取缓存剩余有效期
+func (p *Redis) TTL(key string) (time.Duration, error) {
+	ttl, err := p.client.TTL(context.Background(), p.prefix+key).Result()
+	if err != nil {
+		return 0, err
+	}
+
+	// -2表示不存在，-1表示永不过期
+	switch ttl {
+	case -2, -2 * time.Second:
+		return 0, ErrNotFound
+	case -1, -1 * time.Second:
+		return 0, nil
+	}
+
+	return ttl, nil
+}
+
+// 获取缓存，不存在时执行fn并将结果写入缓存
+func (p *Redis) Remember(key string, ttl time.Duration, fn func() (string, error)) (string, error) {
+	return remember(p, key, ttl, fn)
+}
@@ -0,0 +1,45 @@
+package db
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// 事务提交后执行的方法列表在Context中的键
+type afterCommitKey struct{}
+
+// 在事务中执行fc，提交后依次执行通过AfterCommit注册的方法，回滚时不执行
+func Transaction(fc func(tx *gorm.DB) error) error {
+	return TransactionOn(Client, fc)
+}
+
+// 在指定的数据库连接上开启事务，用法同Transaction
+func TransactionOn(client *gorm.DB, fc func(tx *gorm.DB) error) error {
+	callbacks := &[]func(){}
+
+	err := client.
+		WithContext(context.WithValue(context.Background(), afterCommitKey{}, callbacks)).
+		Transaction(fc)
+	if err != nil {
+		return err
+	}
+
+	for _, fn := range *callbacks {
+		fn()
+	}
+
+	return nil
+}
+
+// 注册事务提交后执行的方法，例如清除缓存、重新加载权限策略，tx不是通过Transaction开启的事务时立即执行
+func AfterCommit(tx *gorm.DB, fn func()) {
+	if tx != nil && tx.Statement != nil && tx.Statement.Context != nil {
+		if callbacks, ok := tx.Statement.Context.Value(afterCommitKey{}).(*[]func()); ok {
+			*callbacks = append(*callbacks, fn)
+			return
+		}
+	}
+
+	fn()
+}
@@ -13,6 +13,6 @@ func InitDB(dialector gorm.Dialector, opts gorm.Option) {
 }
 
 // Init init redis
-func InitRedis(options *redis.Options) {
-	redisclient.Init(options)
+func InitRedis(options *redis.Options) error {
+	return redisclient.Init(options)
 }
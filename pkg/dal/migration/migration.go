@@ -0,0 +1,230 @@
+package migration
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	dbclient "github.com/quarkcloudio/quark-go/v2/pkg/dal/db"
+	"gorm.io/gorm"
+)
+
+// 迁移
+type Migration struct {
+	Version string                  // 版本号，按字典序执行，建议格式：2023_10_01_000000_create_admins_table
+	Up      func(tx *gorm.DB) error // 执行迁移
+	Down    func(tx *gorm.DB) error // 回滚迁移
+}
+
+// 迁移记录
+type Record struct {
+	Id        int       `json:"id" gorm:"autoIncrement"`
+	Version   string    `json:"version" gorm:"size:255;uniqueIndex;not null"`
+	Batch     int       `json:"batch" gorm:"not null"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// 迁移记录表名
+func (Record) TableName() string {
+	return "migrations"
+}
+
+var (
+	mu         sync.Mutex
+	migrations = map[string]*Migration{}
+)
+
+// 注册迁移，版本号相同的迁移只注册一次
+func Register(items ...*Migration) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	for _, item := range items {
+		if _, ok := migrations[item.Version]; ok {
+			continue
+		}
+		migrations[item.Version] = item
+	}
+}
+
+// 获取已注册的迁移，按版本号排序
+func Migrations() []*Migration {
+	mu.Lock()
+	defer mu.Unlock()
+
+	items := []*Migration{}
+	for _, v := range migrations {
+		items = append(items, v)
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].Version < items[j].Version
+	})
+
+	return items
+}
+
+// 获取已执行的迁移记录
+func Records(db *gorm.DB) (records []*Record, err error) {
+	if err = db.AutoMigrate(&Record{}); err != nil {
+		return nil, err
+	}
+
+	err = db.Order("batch asc, id asc").Find(&records).Error
+
+	return records, err
+}
+
+// 获取未执行的迁移
+func Pending(db *gorm.DB) ([]*Migration, error) {
+	records, err := Records(db)
+	if err != nil {
+		return nil, err
+	}
+
+	ran := map[string]bool{}
+	for _, v := range records {
+		ran[v.Version] = true
+	}
+
+	items := []*Migration{}
+	for _, v := range Migrations() {
+		if !ran[v.Version] {
+			items = append(items, v)
+		}
+	}
+
+	return items, nil
+}
+
+// 执行所有未执行的迁移，每个迁移在独立的事务中执行
+func Up(db *gorm.DB) error {
+	records, err := Records(db)
+	if err != nil {
+		return err
+	}
+
+	pending, err := Pending(db)
+	if err != nil {
+		return err
+	}
+
+	batch := 1
+	for _, v := range records {
+		if v.Batch >= batch {
+			batch = v.Batch + 1
+		}
+	}
+
+	for _, item := range pending {
+		err := dbclient.TransactionOn(db, func(tx *gorm.DB) error {
+			if item.Up != nil {
+				if err := item.Up(tx); err != nil {
+					return err
+				}
+			}
+
+			return tx.Create(&Record{Version: item.Version, Batch: batch}).Error
+		})
+		if err != nil {
+			return fmt.Errorf("migration %s: %w", item.Version, err)
+		}
+	}
+
+	return nil
+}
+
+// 判断迁移是否已执行
+func Ran(db *gorm.DB, version string) (bool, error) {
+	records, err := Records(db)
+	if err != nil {
+		return false, err
+	}
+
+	for _, v := range records {
+		if v.Version == version {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// 将迁移标记为已执行而不执行Up，用于接管迁移功能之前已安装的数据库，已记录的版本会被忽略
+func MarkRan(db *gorm.DB, versions ...string) error {
+	records, err := Records(db)
+	if err != nil {
+		return err
+	}
+
+	ran := map[string]bool{}
+	batch := 1
+	for _, v := range records {
+		ran[v.Version] = true
+		if v.Batch >= batch {
+			batch = v.Batch + 1
+		}
+	}
+
+	for _, version := range versions {
+		if ran[version] {
+			continue
+		}
+		err := db.Create(&Record{Version: version, Batch: batch}).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// 回滚最近执行的steps个批次
+func Rollback(db *gorm.DB, steps int) error {
+	records, err := Records(db)
+	if err != nil {
+		return err
+	}
+
+	if steps <= 0 {
+		steps = 1
+	}
+
+	registered := map[string]*Migration{}
+	for _, v := range Migrations() {
+		registered[v.Version] = v
+	}
+
+	batches := 0
+	lastBatch := 0
+	for i := len(records) - 1; i >= 0; i-- {
+		record := records[i]
+		if record.Batch != lastBatch {
+			batches++
+			lastBatch = record.Batch
+		}
+		if batches > steps {
+			break
+		}
+
+		item, ok := registered[record.Version]
+		if !ok {
+			return fmt.Errorf("migration %s is not registered", record.Version)
+		}
+
+		err := dbclient.TransactionOn(db, func(tx *gorm.DB) error {
+			if item.Down != nil {
+				if err := item.Down(tx); err != nil {
+					return err
+				}
+			}
+
+			return tx.Delete(&Record{}, record.Id).Error
+		})
+		if err != nil {
+			return fmt.Errorf("rollback %s: %w", record.Version, err)
+		}
+	}
+
+	return nil
+}
@@ -8,9 +8,15 @@ import (
 
 var Client *redis.Client
 
-func Init(options *redis.Options) {
-	Client = redis.NewClient(options)
-	if err := Client.Ping(context.Background()).Err(); err != nil {
-		panic(err)
+// Init init redis，连接失败时Client为nil
+func Init(options *redis.Options) error {
+	client := redis.NewClient(options)
+	if err := client.Ping(context.Background()).Err(); err != nil {
+		client.Close()
+		return err
 	}
+
+	Client = client
+
+	return nil
 }
@@ -2,6 +2,8 @@ package gopkg
 
 import (
 	"archive/zip"
+	"crypto/sha256"
+	"encoding/base64"
 	"fmt"
 	"io"
 	"io/fs"
@@ -9,6 +11,8 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"sort"
+	"strings"
 
 	"github.com/quarkcloudio/quark-go/v2/pkg/utils/file"
 )
@@ -16,10 +20,14 @@ import (
 // 包域名地址
 const domain = "https://goproxy.cn/"
 
+// 校验和数据库地址
+const sumdb = "https://sum.golang.org/lookup/"
+
 // 结构体
 type PkgGo struct {
-	Name    string
-	Version string
+	Name     string
+	Version  string
+	Checksum string // 模块压缩包的哈希，格式与go.sum相同，例如：h1:xxx，为空时从校验和数据库获取
 }
 
 func New(name string, version string) *PkgGo {
@@ -29,6 +37,13 @@ func New(name string, version string) *PkgGo {
 	}
 }
 
+// 设置模块压缩包的哈希
+func (p *PkgGo) SetChecksum(checksum string) *PkgGo {
+	p.Checksum = checksum
+
+	return p
+}
+
 // 下载文件
 func (p *PkgGo) Download() error {
 
@@ -38,10 +53,14 @@ func (p *PkgGo) Download() error {
 	// Get the data
 	resp, err := http.Get(fileUrl)
 	if err != nil {
-		panic(err)
+		return err
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("download %s: %s", fileUrl, resp.Status)
+	}
+
 	if !file.IsExist("./tmp") {
 		os.MkdirAll("./tmp", 0775)
 	}
@@ -49,7 +68,7 @@ func (p *PkgGo) Download() error {
 	// 创建文件用于保存
 	out, err := os.Create("./tmp/v" + p.Version + ".zip")
 	if err != nil {
-		panic(err)
+		return err
 	}
 	defer out.Close()
 
@@ -59,6 +78,83 @@ func (p *PkgGo) Download() error {
 	return err
 }
 
+// 校验下载的文件，哈希算法与go.sum中的h1相同
+func (p *PkgGo) Verify() error {
+	checksum := p.Checksum
+	if checksum == "" {
+		var err error
+		checksum, err = p.lookupChecksum()
+		if err != nil {
+			return err
+		}
+	}
+
+	reader, err := zip.OpenReader("./tmp/v" + p.Version + ".zip")
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	files := reader.File
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].Name < files[j].Name
+	})
+
+	summary := sha256.New()
+	for _, file := range files {
+		if strings.Contains(file.Name, "\n") {
+			return fmt.Errorf("invalid file name in zip: %q", file.Name)
+		}
+
+		r, err := file.Open()
+		if err != nil {
+			return err
+		}
+		h := sha256.New()
+		_, err = io.Copy(h, r)
+		r.Close()
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(summary, "%x  %s\n", h.Sum(nil), file.Name)
+	}
+
+	result := "h1:" + base64.StdEncoding.EncodeToString(summary.Sum(nil))
+	if result != checksum {
+		return fmt.Errorf("checksum mismatch for %s@v%s: downloaded %s, expected %s", p.Name, p.Version, result, checksum)
+	}
+
+	return nil
+}
+
+// 从校验和数据库获取模块压缩包的哈希
+func (p *PkgGo) lookupChecksum() (string, error) {
+	resp, err := http.Get(sumdb + p.Name + "@v" + p.Version)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("lookup checksum for %s@v%s: %s", p.Name, p.Version, resp.Status)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+
+	// 返回内容的格式为：模块名 版本号 h1:哈希
+	for _, line := range strings.Split(string(body), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 3 && fields[0] == p.Name && fields[1] == "v"+p.Version {
+			return fields[2], nil
+		}
+	}
+
+	return "", fmt.Errorf("checksum for %s@v%s not found", p.Name, p.Version)
+}
+
 // 解压文件
 func (p *PkgGo) Unzip() error {
 
@@ -126,6 +222,13 @@ func (p *PkgGo) Save(dir string, path string) error {
 		return err
 	}
 
+	// 校验下载的文件，校验失败时删除
+	err = p.Verify()
+	if err != nil {
+		os.RemoveAll("./tmp/v" + p.Version + ".zip")
+		return err
+	}
+
 	// 解压下载文件
 	err = p.Unzip()
 	if err != nil {
@@ -56,6 +56,7 @@ var ContentTypeList = map[string]string{
 	"image/png":                          "png",
 	"text/html":                          "html",
 	"text/plain":                         "txt",
+	"text/csv":                           "csv",
 	"text/json":                          "json",
 	"text/rtf":                           "rtf",
 	"application/xml":                    "xml",
@@ -0,0 +1,102 @@
+package storage
+
+import (
+	"errors"
+	"io"
+	"sort"
+	"sync"
+	"time"
+)
+
+// 存储驱动接口，通过RegisterDriver注册后即可在Config.Driver中使用
+type Driver interface {
+
+	// 写入文件
+	Put(path string, reader io.Reader, size int64, contentType string) error
+
+	// 读取文件，调用方需要关闭返回的io.ReadCloser
+	Get(path string) (io.ReadCloser, error)
+
+	// 删除文件
+	Delete(path string) error
+
+	// 判断文件是否存在
+	Exists(path string) (bool, error)
+
+	// 获取文件访问地址
+	URL(path string) string
+
+	// 获取文件信息
+	Stat(path string) (*ObjectInfo, error)
+}
+
+// 存储对象信息
+type ObjectInfo struct {
+	Path         string    `json:"path"`         // 存储路径
+	Size         int64     `json:"size"`         // 文件大小
+	ContentType  string    `json:"contentType"`  // 文件类型
+	LastModified time.Time `json:"lastModified"` // 最后修改时间
+}
+
+// 驱动构造方法
+type DriverFactory func(config *Config) (Driver, error)
+
+var (
+	driversMu sync.RWMutex
+	drivers   = map[string]DriverFactory{}
+)
+
+// 注册存储驱动，同名驱动会被覆盖
+func RegisterDriver(name string, factory DriverFactory) {
+	if factory == nil {
+		panic("storage: register driver " + name + " is nil")
+	}
+
+	driversMu.Lock()
+	defer driversMu.Unlock()
+
+	drivers[name] = factory
+}
+
+// 获取已注册的驱动名称
+func Drivers() []string {
+	driversMu.RLock()
+	defer driversMu.RUnlock()
+
+	names := []string{}
+	for name := range drivers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// 根据配置创建驱动实例
+func NewDriver(config *Config) (Driver, error) {
+	driverName := config.Driver
+	if driverName == "" {
+		driverName = LocalDriver
+	}
+
+	driversMu.RLock()
+	factory, ok := drivers[driverName]
+	driversMu.RUnlock()
+	if !ok {
+		return nil, errors.New("上传驱动未知：" + driverName)
+	}
+
+	return factory(config)
+}
+
+func init() {
+	RegisterDriver(LocalDriver, func(config *Config) (Driver, error) {
+		return NewLocal(), nil
+	})
+	RegisterDriver(OssDriver, func(config *Config) (Driver, error) {
+		return NewOSS(config.OSSConfig)
+	})
+	RegisterDriver(MinioDriver, func(config *Config) (Driver, error) {
+		return NewMinio(config.MinioConfig)
+	})
+}
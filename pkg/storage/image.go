@@ -0,0 +1,274 @@
+package storage
+
+import (
+	"bytes"
+	"image"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+// 图片缩放模式
+const (
+	ImageFit  = "fit"  // 等比缩放至宽高范围内
+	ImageFill = "fill" // 等比缩放后居中裁剪，输出尺寸与宽高一致
+)
+
+// 图片衍生版本配置
+type ImageVariant struct {
+	Name    string // 版本名称，例如thumbnail、medium、webp
+	Width   int    // 最大宽度，为0时不限制
+	Height  int    // 最大高度，为0时不限制
+	Mode    string // 缩放模式，默认fit
+	Format  string // 输出格式，为空时与原图一致
+	Quality int    // 输出质量，仅对有损格式生效
+}
+
+// 图片编码方法
+type ImageEncoder func(w io.Writer, img image.Image, quality int) error
+
+var (
+	imageEncodersMu sync.RWMutex
+	imageEncoders   = map[string]ImageEncoder{}
+)
+
+// 图片格式对应的文件类型
+var imageContentTypes = map[string]string{
+	"jpeg": "image/jpeg",
+	"png":  "image/png",
+	"gif":  "image/gif",
+	"webp": "image/webp",
+}
+
+// 图片格式对应的扩展名
+var imageExts = map[string]string{
+	"jpeg": "jpg",
+	"png":  "png",
+	"gif":  "gif",
+	"webp": "webp",
+}
+
+// 初始化图片衍生版本配置
+func NewImageVariant(name string, width int, height int) *ImageVariant {
+	return &ImageVariant{
+		Name:   name,
+		Width:  width,
+		Height: height,
+		Mode:   ImageFit,
+	}
+}
+
+// 设置缩放模式
+func (p *ImageVariant) SetMode(mode string) *ImageVariant {
+	p.Mode = mode
+
+	return p
+}
+
+// 设置输出格式
+func (p *ImageVariant) SetFormat(format string) *ImageVariant {
+	p.Format = format
+
+	return p
+}
+
+// 设置输出质量
+func (p *ImageVariant) SetQuality(quality int) *ImageVariant {
+	p.Quality = quality
+
+	return p
+}
+
+// 注册图片编码器，webp等格式可通过第三方库注册
+func RegisterImageEncoder(format string, encoder ImageEncoder) {
+	imageEncodersMu.Lock()
+	defer imageEncodersMu.Unlock()
+
+	imageEncoders[format] = encoder
+}
+
+// 获取图片编码器
+func getImageEncoder(format string) (ImageEncoder, bool) {
+	imageEncodersMu.RLock()
+	defer imageEncodersMu.RUnlock()
+
+	encoder, ok := imageEncoders[format]
+
+	return encoder, ok
+}
+
+// 判断是否支持输出指定格式
+func CanEncodeImage(format string) bool {
+	_, ok := getImageEncoder(format)
+
+	return ok
+}
+
+// 缩放图片
+func ResizeImage(img image.Image, width int, height int, mode string) image.Image {
+	bounds := img.Bounds()
+	srcW, srcH := bounds.Dx(), bounds.Dy()
+	if srcW == 0 || srcH == 0 || (width <= 0 && height <= 0) {
+		return img
+	}
+	if width <= 0 {
+		width = srcW * height / srcH
+	}
+	if height <= 0 {
+		height = srcH * width / srcW
+	}
+
+	// 裁剪填充：按较大的缩放比例缩放，再居中裁剪
+	if mode == ImageFill {
+		scaleW := float64(width) / float64(srcW)
+		scaleH := float64(height) / float64(srcH)
+		cropW, cropH := srcW, srcH
+		if scaleW > scaleH {
+			cropH = int(float64(height) / scaleW)
+		} else {
+			cropW = int(float64(width) / scaleH)
+		}
+		if cropW > srcW {
+			cropW = srcW
+		}
+		if cropH > srcH {
+			cropH = srcH
+		}
+
+		x := bounds.Min.X + (srcW-cropW)/2
+		y := bounds.Min.Y + (srcH-cropH)/2
+		dst := image.NewRGBA(image.Rect(0, 0, width, height))
+		draw.CatmullRom.Scale(dst, dst.Bounds(), img, image.Rect(x, y, x+cropW, y+cropH), draw.Src, nil)
+
+		return dst
+	}
+
+	// 等比缩放，不放大原图
+	if srcW <= width && srcH <= height {
+		return img
+	}
+	if srcW*height > srcH*width {
+		height = srcH * width / srcW
+	} else {
+		width = srcW * height / srcH
+	}
+	if width < 1 {
+		width = 1
+	}
+	if height < 1 {
+		height = 1
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img, bounds, draw.Src, nil)
+
+	return dst
+}
+
+// 获取衍生版本的保存路径，例如a.jpg的thumbnail版本为a_thumbnail.jpg
+func ImageVariantPath(path string, name string, format string) string {
+	return strings.TrimSuffix(path, filepath.Ext(path)) + "_" + name + "." + imageExts[format]
+}
+
+// 生成图片衍生版本并通过存储驱动保存，path为已保存的原图路径，未注册编码器的格式会被跳过
+func (p *FileSystem) MakeImageVariants(path string, variants []*ImageVariant) (map[string]*FileInfo, error) {
+	results := map[string]*FileInfo{}
+	if len(variants) == 0 {
+		return results, nil
+	}
+
+	driver, err := p.Storage()
+	if err != nil {
+		return results, err
+	}
+
+	reader, err := driver.Get(path)
+	if err != nil {
+		return results, err
+	}
+	defer reader.Close()
+
+	img, format, err := image.Decode(reader)
+	if err != nil {
+		return results, err
+	}
+
+	for _, variant := range variants {
+		outputFormat := variant.Format
+		if outputFormat == "" {
+			outputFormat = format
+		}
+
+		encoder, ok := getImageEncoder(outputFormat)
+		if !ok {
+			continue
+		}
+
+		resized := ResizeImage(img, variant.Width, variant.Height, variant.Mode)
+
+		buffer := &bytes.Buffer{}
+		err = encoder(buffer, resized, variant.Quality)
+		if err != nil {
+			return results, err
+		}
+
+		variantPath := ImageVariantPath(path, variant.Name, outputFormat)
+		err = driver.Put(variantPath, bytes.NewReader(buffer.Bytes()), int64(buffer.Len()), imageContentTypes[outputFormat])
+		if err != nil {
+			return results, err
+		}
+
+		results[variant.Name] = &FileInfo{
+			Name:        variant.Name,
+			Size:        int64(buffer.Len()),
+			Ext:         imageExts[outputFormat],
+			ContentType: imageContentTypes[outputFormat],
+			Path:        variantPath,
+			Url:         driver.URL(variantPath),
+			Width:       resized.Bounds().Dx(),
+			Height:      resized.Bounds().Dy(),
+		}
+	}
+
+	return results, nil
+}
+
+// 删除图片衍生版本
+func (p *FileSystem) DeleteImageVariants(paths []string) error {
+	driver, err := p.Storage()
+	if err != nil {
+		return err
+	}
+
+	for _, path := range paths {
+		err = driver.Delete(path)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func init() {
+	RegisterImageEncoder("jpeg", func(w io.Writer, img image.Image, quality int) error {
+		if quality <= 0 {
+			quality = 85
+		}
+
+		return jpeg.Encode(w, img, &jpeg.Options{Quality: quality})
+	})
+	RegisterImageEncoder("png", func(w io.Writer, img image.Image, quality int) error {
+		return png.Encode(w, img)
+	})
+	RegisterImageEncoder("gif", func(w io.Writer, img image.Image, quality int) error {
+		return gif.Encode(w, img, nil)
+	})
+}
@@ -0,0 +1,250 @@
+package storage
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/gabriel-vasile/mimetype"
+	"github.com/quarkcloudio/quark-go/v2/pkg/utils/file"
+	"github.com/quarkcloudio/quark-go/v2/pkg/utils/rand"
+)
+
+// 本地存储驱动
+type Local struct {
+	TempPath   string        // 分片上传临时目录
+	TempExpire time.Duration // 分片临时目录过期时间，超过此时间未写入的目录在新建分片上传时清理
+}
+
+// 初始化本地存储驱动
+func NewLocal() *Local {
+	return &Local{
+		TempPath:   filepath.Join(os.TempDir(), "quark-multipart"),
+		TempExpire: 24 * time.Hour,
+	}
+}
+
+// 写入文件
+func (p *Local) Put(path string, reader io.Reader, size int64, contentType string) error {
+	dir := filepath.Dir(path)
+	if !file.IsExist(dir) {
+		err := os.MkdirAll(dir, 0755)
+		if err != nil {
+			return err
+		}
+	}
+
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0666)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = io.Copy(f, reader)
+
+	return err
+}
+
+// 读取文件
+func (p *Local) Get(path string) (io.ReadCloser, error) {
+	return os.Open(path)
+}
+
+// 删除文件
+func (p *Local) Delete(path string) error {
+	err := os.Remove(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+
+	return err
+}
+
+// 判断文件是否存在
+func (p *Local) Exists(path string) (bool, error) {
+	_, err := os.Stat(path)
+	if err == nil {
+		return true, nil
+	}
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+
+	return false, err
+}
+
+// 获取文件访问地址，本地文件由上层根据站点域名重写
+func (p *Local) URL(path string) string {
+	return path
+}
+
+// 获取文件信息
+func (p *Local) Stat(path string) (*ObjectInfo, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	contentType := ""
+	mtype, err := mimetype.DetectFile(path)
+	if err == nil {
+		contentType = mtype.String()
+	}
+
+	return &ObjectInfo{
+		Path:         path,
+		Size:         info.Size(),
+		ContentType:  contentType,
+		LastModified: info.ModTime(),
+	}, nil
+}
+
+// 分片临时目录
+func (p *Local) partsPath(uploadId string) string {
+	return filepath.Join(p.TempPath, filepath.Base(uploadId))
+}
+
+// 清理过期的分片临时目录，上传中断或会话过期后未取消的目录不会被删除
+func (p *Local) clearExpiredParts() {
+	if p.TempExpire <= 0 {
+		return
+	}
+
+	entries, err := os.ReadDir(p.TempPath)
+	if err != nil {
+		return
+	}
+
+	expiredAt := time.Now().Add(-p.TempExpire)
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+
+		info, err := entry.Info()
+		if err != nil || info.ModTime().After(expiredAt) {
+			continue
+		}
+
+		os.RemoveAll(filepath.Join(p.TempPath, entry.Name()))
+	}
+}
+
+// 初始化分片上传
+func (p *Local) InitMultipart(path string, contentType string) (string, error) {
+	p.clearExpiredParts()
+
+	uploadId := rand.MakeAlphanumeric(32)
+
+	err := os.MkdirAll(p.partsPath(uploadId), 0755)
+	if err != nil {
+		return "", err
+	}
+
+	return uploadId, nil
+}
+
+// 上传分片，分片保存在临时目录中
+func (p *Local) PutPart(path string, uploadId string, partNumber int, reader io.Reader, size int64) (*Part, error) {
+	partsPath := p.partsPath(uploadId)
+	if !file.IsExist(partsPath) {
+		return nil, errors.New("分片上传不存在或已过期")
+	}
+
+	// 先写入临时文件，写入完成后再重命名，避免中断时留下不完整的分片
+	partPath := filepath.Join(partsPath, strconv.Itoa(partNumber))
+	f, err := os.CreateTemp(partsPath, "part-*")
+	if err != nil {
+		return nil, err
+	}
+
+	hash := md5.New()
+	written, err := io.Copy(io.MultiWriter(f, hash), reader)
+	f.Close()
+	if err == nil && written != size {
+		err = io.ErrUnexpectedEOF
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		return nil, err
+	}
+
+	err = os.Rename(f.Name(), partPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Part{
+		Number: partNumber,
+		Size:   written,
+		ETag:   hex.EncodeToString(hash.Sum(nil)),
+	}, nil
+}
+
+// 获取已上传的分片
+func (p *Local) ListParts(path string, uploadId string) ([]*Part, error) {
+	entries, err := os.ReadDir(p.partsPath(uploadId))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, errors.New("分片上传不存在或已过期")
+		}
+		return nil, err
+	}
+
+	parts := []*Part{}
+	for _, entry := range entries {
+		partNumber, err := strconv.Atoi(entry.Name())
+		if err != nil {
+			continue
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return nil, err
+		}
+
+		parts = append(parts, &Part{
+			Number: partNumber,
+			Size:   info.Size(),
+		})
+	}
+	sort.Slice(parts, func(i, j int) bool {
+		return parts[i].Number < parts[j].Number
+	})
+
+	return parts, nil
+}
+
+// 按分片序号合并文件，完成后删除临时目录
+func (p *Local) CompleteMultipart(path string, uploadId string, parts []*Part) error {
+	partsPath := p.partsPath(uploadId)
+
+	readers := []io.Reader{}
+	for _, part := range parts {
+		f, err := os.Open(filepath.Join(partsPath, strconv.Itoa(part.Number)))
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		readers = append(readers, f)
+	}
+
+	err := p.Put(path, io.MultiReader(readers...), -1, "")
+	if err != nil {
+		return err
+	}
+
+	return os.RemoveAll(partsPath)
+}
+
+// 取消分片上传
+func (p *Local) AbortMultipart(path string, uploadId string) error {
+	return os.RemoveAll(p.partsPath(uploadId))
+}
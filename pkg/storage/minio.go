@@ -0,0 +1,163 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// Minio存储驱动，兼容S3协议
+type Minio struct {
+	config *MinioConfig
+	client *minio.Client
+}
+
+// 初始化Minio存储驱动
+func NewMinio(config *MinioConfig) (*Minio, error) {
+	if config == nil {
+		return nil, errors.New("请配置Minio信息")
+	}
+
+	client, err := minio.New(config.Endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(config.AccessKeyID, config.SecretAccessKey, ""),
+		Secure: config.UseSSL,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Minio{config: config, client: client}, nil
+}
+
+// 写入文件
+func (p *Minio) Put(path string, reader io.Reader, size int64, contentType string) error {
+	if size <= 0 {
+		size = -1
+	}
+
+	_, err := p.client.PutObject(context.Background(), p.config.BucketName, path, reader, size, minio.PutObjectOptions{ContentType: contentType})
+
+	return err
+}
+
+// 读取文件
+func (p *Minio) Get(path string) (io.ReadCloser, error) {
+	return p.client.GetObject(context.Background(), p.config.BucketName, path, minio.GetObjectOptions{})
+}
+
+// 删除文件
+func (p *Minio) Delete(path string) error {
+	return p.client.RemoveObject(context.Background(), p.config.BucketName, path, minio.RemoveObjectOptions{})
+}
+
+// 判断文件是否存在
+func (p *Minio) Exists(path string) (bool, error) {
+	_, err := p.client.StatObject(context.Background(), p.config.BucketName, path, minio.StatObjectOptions{})
+	if err != nil {
+		if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+// 获取文件访问地址
+func (p *Minio) URL(path string) string {
+	return "//" + p.config.Domain + "/" + path
+}
+
+// 获取文件信息
+func (p *Minio) Stat(path string) (*ObjectInfo, error) {
+	info, err := p.client.StatObject(context.Background(), p.config.BucketName, path, minio.StatObjectOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ObjectInfo{
+		Path:         path,
+		Size:         info.Size,
+		ContentType:  info.ContentType,
+		LastModified: info.LastModified,
+	}, nil
+}
+
+// 初始化分片上传
+func (p *Minio) InitMultipart(path string, contentType string) (string, error) {
+	core := minio.Core{Client: p.client}
+
+	return core.NewMultipartUpload(context.Background(), p.config.BucketName, path, minio.PutObjectOptions{ContentType: contentType})
+}
+
+// 上传分片
+func (p *Minio) PutPart(path string, uploadId string, partNumber int, reader io.Reader, size int64) (*Part, error) {
+	core := minio.Core{Client: p.client}
+
+	objectPart, err := core.PutObjectPart(context.Background(), p.config.BucketName, path, uploadId, partNumber, reader, size, minio.PutObjectPartOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Part{
+		Number: objectPart.PartNumber,
+		Size:   objectPart.Size,
+		ETag:   objectPart.ETag,
+	}, nil
+}
+
+// 获取已上传的分片
+func (p *Minio) ListParts(path string, uploadId string) ([]*Part, error) {
+	core := minio.Core{Client: p.client}
+
+	parts := []*Part{}
+	partNumberMarker := 0
+	for {
+		result, err := core.ListObjectParts(context.Background(), p.config.BucketName, path, uploadId, partNumberMarker, 1000)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, objectPart := range result.ObjectParts {
+			parts = append(parts, &Part{
+				Number: objectPart.PartNumber,
+				Size:   objectPart.Size,
+				ETag:   objectPart.ETag,
+			})
+		}
+
+		if !result.IsTruncated {
+			break
+		}
+		partNumberMarker = result.NextPartNumberMarker
+	}
+
+	return parts, nil
+}
+
+// 合并分片
+func (p *Minio) CompleteMultipart(path string, uploadId string, parts []*Part) error {
+	core := minio.Core{Client: p.client}
+
+	completeParts := []minio.CompletePart{}
+	for _, part := range parts {
+		completeParts = append(completeParts, minio.CompletePart{
+			PartNumber: part.Number,
+			ETag:       part.ETag,
+		})
+	}
+
+	_, err := core.CompleteMultipartUpload(context.Background(), p.config.BucketName, path, uploadId, completeParts, minio.PutObjectOptions{})
+
+	return err
+}
+
+// 取消分片上传
+func (p *Minio) AbortMultipart(path string, uploadId string) error {
+	core := minio.Core{Client: p.client}
+
+	return core.AbortMultipartUpload(context.Background(), p.config.BucketName, path, uploadId)
+}
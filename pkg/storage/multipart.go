@@ -0,0 +1,315 @@
+package storage
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"mime"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gabriel-vasile/mimetype"
+	"github.com/quarkcloudio/quark-go/v2/pkg/utils/rand"
+)
+
+// 默认分片大小，S3协议要求除最后一片外每片不小于5MB
+const DefaultChunkSize int64 = 5 * 1024 * 1024
+
+// 分片上传驱动接口，存储驱动实现该接口后即可支持分片上传
+type MultipartDriver interface {
+
+	// 初始化分片上传，返回驱动的上传ID
+	InitMultipart(path string, contentType string) (string, error)
+
+	// 上传分片，partNumber从1开始
+	PutPart(path string, uploadId string, partNumber int, reader io.Reader, size int64) (*Part, error)
+
+	// 获取已上传的分片
+	ListParts(path string, uploadId string) ([]*Part, error)
+
+	// 合并分片
+	CompleteMultipart(path string, uploadId string, parts []*Part) error
+
+	// 取消分片上传，清理已上传的分片
+	AbortMultipart(path string, uploadId string) error
+}
+
+// 分片信息
+type Part struct {
+	Number int    `json:"number"` // 分片序号
+	Size   int64  `json:"size"`   // 分片大小
+	ETag   string `json:"etag"`   // 分片标识
+}
+
+// 分片上传会话
+type MultipartUpload struct {
+	UploadId       string    `json:"uploadId"`       // 上传ID
+	DriverUploadId string    `json:"driverUploadId"` // 存储驱动返回的上传ID
+	Name           string    `json:"name"`           // 文件名称
+	Size           int64     `json:"size"`           // 文件大小
+	Ext            string    `json:"ext"`            // 文件扩展名
+	ContentType    string    `json:"contentType"`    // 文件类型
+	Sha256         string    `json:"sha256"`         // 客户端提供的文件内容SHA256，合并后校验
+	ChunkSize      int64     `json:"chunkSize"`      // 分片大小
+	Path           string    `json:"path"`           // 保存路径
+	Owner          string    `json:"owner"`          // 上传者，例如admin|1，续传、合并及取消时校验
+	Resource       string    `json:"resource"`       // 上传资源，续传、合并及取消时校验
+	CreatedAt      time.Time `json:"createdAt"`      // 创建时间
+}
+
+// 分片总数
+func (p *MultipartUpload) PartCount() int {
+	if p.ChunkSize <= 0 {
+		return 0
+	}
+
+	return int((p.Size + p.ChunkSize - 1) / p.ChunkSize)
+}
+
+// 指定分片的大小
+func (p *MultipartUpload) PartSize(partNumber int) int64 {
+	if partNumber < p.PartCount() {
+		return p.ChunkSize
+	}
+
+	return p.Size - int64(p.PartCount()-1)*p.ChunkSize
+}
+
+// 获取支持分片上传的驱动实例
+func (p *FileSystem) multipartStorage() (MultipartDriver, error) {
+	driver, err := p.Storage()
+	if err != nil {
+		return nil, err
+	}
+
+	multipartDriver, ok := driver.(MultipartDriver)
+	if !ok {
+		return nil, errors.New("存储驱动不支持分片上传：" + p.Config.Driver)
+	}
+
+	return multipartDriver, nil
+}
+
+// 初始化分片上传，文件名称、大小、类型通过Reader设置，sha256为文件内容的哈希值，可为空
+func (p *FileSystem) InitMultipart(sha256 string, chunkSize int64) (*MultipartUpload, error) {
+	if p.Config.SavePath == "" {
+		return nil, errors.New("请设置保存路径")
+	}
+	if p.File == nil || p.File.Name == "" || p.File.Size <= 0 {
+		return nil, errors.New("请设置文件名称及大小")
+	}
+	if chunkSize <= 0 {
+		chunkSize = DefaultChunkSize
+	}
+
+	// 未指定类型时根据扩展名获取
+	if p.File.ContentType == "" {
+		p.File.ContentType = strings.Split(mime.TypeByExtension(filepath.Ext(p.File.Name)), ";")[0]
+	}
+
+	fileExt := ContentTypeList[p.File.ContentType]
+	if fileExt == "" {
+		return nil, errors.New("无法获取文件扩展名！")
+	}
+	p.File.Ext = fileExt
+
+	err := p.checkFileSize()
+	if err != nil {
+		return nil, err
+	}
+
+	err = p.checkFileType()
+	if err != nil {
+		return nil, err
+	}
+
+	driver, err := p.multipartStorage()
+	if err != nil {
+		return nil, err
+	}
+
+	saveName := rand.MakeAlphanumeric(40) + "." + p.File.Ext
+	driverUploadId, err := driver.InitMultipart(p.Config.SavePath+saveName, p.File.ContentType)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MultipartUpload{
+		UploadId:       rand.MakeAlphanumeric(32),
+		DriverUploadId: driverUploadId,
+		Name:           p.File.Name,
+		Size:           p.File.Size,
+		Ext:            p.File.Ext,
+		ContentType:    p.File.ContentType,
+		Sha256:         strings.ToLower(sha256),
+		ChunkSize:      chunkSize,
+		Path:           p.Config.SavePath + saveName,
+		CreatedAt:      time.Now(),
+	}, nil
+}
+
+// 上传分片
+func (p *FileSystem) UploadPart(upload *MultipartUpload, partNumber int, reader io.Reader) (*Part, error) {
+	if partNumber < 1 || partNumber > upload.PartCount() {
+		return nil, errors.New("分片序号错误：" + strconv.Itoa(partNumber))
+	}
+
+	driver, err := p.multipartStorage()
+	if err != nil {
+		return nil, err
+	}
+
+	size := upload.PartSize(partNumber)
+	counter := &countingReader{reader: reader}
+	part, err := driver.PutPart(upload.Path, upload.DriverUploadId, partNumber, io.LimitReader(counter, size), size)
+
+	// 分片内容不足或超出时，需要客户端重新上传该分片
+	if counter.count != size {
+		return nil, errors.New("分片大小错误，应为" + strconv.FormatInt(size, 10) + "字节")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if extra, _ := reader.Read(make([]byte, 1)); extra > 0 {
+		return nil, errors.New("分片大小错误，应为" + strconv.FormatInt(size, 10) + "字节")
+	}
+
+	return part, nil
+}
+
+// 获取已上传的分片
+func (p *FileSystem) ListParts(upload *MultipartUpload) ([]*Part, error) {
+	driver, err := p.multipartStorage()
+	if err != nil {
+		return nil, err
+	}
+
+	return driver.ListParts(upload.Path, upload.DriverUploadId)
+}
+
+// 合并分片并校验文件，校验失败时删除已合并的文件
+func (p *FileSystem) CompleteMultipart(upload *MultipartUpload) (*FileInfo, error) {
+	driver, err := p.multipartStorage()
+	if err != nil {
+		return nil, err
+	}
+
+	parts, err := driver.ListParts(upload.Path, upload.DriverUploadId)
+	if err != nil {
+		return nil, err
+	}
+	if len(parts) != upload.PartCount() {
+		return nil, errors.New("分片未上传完成，已上传" + strconv.Itoa(len(parts)) + "/" + strconv.Itoa(upload.PartCount()))
+	}
+
+	err = driver.CompleteMultipart(upload.Path, upload.DriverUploadId, parts)
+	if err != nil {
+		return nil, err
+	}
+
+	fileInfo, err := p.verifyMultipart(upload)
+	if err != nil {
+		p.Delete(upload.Path)
+		return nil, err
+	}
+
+	return fileInfo, nil
+}
+
+// 取消分片上传
+func (p *FileSystem) AbortMultipart(upload *MultipartUpload) error {
+	driver, err := p.multipartStorage()
+	if err != nil {
+		return err
+	}
+
+	return driver.AbortMultipart(upload.Path, upload.DriverUploadId)
+}
+
+// 读取合并后的文件，校验大小、哈希值及图片宽高
+func (p *FileSystem) verifyMultipart(upload *MultipartUpload) (*FileInfo, error) {
+	reader, err := p.Get(upload.Path)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	// 文件头用于读取图片宽高
+	head := make([]byte, 3072)
+	n, err := io.ReadFull(reader, head)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return nil, err
+	}
+	head = head[:n]
+
+	hash := sha256.New()
+	hash.Write(head)
+	size, err := io.Copy(hash, reader)
+	if err != nil {
+		return nil, err
+	}
+	size += int64(n)
+
+	if size != upload.Size {
+		return nil, errors.New("文件大小校验失败")
+	}
+
+	contentHash := hex.EncodeToString(hash.Sum(nil))
+	if upload.Sha256 != "" && upload.Sha256 != contentHash {
+		return nil, errors.New("文件哈希值校验失败")
+	}
+
+	// 与GetFileHash保持一致，哈希值为文件内容拼接文件名称
+	hash.Write([]byte(upload.Name))
+
+	p.File = &File{
+		Name:        upload.Name,
+		Size:        size,
+		Ext:         upload.Ext,
+		ContentType: upload.ContentType,
+		Content:     bytes.NewReader(head),
+		Hash:        hex.EncodeToString(hash.Sum(nil)),
+	}
+	if strings.HasPrefix(mimetype.Detect(head).String(), "image/") {
+		p.WithImageWH()
+	}
+
+	err = p.CheckFile()
+	if err != nil {
+		return nil, err
+	}
+
+	driver, err := p.Storage()
+	if err != nil {
+		return nil, err
+	}
+
+	return &FileInfo{
+		p.File.Name,
+		p.File.Size,
+		p.File.Ext,
+		p.File.ContentType,
+		upload.Path,
+		driver.URL(upload.Path),
+		p.File.Hash,
+		p.File.Width,
+		p.File.Height,
+	}, nil
+}
+
+// 统计读取字节数
+type countingReader struct {
+	reader io.Reader
+	count  int64
+}
+
+func (p *countingReader) Read(b []byte) (int, error) {
+	n, err := p.reader.Read(b)
+	p.count += int64(n)
+
+	return n, err
+}
@@ -0,0 +1,176 @@
+package storage
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/aliyun/aliyun-oss-go-sdk/oss"
+)
+
+// 阿里云OSS存储驱动
+type OSS struct {
+	config *OSSConfig
+	bucket *oss.Bucket
+}
+
+// 初始化OSS存储驱动
+func NewOSS(config *OSSConfig) (*OSS, error) {
+	if config == nil {
+		return nil, errors.New("请配置OSS信息")
+	}
+
+	client, err := oss.New(config.Endpoint, config.AccessKeyID, config.AccessKeySecret)
+	if err != nil {
+		return nil, err
+	}
+
+	bucket, err := client.Bucket(config.BucketName)
+	if err != nil {
+		return nil, err
+	}
+
+	return &OSS{config: config, bucket: bucket}, nil
+}
+
+// 写入文件
+func (p *OSS) Put(path string, reader io.Reader, size int64, contentType string) error {
+	options := []oss.Option{
+		oss.ObjectACL(oss.ACLPublicRead), // 指定Object访问权限
+	}
+	if contentType != "" {
+		options = append(options, oss.ContentType(contentType))
+	}
+
+	return p.bucket.PutObject(path, reader, options...)
+}
+
+// 读取文件
+func (p *OSS) Get(path string) (io.ReadCloser, error) {
+	return p.bucket.GetObject(path)
+}
+
+// 删除文件
+func (p *OSS) Delete(path string) error {
+	return p.bucket.DeleteObject(path)
+}
+
+// 判断文件是否存在
+func (p *OSS) Exists(path string) (bool, error) {
+	return p.bucket.IsObjectExist(path)
+}
+
+// 获取文件访问地址
+func (p *OSS) URL(path string) string {
+	if p.config.Domain != "" {
+		return "//" + p.config.Domain + "/" + path
+	}
+
+	return "//" + p.config.BucketName + "." + p.config.Endpoint + "/" + path
+}
+
+// 获取文件信息
+func (p *OSS) Stat(path string) (*ObjectInfo, error) {
+	header, err := p.bucket.GetObjectDetailedMeta(path)
+	if err != nil {
+		return nil, err
+	}
+
+	size, _ := strconv.ParseInt(header.Get("Content-Length"), 10, 64)
+	lastModified, _ := http.ParseTime(header.Get("Last-Modified"))
+
+	return &ObjectInfo{
+		Path:         path,
+		Size:         size,
+		ContentType:  header.Get("Content-Type"),
+		LastModified: lastModified,
+	}, nil
+}
+
+// 分片上传标识
+func (p *OSS) multipartResult(path string, uploadId string) oss.InitiateMultipartUploadResult {
+	return oss.InitiateMultipartUploadResult{
+		Bucket:   p.config.BucketName,
+		Key:      path,
+		UploadID: uploadId,
+	}
+}
+
+// 初始化分片上传
+func (p *OSS) InitMultipart(path string, contentType string) (string, error) {
+	options := []oss.Option{
+		oss.ObjectACL(oss.ACLPublicRead), // 指定Object访问权限
+	}
+	if contentType != "" {
+		options = append(options, oss.ContentType(contentType))
+	}
+
+	result, err := p.bucket.InitiateMultipartUpload(path, options...)
+	if err != nil {
+		return "", err
+	}
+
+	return result.UploadID, nil
+}
+
+// 上传分片
+func (p *OSS) PutPart(path string, uploadId string, partNumber int, reader io.Reader, size int64) (*Part, error) {
+	uploadPart, err := p.bucket.UploadPart(p.multipartResult(path, uploadId), reader, size, partNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Part{
+		Number: uploadPart.PartNumber,
+		Size:   size,
+		ETag:   uploadPart.ETag,
+	}, nil
+}
+
+// 获取已上传的分片
+func (p *OSS) ListParts(path string, uploadId string) ([]*Part, error) {
+	parts := []*Part{}
+	partNumberMarker := 0
+	for {
+		result, err := p.bucket.ListUploadedParts(p.multipartResult(path, uploadId), oss.MaxParts(1000), oss.PartNumberMarker(partNumberMarker))
+		if err != nil {
+			return nil, err
+		}
+
+		for _, uploadedPart := range result.UploadedParts {
+			parts = append(parts, &Part{
+				Number: uploadedPart.PartNumber,
+				Size:   int64(uploadedPart.Size),
+				ETag:   uploadedPart.ETag,
+			})
+		}
+
+		if !result.IsTruncated {
+			break
+		}
+		partNumberMarker, _ = strconv.Atoi(result.NextPartNumberMarker)
+	}
+
+	return parts, nil
+}
+
+// 合并分片
+func (p *OSS) CompleteMultipart(path string, uploadId string, parts []*Part) error {
+	uploadParts := []oss.UploadPart{}
+	for _, part := range parts {
+		uploadParts = append(uploadParts, oss.UploadPart{
+			PartNumber: part.Number,
+			ETag:       part.ETag,
+		})
+	}
+
+	_, err := p.bucket.CompleteMultipartUpload(p.multipartResult(path, uploadId), uploadParts)
+
+	return err
+}
+
+// 取消分片上传
+func (p *OSS) AbortMultipart(path string, uploadId string) error {
+	return p.bucket.AbortMultipartUpload(p.multipartResult(path, uploadId))
+}
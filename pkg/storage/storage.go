@@ -1,8 +1,6 @@
 package storage
 
 import (
-	"bytes"
-	"context"
 	"crypto/sha256"
 	"encoding/hex"
 	"errors"
@@ -16,14 +14,11 @@ import (
 	"strconv"
 	"strings"
 
-	"github.com/aliyun/aliyun-oss-go-sdk/oss"
 	"github.com/gabriel-vasile/mimetype"
-	"github.com/minio/minio-go/v7"
-	"github.com/minio/minio-go/v7/pkg/credentials"
-	"github.com/quarkcloudio/quark-go/v2/pkg/utils/file"
 	"github.com/quarkcloudio/quark-go/v2/pkg/utils/rand"
 )
 
+// 内置存储驱动，其他驱动可通过RegisterDriver注册
 var (
 	OssDriver   = "oss"
 	LocalDriver = "local"
@@ -72,7 +67,7 @@ type File struct {
 	Size        int64               // 文件大小
 	Ext         string              // 文件扩展名
 	ContentType string              // 文件类型
-	Content     []byte              // 文件内容
+	Content     io.Reader           // 文件内容
 	Hash        string              // 文件哈希值
 	Width       int                 // 如果为图片，则返回宽度
 	Height      int                 // 如果为图片，则返回高度
@@ -93,8 +88,10 @@ type FileInfo struct {
 
 // 结构体
 type FileSystem struct {
-	Config *Config // 配置信息
-	File   *File   // 文件信息
+	Config   *Config  // 配置信息
+	File     *File    // 文件信息
+	tempFile *os.File // 无法重复读取的内容会先写入临时文件
+	err      error    // 读取文件内容时的错误
 }
 
 // 初始化对象
@@ -108,26 +105,114 @@ func New(config *Config) *FileSystem {
 	}
 }
 
-// 设置文件信息
+// 设置文件信息，文件内容以流的方式读取，不会整体加载到内存中
 func (p *FileSystem) Reader(file *File) *FileSystem {
-	if file.Size == 0 {
-		file.Size = int64(len(file.Content))
+	p.File = file
+	if file.Content == nil {
+		return p
 	}
+
+	p.err = p.bufferContent()
+	if p.err != nil {
+		return p
+	}
+
 	if file.ContentType == "" {
 		if file.Header != nil {
 			if len(file.Header["Content-Type"]) > 0 {
 				file.ContentType = file.Header["Content-Type"][0]
 			}
 		} else {
-			file.ContentType = mimetype.Detect(file.Content).String()
+			content, err := p.content()
+			if err == nil {
+				mtype, err := mimetype.DetectReader(content)
+				if err == nil {
+					file.ContentType = mtype.String()
+				}
+			}
 		}
 	}
 
-	p.File = file
-
 	return p
 }
 
+// 确保文件内容可以重复读取，不支持Seek的内容先写入临时文件
+func (p *FileSystem) bufferContent() error {
+	p.Close()
+
+	if seeker, ok := p.File.Content.(io.ReadSeeker); ok {
+		if p.File.Size == 0 {
+			size, err := seeker.Seek(0, io.SeekEnd)
+			if err != nil {
+				return err
+			}
+			p.File.Size = size
+		}
+
+		_, err := seeker.Seek(0, io.SeekStart)
+
+		return err
+	}
+
+	tempFile, err := os.CreateTemp("", "quark-upload-*")
+	if err != nil {
+		return err
+	}
+	p.tempFile = tempFile
+
+	// 设置了大小限制时最多多读取一个字节，超出限制即可停止读取
+	reader := p.File.Content
+	if p.Config.LimitSize > 0 {
+		reader = io.LimitReader(reader, p.Config.LimitSize+1)
+	}
+
+	size, err := io.Copy(tempFile, reader)
+	if err != nil {
+		return err
+	}
+	if p.Config.LimitSize > 0 && size > p.Config.LimitSize {
+		return errors.New("上传文件大小超出限制！")
+	}
+	p.File.Size = size
+	p.File.Content = tempFile
+
+	_, err = tempFile.Seek(0, io.SeekStart)
+
+	return err
+}
+
+// 获取从头读取的文件内容
+func (p *FileSystem) content() (io.ReadSeeker, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+	if p.File == nil || p.File.Content == nil {
+		return nil, errors.New("文件内容为空")
+	}
+
+	seeker, ok := p.File.Content.(io.ReadSeeker)
+	if !ok {
+		return nil, errors.New("文件内容无法重复读取")
+	}
+
+	_, err := seeker.Seek(0, io.SeekStart)
+
+	return seeker, err
+}
+
+// 清理读取文件时产生的临时文件
+func (p *FileSystem) Close() error {
+	if p.tempFile == nil {
+		return nil
+	}
+
+	p.tempFile.Close()
+	err := os.Remove(p.tempFile.Name())
+	p.tempFile = nil
+
+	return err
+}
+
 // 设置文件标头
 func (p *FileSystem) FileHeader(fileHeader map[string][]string) *FileSystem {
 	p.File.Header = fileHeader
@@ -142,9 +227,11 @@ func (p *FileSystem) FileName(fileName string) *FileSystem {
 	return p
 }
 
-// 设置二进制内容
-func (p *FileSystem) FileContent(fileContent []byte) *FileSystem {
+// 设置文件内容
+func (p *FileSystem) FileContent(fileContent io.Reader) *FileSystem {
 	p.File.Content = fileContent
+	p.File.Size = 0
+	p.err = p.bufferContent()
 
 	return p
 }
@@ -179,8 +266,13 @@ func (p *FileSystem) LimitImageHeight(limitImageHeight int) *FileSystem {
 
 // 读取图片宽高
 func (p *FileSystem) WithImageWH() *FileSystem {
-	byteReader := bytes.NewReader(p.File.Content)
-	imageConfig, _, err := image.DecodeConfig(byteReader)
+	content, err := p.content()
+	if err != nil {
+		fmt.Println(err)
+		return p
+	}
+
+	imageConfig, _, err := image.DecodeConfig(content)
 	if err != nil {
 		fmt.Println(err)
 		return p
@@ -234,13 +326,17 @@ func (p *FileSystem) GetFileHash() (string, error) {
 		err       error
 	)
 
-	sha256New := sha256.New()
+	content, err := p.content()
+	if err != nil {
+		return hashValue, err
+	}
 
-	byteReader := bytes.NewReader(append(p.File.Content, []byte(p.File.Name)...))
-	_, err = io.Copy(sha256New, byteReader)
+	sha256New := sha256.New()
+	_, err = io.Copy(sha256New, content)
 	if err != nil {
 		return hashValue, err
 	}
+	sha256New.Write([]byte(p.File.Name))
 
 	hashValue = hex.EncodeToString(sha256New.Sum(nil))
 
@@ -300,8 +396,12 @@ func (p *FileSystem) checkImageWH() error {
 		return err
 	}
 
-	byteReader := bytes.NewReader(p.File.Content)
-	imageConfig, _, err := image.DecodeConfig(byteReader)
+	content, err := p.content()
+	if err != nil {
+		return err
+	}
+
+	imageConfig, _, err := image.DecodeConfig(content)
 	if err != nil {
 		return err
 	}
@@ -337,8 +437,17 @@ func (p *FileSystem) CheckFile() error {
 	return err
 }
 
-// 保存文件到本地
-func (p *FileSystem) SaveToLocal() error {
+// 获取存储驱动实例
+func (p *FileSystem) Storage() (Driver, error) {
+	return NewDriver(p.Config)
+}
+
+// 使用指定驱动保存文件
+func (p *FileSystem) saveWith(driver Driver) error {
+	if p.err != nil {
+		return p.err
+	}
+
 	savePath := p.Config.SavePath
 	if savePath == "" {
 		return errors.New("请设置保存路径")
@@ -365,16 +474,13 @@ func (p *FileSystem) SaveToLocal() error {
 		p.Config.SaveName = rand.MakeAlphanumeric(40) + "." + p.File.Ext
 	}
 
-	if !file.IsExist(savePath) {
-		err := os.MkdirAll(savePath, os.ModeDir)
+	saveName := p.Config.SaveName
+	if p.Config.CheckFileExist {
+		exist, err := driver.Exists(savePath + saveName)
 		if err != nil {
 			return err
 		}
-	}
-
-	saveName := p.Config.SaveName
-	if p.Config.CheckFileExist {
-		if file.IsExist(savePath + saveName) {
+		if exist {
 			return errors.New("文件已存在：" + savePath + saveName)
 		}
 	}
@@ -386,190 +492,103 @@ func (p *FileSystem) SaveToLocal() error {
 	}
 	p.File.Hash = fileHash
 
-	f, err := os.OpenFile(savePath+saveName, os.O_WRONLY|os.O_CREATE, 0666) //根据文件名创建文件路径
+	content, err := p.content()
 	if err != nil {
 		return err
 	}
-	defer f.Close()
-
-	byteReader := bytes.NewReader(p.File.Content)
-	io.Copy(f, byteReader)
 
-	return nil
+	return driver.Put(savePath+saveName, content, p.File.Size, p.File.ContentType)
 }
 
-// 保存文件到OSS
-func (p *FileSystem) SaveToOSS() error {
-	if p.Config.OSSConfig == nil {
-		return errors.New("请配置OSS信息")
-	}
-
-	savePath := p.Config.SavePath
-	if savePath == "" {
-		return errors.New("请设置保存路径")
-	}
-
-	if p.Config.SaveName == "" {
-		p.Config.SaveName = p.File.Name
-	}
-
-	// 获取文件扩展名
-	fileExt := ContentTypeList[p.File.ContentType]
-	if fileExt == "" {
-		return errors.New("无法获取文件扩展名！")
-	}
-	p.File.Ext = fileExt
-
-	// 检查文件合法性
-	err := p.CheckFile()
+// 使用指定名称的驱动保存文件
+func (p *FileSystem) saveTo(driverName string) error {
+	p.Config.Driver = driverName
+	driver, err := p.Storage()
 	if err != nil {
 		return err
 	}
 
-	if p.Config.SaveRandName {
-		p.Config.SaveName = rand.MakeAlphanumeric(40) + "." + p.File.Ext
-	}
-
-	saveName := p.Config.SaveName
-
-	// 计算文件哈希值
-	fileHash, err := p.GetFileHash()
-	if err != nil {
-		return err
-	}
-	p.File.Hash = fileHash
-
-	client, err := oss.New(p.Config.OSSConfig.Endpoint, p.Config.OSSConfig.AccessKeyID, p.Config.OSSConfig.AccessKeySecret)
-	if err != nil {
-		return err
-	}
-
-	bucket, err := client.Bucket(p.Config.OSSConfig.BucketName)
-	if err != nil {
-		return err
-	}
+	return p.saveWith(driver)
+}
 
-	// 指定Object访问权限
-	objectAcl := oss.ObjectACL(oss.ACLPublicRead)
-	byteReader := bytes.NewReader(p.File.Content)
-	err = bucket.PutObject(savePath+saveName, byteReader, objectAcl)
-	if err != nil {
-		return err
-	}
+// 保存文件到本地
+func (p *FileSystem) SaveToLocal() error {
+	return p.saveTo(LocalDriver)
+}
 
-	return nil
+// 保存文件到OSS
+func (p *FileSystem) SaveToOSS() error {
+	return p.saveTo(OssDriver)
 }
 
 // 保存文件到Minio
 func (p *FileSystem) SaveToMinio() error {
-	if p.Config.MinioConfig == nil {
-		return errors.New("请配置Minio信息")
-	}
-
-	savePath := p.Config.SavePath
-	if savePath == "" {
-		return errors.New("请设置保存路径")
-	}
-
-	if p.Config.SaveName == "" {
-		p.Config.SaveName = p.File.Name
-	}
+	return p.saveTo(MinioDriver)
+}
 
-	// 获取文件扩展名
-	fileExt := ContentTypeList[p.File.ContentType]
-	if fileExt == "" {
-		return errors.New("无法获取文件扩展名！")
+// 保存文件
+func (p *FileSystem) Save() (fileInfo *FileInfo, err error) {
+	driver, err := p.Storage()
+	if err != nil {
+		return fileInfo, err
 	}
-	p.File.Ext = fileExt
 
-	// 检查文件合法性
-	err := p.CheckFile()
+	err = p.saveWith(driver)
 	if err != nil {
-		return err
+		return fileInfo, err
 	}
 
-	if p.Config.SaveRandName {
-		p.Config.SaveName = rand.MakeAlphanumeric(40) + "." + p.File.Ext
+	fileInfo = &FileInfo{
+		p.File.Name,
+		p.File.Size,
+		p.File.Ext,
+		p.File.ContentType,
+		p.Config.SavePath + p.Config.SaveName,
+		driver.URL(p.Config.SavePath + p.Config.SaveName),
+		p.File.Hash,
+		p.File.Width,
+		p.File.Height,
 	}
 
-	saveName := p.Config.SaveName
+	return fileInfo, err
+}
 
-	// 计算文件哈希值
-	fileHash, err := p.GetFileHash()
+// 读取已保存的文件，调用方需要关闭返回的io.ReadCloser
+func (p *FileSystem) Get(path string) (io.ReadCloser, error) {
+	driver, err := p.Storage()
 	if err != nil {
-		return err
+		return nil, err
 	}
-	p.File.Hash = fileHash
 
-	ctx := context.Background()
+	return driver.Get(path)
+}
 
-	// Initialize minio client object.
-	minioClient, err := minio.New(p.Config.MinioConfig.Endpoint, &minio.Options{
-		Creds:  credentials.NewStaticV4(p.Config.MinioConfig.AccessKeyID, p.Config.MinioConfig.SecretAccessKey, ""),
-		Secure: p.Config.MinioConfig.UseSSL,
-	})
+// 删除已保存的文件
+func (p *FileSystem) Delete(path string) error {
+	driver, err := p.Storage()
 	if err != nil {
 		return err
 	}
 
-	byteReader := bytes.NewReader(p.File.Content)
+	return driver.Delete(path)
+}
 
-	// Upload the zip file with FPutObject
-	info, err := minioClient.PutObject(ctx, p.Config.MinioConfig.BucketName, saveName, byteReader, -1, minio.PutObjectOptions{ContentType: p.File.ContentType})
+// 判断文件是否存在
+func (p *FileSystem) Exists(path string) (bool, error) {
+	driver, err := p.Storage()
 	if err != nil {
-		return err
+		return false, err
 	}
 
-	fmt.Printf("Successfully uploaded %s of size %d\n", saveName, info.Size)
-
-	return nil
+	return driver.Exists(path)
 }
 
-// 保存文件
-func (p *FileSystem) Save() (fileInfo *FileInfo, err error) {
-	var fileUrl = ""
-
-	switch p.Config.Driver {
-	case LocalDriver:
-		err = p.SaveToLocal()
-		if err != nil {
-			return fileInfo, err
-		}
-
-		fileUrl = p.Config.SavePath + p.Config.SaveName
-	case OssDriver:
-		err = p.SaveToOSS()
-		if err != nil {
-			return fileInfo, err
-		}
-
-		if p.Config.OSSConfig.Domain != "" {
-			fileUrl = "//" + p.Config.OSSConfig.Domain + "/" + p.Config.SavePath + p.Config.SaveName
-		} else {
-			fileUrl = "//" + p.Config.OSSConfig.BucketName + "." + p.Config.OSSConfig.Endpoint + "/" + p.Config.SavePath + p.Config.SaveName
-		}
-	case MinioDriver:
-		err = p.SaveToMinio()
-		if err != nil {
-			return fileInfo, err
-		}
-
-		fileUrl = "//" + p.Config.MinioConfig.Domain + "/" + p.Config.SavePath + p.Config.SaveName
-	default:
-		return fileInfo, errors.New("上传驱动未知")
-	}
-
-	fileInfo = &FileInfo{
-		p.File.Name,
-		p.File.Size,
-		p.File.Ext,
-		p.File.ContentType,
-		p.Config.SavePath + p.Config.SaveName,
-		fileUrl,
-		p.File.Hash,
-		p.File.Width,
-		p.File.Height,
+// 获取已保存文件的信息
+func (p *FileSystem) Stat(path string) (*ObjectInfo, error) {
+	driver, err := p.Storage()
+	if err != nil {
+		return nil, err
 	}
 
-	return fileInfo, err
+	return driver.Stat(path)
 }
@@ -0,0 +1,22 @@
+package qrcode
+
+import (
+	"encoding/base64"
+
+	"github.com/skip2/go-qrcode"
+)
+
+// 生成PNG图片，size为图片边长，使用纠错等级M
+func PNG(content string, size int) ([]byte, error) {
+	return qrcode.Encode(content, qrcode.Medium, size)
+}
+
+// 生成PNG图片的Data URI，可直接用作img标签的src
+func DataURI(content string, size int) (string, error) {
+	data, err := PNG(content, size)
+	if err != nil {
+		return "", err
+	}
+
+	return "data:image/png;base64," + base64.StdEncoding.EncodeToString(data), nil
+}
@@ -0,0 +1,129 @@
+package web
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+)
+
+// 内嵌的前端静态文件，上传的文件等运行时生成的内容不在其中
+//
+//go:embed app/admin app/miniapp app/favicon.ico app/robots.txt
+var assets embed.FS
+
+// 获取内嵌的前端静态文件，根目录对应web/app
+func Assets() fs.FS {
+	sub, err := fs.Sub(assets, "app")
+	if err != nil {
+		panic(err)
+	}
+
+	return sub
+}
+
+// 叠加的文件系统，优先从磁盘目录读取，不存在时使用内嵌的文件
+type overlayFS struct {
+	disk     fs.FS
+	embedded fs.FS
+}
+
+// 创建叠加的文件系统，dir为磁盘目录，可用于覆盖内嵌的文件，也用于读取上传的文件
+func Overlay(dir string, embedded fs.FS) fs.FS {
+	return &overlayFS{disk: os.DirFS(dir), embedded: embedded}
+}
+
+// 打开文件
+func (p *overlayFS) Open(name string) (fs.File, error) {
+	file, err := p.disk.Open(name)
+	if err == nil {
+		return file, nil
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+
+	return p.embedded.Open(name)
+}
+
+// 注入接口前缀的文件系统，管理后台首页注入脚本，为/api/开头的请求地址添加前缀
+type baseFS struct {
+	fs.FS
+	script []byte
+}
+
+// 管理后台首页
+const adminIndex = "admin/index.html"
+
+// 设置前端的接口前缀，用于挂载在前缀下的应用，例如base为/admin-panel时，/api/admin/login请求/admin-panel/api/admin/login
+func WithBase(filesystem fs.FS, base string) fs.FS {
+	quoted, _ := json.Marshal(base)
+	script := `<script>(function(b){` +
+		`var f=function(u){return typeof u==="string"&&u.indexOf("/api/")===0?b+u:u};` +
+		`var o=XMLHttpRequest.prototype.open;XMLHttpRequest.prototype.open=function(m,u){arguments[1]=f(u);return o.apply(this,arguments)};` +
+		`if(window.fetch){var w=window.fetch;window.fetch=function(u,i){return w.call(this,f(u),i)}}` +
+		`var n=window.open;window.open=function(u){arguments[0]=f(u);return n.apply(this,arguments)}` +
+		`})(` + string(quoted) + `)</script>`
+
+	return &baseFS{FS: filesystem, script: []byte(script)}
+}
+
+// 打开文件，管理后台首页在第一个脚本之前注入脚本
+func (p *baseFS) Open(name string) (fs.File, error) {
+	file, err := p.FS.Open(name)
+	if err != nil || name != adminIndex {
+		return file, err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+	content, err := io.ReadAll(file)
+	if err != nil {
+		return nil, err
+	}
+
+	index := bytes.Index(content, []byte("<script"))
+	if index < 0 {
+		index = len(content)
+	}
+	result := make([]byte, 0, len(content)+len(p.script))
+	result = append(result, content[:index]...)
+	result = append(result, p.script...)
+	result = append(result, content[index:]...)
+
+	return &memFile{Reader: bytes.NewReader(result), info: info, size: int64(len(result))}, nil
+}
+
+// 内存中的文件
+type memFile struct {
+	*bytes.Reader
+	info fs.FileInfo
+	size int64
+}
+
+// 获取文件信息
+func (p *memFile) Stat() (fs.FileInfo, error) {
+	return &memFileInfo{FileInfo: p.info, size: p.size}, nil
+}
+
+// 关闭文件
+func (p *memFile) Close() error {
+	return nil
+}
+
+// 内存中的文件信息，大小为注入后的大小
+type memFileInfo struct {
+	fs.FileInfo
+	size int64
+}
+
+// 文件大小
+func (p *memFileInfo) Size() int64 {
+	return p.size
+}